@@ -0,0 +1,296 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flanksource/clicky/api/tailwind"
+)
+
+// ansiPalette is the 16 colour ANSI palette as Tailwind shades: black, red, green, yellow,
+// blue, magenta, cyan and white, then their bright variants
+var ansiPalette = [16]string{
+	tailwind.TailwindColors["gray"]["900"],
+	tailwind.TailwindColors["red"]["600"],
+	tailwind.TailwindColors["green"]["600"],
+	tailwind.TailwindColors["yellow"]["600"],
+	tailwind.TailwindColors["blue"]["600"],
+	tailwind.TailwindColors["fuchsia"]["600"],
+	tailwind.TailwindColors["cyan"]["600"],
+	tailwind.TailwindColors["gray"]["200"],
+	tailwind.TailwindColors["gray"]["500"],
+	tailwind.TailwindColors["red"]["400"],
+	tailwind.TailwindColors["green"]["400"],
+	tailwind.TailwindColors["yellow"]["400"],
+	tailwind.TailwindColors["blue"]["400"],
+	tailwind.TailwindColors["fuchsia"]["400"],
+	tailwind.TailwindColors["cyan"]["400"],
+	"#ffffff",
+}
+
+// ansiState is the graphic rendition in effect while parsing ANSI output
+type ansiState struct {
+	foreground, background string
+	bold, faint, italic    bool
+	underline, strike      bool
+	inverse                bool
+	href                   string
+}
+
+// text returns a run of content with the styles of the state
+func (s ansiState) text(content string) Text {
+	foreground, background := s.foreground, s.background
+	if s.inverse {
+		foreground, background = background, foreground
+		if foreground == "" {
+			foreground = "#ffffff"
+		}
+		if background == "" {
+			background = ansiPalette[0]
+		}
+	}
+
+	text := Text{Content: content, Href: s.href}
+	if foreground != "" {
+		text.Class.Foreground = &Color{Hex: foreground}
+	}
+	if background != "" {
+		text.Class.Background = &Color{Hex: background}
+	}
+	if s.bold || s.faint || s.italic || s.underline || s.strike {
+		text.Class.Font = &Font{Bold: s.bold, Faint: s.faint, Italic: s.italic, Underline: s.underline, Strikethrough: s.strike}
+	}
+	return text
+}
+
+// ParseANSI parses terminal output, such as the output of a command captured with
+// exec.Process, into Text. SGR sequences with 16, 256 and 24-bit colours, bold, faint,
+// italic, underline, strikethrough and inverse become styles, and OSC 8 hyperlinks
+// become links, so the output renders the same in HTML, Markdown and PDF. Cursor
+// movement, other OSC sequences and control characters are removed.
+func ParseANSI(output string) Text {
+	var root Text
+	var state ansiState
+	var run strings.Builder
+
+	flush := func() {
+		if run.Len() > 0 {
+			root.Children = append(root.Children, state.text(run.String()))
+			run.Reset()
+		}
+	}
+
+	scanANSI(output, func(r rune) {
+		run.WriteRune(r)
+	}, func(final byte, params string) {
+		next := state
+		switch final {
+		case 'm':
+			next = next.apply(params)
+		case ']':
+			if link, ok := strings.CutPrefix(params, "8;"); ok {
+				// OSC 8 is 8;params;url, and an empty url ends the link
+				if _, url, found := strings.Cut(link, ";"); found {
+					next.href = url
+				}
+			}
+		}
+		if next != state {
+			flush()
+			state = next
+		}
+	})
+	flush()
+
+	return root.simplify()
+}
+
+// StripANSI removes ANSI escape sequences and control characters other than newlines
+// and tabs from terminal output
+func StripANSI(output string) string {
+	var b strings.Builder
+	scanANSI(output, func(r rune) { b.WriteRune(r) }, func(byte, string) {})
+	return b.String()
+}
+
+// scanANSI calls text for each printable rune of output, and sequence for each CSI
+// sequence with its final byte and parameters, or each OSC sequence with ']' and its
+// payload. Other escape sequences and control characters are dropped.
+func scanANSI(output string, text func(rune), sequence func(final byte, params string)) {
+	for i := 0; i < len(output); i++ {
+		c := output[i]
+		if c != '\x1b' {
+			if c == '\n' || c == '\t' || c >= ' ' && c != 0x7f {
+				r, size := utf8.DecodeRuneInString(output[i:])
+				text(r)
+				i += size - 1
+			}
+			continue
+		}
+		if i+1 >= len(output) {
+			return
+		}
+
+		switch output[i+1] {
+		case '[':
+			// CSI: parameter and intermediate bytes, ended by a final byte in @ to ~
+			end := i + 2
+			for end < len(output) && (output[end] < '@' || output[end] > '~') {
+				end++
+			}
+			if end == len(output) {
+				return
+			}
+			sequence(output[end], output[i+2:end])
+			i = end
+
+		case ']', 'P', '_', '^':
+			// OSC, DCS, APC and PM strings end with BEL or ST (ESC \)
+			end := i + 2
+			for end < len(output) && output[end] != '\a' && !(output[end] == '\x1b' && end+1 < len(output) && output[end+1] == '\\') {
+				end++
+			}
+			if output[i+1] == ']' {
+				sequence(']', output[i+2:end])
+			}
+			if end < len(output) && output[end] == '\x1b' {
+				end++
+			}
+			i = end
+
+		case '(', ')', '*', '+', '#':
+			// Character set designations take one more byte
+			i += 2
+
+		default:
+			i++
+		}
+	}
+}
+
+// apply returns the state after the SGR parameters, e.g. "1;38;5;208" or "4:3"
+func (s ansiState) apply(params string) ansiState {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		// Colon separated sub-parameters belong to their code, e.g. 38:2::255:0:0
+		sub := strings.FieldsFunc(codes[i], func(r rune) bool { return r == ':' })
+		if len(sub) == 0 {
+			sub = []string{"0"}
+		}
+		code, err := strconv.Atoi(sub[0])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			s = ansiState{href: s.href}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			// 4:0 turns underlining off, 4:1 to 4:5 are its styles
+			s.underline = len(sub) == 1 || sub[1] != "0"
+		case code == 7:
+			s.inverse = true
+		case code == 9:
+			s.strike = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.inverse = false
+		case code == 29:
+			s.strike = false
+		case code >= 30 && code <= 37:
+			s.foreground = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			s.foreground = ansiPalette[code-90+8]
+		case code == 39:
+			s.foreground = ""
+		case code >= 40 && code <= 47:
+			s.background = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			s.background = ansiPalette[code-100+8]
+		case code == 49:
+			s.background = ""
+		case code == 38 || code == 48:
+			var color string
+			if len(sub) > 1 {
+				color, _ = extendedColor(sub[1:])
+			} else {
+				var used int
+				color, used = extendedColor(codes[i+1:])
+				i += used
+			}
+			if color == "" {
+				continue
+			}
+			if code == 38 {
+				s.foreground = color
+			} else {
+				s.background = color
+			}
+		}
+	}
+	return s
+}
+
+// extendedColor parses the 5;n or 2;r;g;b following a 38 or 48, returning the colour
+// and the number of parameters it used
+func extendedColor(params []string) (string, int) {
+	if len(params) == 0 {
+		return "", 0
+	}
+	values := make([]int, 0, 4)
+	for _, param := range params {
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			break
+		}
+		values = append(values, value)
+	}
+
+	switch {
+	case len(values) >= 2 && values[0] == 5:
+		return ansi256Color(values[1]), 2
+	case len(values) >= 4 && values[0] == 2:
+		return fmt.Sprintf("#%02x%02x%02x", clampByte(values[1]), clampByte(values[2]), clampByte(values[3])), 4
+	}
+	return "", len(params)
+}
+
+// ansi256Color returns the colour of an entry in the 256 colour palette
+func ansi256Color(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		// A 6x6x6 cube of the levels 0, 95, 135, 175, 215 and 255
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+func clampByte(v int) int {
+	return min(max(v, 0), 255)
+}
@@ -0,0 +1,101 @@
+package api
+
+import "testing"
+
+func TestParseANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		plain    string
+		html     string
+		markdown string
+	}{
+		{
+			name:     "plain",
+			output:   "ok  \tpkg\t0.01s\n",
+			plain:    "ok  \tpkg\t0.01s\n",
+			html:     "ok  \tpkg\t0.01s\n",
+			markdown: "ok  \tpkg\t0.01s\n",
+		},
+		{
+			name:     "16 colours and reset",
+			output:   "\x1b[32mPASS\x1b[0m TestA\n\x1b[1;31mFAIL\x1b[m TestB",
+			plain:    "PASS TestA\nFAIL TestB",
+			html:     `<span style="color: #16a34a">PASS</span> TestA` + "\n" + `<span style="color: #dc2626"><strong>FAIL</strong></span> TestB`,
+			markdown: `<span style="color: #16a34a">PASS</span> TestA` + "\n" + `<span style="color: #dc2626">**FAIL**</span> TestB`,
+		},
+		{
+			name:     "256 colours and truecolor",
+			output:   "\x1b[38;5;208mwarn\x1b[39m \x1b[48;2;255;0;0mhot\x1b[49m \x1b[38:2::0:128:255mcool\x1b[0m",
+			plain:    "warn hot cool",
+			html:     `<span style="color: #ff8700">warn</span> <span style="background-color: #ff0000">hot</span> <span style="color: #0080ff">cool</span>`,
+			markdown: `<span style="color: #ff8700">warn</span> <span style="background-color: #ff0000">hot</span> <span style="color: #0080ff">cool</span>`,
+		},
+		{
+			name:     "underline and italic off",
+			output:   "\x1b[4;3mone\x1b[23m two\x1b[24m three",
+			plain:    "one two three",
+			html:     "<em><u>one</u></em><u> two</u> three",
+			markdown: "*one* two three",
+		},
+		{
+			name:     "cursor movement and OSC are removed",
+			output:   "\x1b]0;title\a\x1b[2K\x1b[1Gdone\x1b[?25h\r\n\x1b(Bnext",
+			plain:    "done\nnext",
+			html:     "done\nnext",
+			markdown: "done\nnext",
+		},
+		{
+			name:     "hyperlinks",
+			output:   "see \x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\ now",
+			plain:    "see docs now",
+			html:     `see <a href="https://example.com" class="text-blue-600 hover:underline">docs</a> now`,
+			markdown: "see [docs](https://example.com) now",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text := ParseANSI(test.output)
+			if got := text.String(); got != test.plain {
+				t.Errorf("expected plain %q, got %q", test.plain, got)
+			}
+			if got := text.HTML(); got != test.html {
+				t.Errorf("expected html %q, got %q", test.html, got)
+			}
+			if got := text.Markdown(); got != test.markdown {
+				t.Errorf("expected markdown %q, got %q", test.markdown, got)
+			}
+		})
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	for output, expected := range map[string]string{
+		"\x1b[1;32mok\x1b[0m":                   "ok",
+		"\x1b]8;;https://a\x07link\x1b]8;;\x07": "link",
+		"unterminated \x1b[31":                  "unterminated ",
+		"bell\a and backspace\b":                "bell and backspace",
+		"wide 日本\x1b[0m":                        "wide 日本",
+	} {
+		if got := StripANSI(output); got != expected {
+			t.Errorf("StripANSI(%q): expected %q, got %q", output, expected, got)
+		}
+	}
+}
+
+func TestANSI256Color(t *testing.T) {
+	for n, expected := range map[int]string{
+		1:   "#dc2626",
+		16:  "#000000",
+		21:  "#0000ff",
+		196: "#ff0000",
+		232: "#080808",
+		255: "#eeeeee",
+		256: "",
+	} {
+		if got := ansi256Color(n); got != expected {
+			t.Errorf("ansi256Color(%d): expected %q, got %q", n, expected, got)
+		}
+	}
+}
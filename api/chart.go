@@ -0,0 +1,58 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/flanksource/clicky/api/tailwind"
+)
+
+// Chart types of a table chart
+const (
+	ChartBar  = "bar"
+	ChartLine = "line"
+	ChartPie  = "pie"
+)
+
+// TableChart is a chart drawn next to a table, plotting its rows
+type TableChart struct {
+	// Type is bar, line or pie, defaulting to bar
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// X is the column labelling each row, or slice for pie charts
+	X string `json:"x" yaml:"x"`
+	// Y are the numeric columns plotted as series, pie charts use the first one
+	Y     []string `json:"y" yaml:"y"`
+	Title string   `json:"title,omitempty" yaml:"title,omitempty"`
+	// Colors are Tailwind colors of the series, e.g. blue-500, overriding the theme
+	Colors []string `json:"colors,omitempty" yaml:"colors,omitempty"`
+}
+
+// chartPalette follows the theme colors when a chart has more series than the theme has colors
+var chartPalette = []string{
+	"blue-500", "emerald-500", "amber-500", "rose-500", "violet-500",
+	"cyan-500", "orange-500", "lime-500", "pink-500", "slate-500",
+}
+
+// ChartColors returns n hex colors for the series of a chart: its own Tailwind colors
+// first, then the theme's colors and the Tailwind palette
+func (t Theme) ChartColors(chart TableChart, n int) []string {
+	var colors []string
+	for _, class := range chart.Colors {
+		if hex := tailwind.Color(class); strings.HasPrefix(hex, "#") {
+			colors = append(colors, hex)
+		}
+	}
+	for _, color := range []lipgloss.Color{t.Primary, t.Secondary, t.Success, t.Warning, t.Info, t.Error} {
+		if strings.HasPrefix(string(color), "#") {
+			colors = append(colors, string(color))
+		}
+	}
+	for _, class := range chartPalette {
+		colors = append(colors, tailwind.Color(class))
+	}
+	for len(colors) < n {
+		colors = append(colors, colors...)
+	}
+	return colors[:n]
+}
@@ -23,12 +23,41 @@ const (
 	FormatDate     = "date"
 	FormatFloat    = "float"
 	FormatMarkdown = "markdown"
+	FormatAsciiDoc = "asciidoc"
+	FormatRST      = "rst"
 	FormatJSON     = "json"
 	FormatYAML     = "yaml"
 	FormatCSV      = "csv"
 	FormatHTML     = "html"
 	FormatPDF      = "pdf"
 	FormatPretty   = "pretty"
+	FormatBytes    = "bytes"
+	FormatIBytes   = "ibytes"
+	FormatPercent  = "percent"
+	FormatSI       = "si"
+	FormatRelative = "relative"
+	FormatDuration = "duration"
+	// FormatMarkup parses string values as inline markup, see ParseMarkup
+	FormatMarkup = "markup"
+
+	// Inline charts, rendered by the registered render function of the same name
+	FormatSparkline = "sparkline"
+	FormatBar       = "bar"
+	FormatGauge     = "gauge"
+)
+
+// Table style constants, the borders of terminal tables
+const (
+	TableStyleSingle   = "single"
+	TableStyleRounded  = "rounded"
+	TableStyleDouble   = "double"
+	TableStyleHeavy    = "heavy"
+	TableStyleASCII    = "ascii"
+	TableStyleMarkdown = "markdown"
+	// TableStyleMinimal has no borders, for output that is grepped
+	TableStyleMinimal = "minimal"
+	// TableStyleCompact has no borders and uppercase headers, like kubectl
+	TableStyleCompact = "compact"
 )
 
 // Common strings
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Synthetic rows added to a table by GroupRows are marked with a kind under RowKindKey
+const (
+	RowKindKey      = "__row_kind"
+	RowKindGroup    = "group"
+	RowKindSubtotal = "subtotal"
+	RowKindTotal    = "total"
+)
+
+// Aggregate functions supported by the aggregate field option
+const (
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+	AggregateCount = "count"
+)
+
+// Kind returns the kind of a group header or aggregate row, or "" for data rows
+func (r PrettyDataRow) Kind() string {
+	if kind, ok := r[RowKindKey].Value.(string); ok {
+		return kind
+	}
+	return ""
+}
+
+// DataRows returns the rows that are not group headers or aggregate rows
+func DataRows(rows []PrettyDataRow) []PrettyDataRow {
+	data := make([]PrettyDataRow, 0, len(rows))
+	for _, row := range rows {
+		if row.Kind() == "" {
+			data = append(data, row)
+		}
+	}
+	return data
+}
+
+// GroupRows groups rows by the table's group_by column, adding a group header
+// row before each group and, for columns with an aggregate, a subtotal row after
+// each group and a total row at the end. Rows from a previous call are replaced,
+// so the rows can be regrouped after filtering.
+func GroupRows(field PrettyField, rows []PrettyDataRow) []PrettyDataRow {
+	rows = DataRows(rows)
+	if len(rows) == 0 {
+		return rows
+	}
+
+	columns := TableColumns(field, rows)
+	groupBy := field.TableOptions.GroupBy
+	var aggregates []PrettyField
+	label := ""
+	for _, column := range columns {
+		if groupBy == "" && column.FormatOptions["group_by"] == "true" {
+			groupBy = column.Name
+		}
+		if column.Aggregate != "" {
+			aggregates = append(aggregates, column)
+		} else if label == "" {
+			label = column.Name
+		}
+	}
+	if groupBy == "" && len(aggregates) == 0 {
+		return rows
+	}
+
+	var result []PrettyDataRow
+	if groupBy == "" {
+		result = append(result, rows...)
+	} else {
+		var keys []string
+		groups := make(map[string][]PrettyDataRow)
+		headers := make(map[string]FieldValue)
+		for _, row := range rows {
+			value := row[groupBy]
+			key := fmt.Sprintf("%v", value.Value)
+			if _, exists := groups[key]; !exists {
+				keys = append(keys, key)
+				headers[key] = value
+			}
+			groups[key] = append(groups[key], row)
+		}
+
+		for _, key := range keys {
+			result = append(result, PrettyDataRow{
+				RowKindKey: {Value: RowKindGroup},
+				groupBy:    headers[key],
+			})
+			result = append(result, groups[key]...)
+			if len(aggregates) > 0 {
+				result = append(result, aggregateRow(RowKindSubtotal, "Subtotal", label, aggregates, groups[key]))
+			}
+		}
+	}
+
+	if len(aggregates) > 0 {
+		result = append(result, aggregateRow(RowKindTotal, "Total", label, aggregates, rows))
+	}
+	return result
+}
+
+// aggregateRow creates a subtotal or total row, with the title in the label column
+func aggregateRow(kind, title, label string, columns []PrettyField, rows []PrettyDataRow) PrettyDataRow {
+	row := PrettyDataRow{RowKindKey: {Value: kind}}
+	if label != "" {
+		if value, err := (PrettyField{Name: label, Type: FieldTypeString}).Parse(title); err == nil {
+			row[label] = value
+		}
+	}
+	for _, column := range columns {
+		if value, ok := Aggregate(column, rows); ok {
+			row[column.Name] = value
+		}
+	}
+	return row
+}
+
+// Aggregate computes the column's aggregate over the rows. The result is parsed
+// with the column's field, so sums of a currency column are formatted as currency.
+func Aggregate(column PrettyField, rows []PrettyDataRow) (FieldValue, bool) {
+	var values []interface{}
+	for _, row := range rows {
+		if value, ok := row[column.Name]; ok && value.Value != nil {
+			values = append(values, value.Value)
+		}
+	}
+
+	field := column
+	if column.Aggregate == AggregateCount {
+		field.Type = FieldTypeInt
+		field.Format = ""
+		value, err := field.Parse(int64(len(values)))
+		return value, err == nil
+	}
+
+	if column.Aggregate == AggregateMin || column.Aggregate == AggregateMax {
+		if result, ok := aggregateTime(column.Aggregate, values); ok {
+			value, err := field.Parse(result)
+			return value, err == nil
+		}
+	}
+
+	var numbers []float64
+	integers := true
+	for _, value := range values {
+		number, integer, ok := numericValue(value)
+		if !ok {
+			continue
+		}
+		numbers = append(numbers, number)
+		integers = integers && integer
+	}
+	if len(numbers) == 0 {
+		return FieldValue{}, false
+	}
+
+	var result float64
+	switch column.Aggregate {
+	case AggregateSum, AggregateAvg:
+		for _, number := range numbers {
+			result += number
+		}
+		if column.Aggregate == AggregateAvg {
+			result /= float64(len(numbers))
+			integers = false
+		}
+	case AggregateMin, AggregateMax:
+		result = numbers[0]
+		for _, number := range numbers[1:] {
+			if (column.Aggregate == AggregateMin) == (number < result) {
+				result = number
+			}
+		}
+	default:
+		return FieldValue{}, false
+	}
+
+	var value interface{} = result
+	if integers {
+		value = int64(result)
+	} else if field.Type == FieldTypeInt {
+		field.Type = FieldTypeFloat
+	}
+	parsed, err := field.Parse(value)
+	return parsed, err == nil
+}
+
+// aggregateTime returns the earliest or latest time when all values are times
+func aggregateTime(fn string, values []interface{}) (time.Time, bool) {
+	var result time.Time
+	for i, value := range values {
+		t, ok := value.(time.Time)
+		if !ok {
+			return time.Time{}, false
+		}
+		if i == 0 || (fn == AggregateMin && t.Before(result)) || (fn == AggregateMax && t.After(result)) {
+			result = t
+		}
+	}
+	return result, len(values) > 0
+}
+
+// numericValue converts numeric kinds (including numeric strings) to a float64,
+// reporting whether the value was an integer
+func numericValue(value interface{}) (float64, bool, bool) {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), true, true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), false, true
+	case reflect.String:
+		if f := (FieldValue{Value: val.String()}).Float(); f != nil {
+			return *f, false, true
+		}
+	}
+	return 0, false, false
+}
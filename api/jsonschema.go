@@ -0,0 +1,387 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of generated schemas
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Kinds of mismatches reported by JSONSchema.Validate
+const (
+	IssueType    = "type"
+	IssueFormat  = "format"
+	IssueMissing = "missing"
+	IssueUnknown = "unknown"
+)
+
+// JSONSchema is the subset of JSON Schema needed to describe the data shape of a PrettyObject
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+}
+
+// ValidationIssue is a mismatch between data and a schema
+type ValidationIssue struct {
+	Path     string `json:"path"`
+	Issue    string `json:"issue"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema for the data described by the object. Fields
+// are required unless they are optional or have a default, and other fields are
+// reported as unknown.
+func (o *PrettyObject) JSONSchema() *JSONSchema {
+	schema := objectJSONSchema(o.Fields)
+	schema.Schema = JSONSchemaDraft
+	return schema
+}
+
+// JSONSchema returns a JSON Schema for the values of the field
+func (f PrettyField) JSONSchema() *JSONSchema {
+	schema := &JSONSchema{}
+	if f.Label != "" && f.Label != f.Name {
+		schema.Title = f.Label
+	}
+
+	switch f.Format {
+	case FormatTable:
+		columns := f.TableOptions.Fields
+		if len(columns) == 0 {
+			columns = f.Fields
+		}
+		schema.Type = "array"
+		schema.Items = objectJSONSchema(columns)
+		return schema
+	case FormatList:
+		schema.Type = "array"
+		return schema
+	case FormatCurrency, FormatFloat, FormatBytes, FormatIBytes, FormatPercent, FormatSI:
+		schema.Type = "number"
+		return schema
+	case FormatDate:
+		schema.Type = "string"
+		schema.Format = "date-time"
+		return schema
+	}
+
+	switch f.Type {
+	case FieldTypeString:
+		schema.Type = "string"
+	case FieldTypeInt:
+		schema.Type = "integer"
+	case FieldTypeFloat:
+		schema.Type = "number"
+	case FieldTypeBoolean:
+		schema.Type = "boolean"
+	case FieldTypeDate:
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case FieldTypeDuration:
+		// Durations are strings such as 1h30m, or nanoseconds when marshalled from Go
+		schema.Type = []string{"string", "integer"}
+	case FieldTypeArray:
+		schema.Type = "array"
+		if len(f.Fields) > 0 {
+			schema.Items = objectJSONSchema(f.Fields)
+		}
+	case FieldTypeStruct, FieldTypeMap:
+		if len(f.Fields) > 0 {
+			*schema = *objectJSONSchema(f.Fields)
+			if f.Label != "" && f.Label != f.Name {
+				schema.Title = f.Label
+			}
+		} else {
+			schema.Type = "object"
+		}
+	}
+	return schema
+}
+
+// objectJSONSchema returns a closed object schema with a property per field
+func objectJSONSchema(fields []PrettyField) *JSONSchema {
+	closed := false
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema, len(fields)),
+		AdditionalProperties: &closed,
+	}
+	if len(fields) == 0 {
+		schema.AdditionalProperties = nil
+		return schema
+	}
+	for _, field := range fields {
+		property := field.JSONSchema()
+		if field.Optional {
+			// Optional fields may also be null
+			if t, ok := property.Type.(string); ok {
+				property.Type = []string{t, "null"}
+			}
+		}
+		schema.Properties[field.Name] = property
+		if !field.Optional && field.Default == "" {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema
+}
+
+// StructJSONSchema returns a JSON Schema for a struct, using the pretty tags parsed
+// by ParseStructSchema and the Go types for fields without a type. Properties use
+// the json field names, and pointer or omitempty fields are optional.
+func (p *StructParser) StructJSONSchema(data interface{}) (*JSONSchema, error) {
+	typ := reflect.TypeOf(data)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %T", data)
+	}
+
+	fields, err := p.structSchemaFields(typ, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return (&PrettyObject{Fields: fields}).JSONSchema(), nil
+}
+
+// structSchemaFields pairs the fields from ParseStructSchema with the struct fields they came from.
+// A struct nested in itself, such as the children of a tree node, is an object without properties.
+func (p *StructParser) structSchemaFields(typ reflect.Type, parents map[reflect.Type]bool) ([]PrettyField, error) {
+	if parents[typ] {
+		return nil, nil
+	}
+	parents[typ] = true
+	defer delete(parents, typ)
+
+	obj, err := p.ParseStructSchema(reflect.New(typ).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []PrettyField
+	index := 0
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		prettyTag := structField.Tag.Get("pretty")
+		if prettyTag == "-" || prettyTag == FormatHide || prettyTag == "hide" {
+			continue
+		}
+		field := obj.Fields[index]
+		index++
+
+		jsonTag := strings.Split(structField.Tag.Get("json"), ",")
+		if jsonTag[0] == "-" {
+			continue
+		}
+		if jsonTag[0] != "" {
+			field.Name = jsonTag[0]
+		}
+		if field.Label == structField.Name {
+			field.Label = ""
+		}
+		for _, option := range jsonTag[1:] {
+			if option == "omitempty" || option == "omitzero" {
+				field.Optional = true
+			}
+		}
+
+		fieldType := structField.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+			field.Optional = true
+		}
+		if field.Type == "" {
+			field.Type = goFieldType(fieldType)
+		}
+		if kind := fieldType.Kind(); kind == reflect.Slice || kind == reflect.Map {
+			// nil slices and maps are marshalled as null
+			field.Optional = true
+		}
+
+		switch {
+		case field.Type == FieldTypeStruct:
+			if field.Fields, err = p.structSchemaFields(fieldType, parents); err != nil {
+				return nil, err
+			}
+		case fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array:
+			elem := fieldType.Elem()
+			for elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if goFieldType(elem) == FieldTypeStruct {
+				columns, err := p.structSchemaFields(elem, parents)
+				if err != nil {
+					return nil, err
+				}
+				field.Fields = columns
+				field.TableOptions.Fields = nil
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// goFieldType returns the field type for a Go type
+func goFieldType(typ reflect.Type) string {
+	switch {
+	case typ == reflect.TypeOf(time.Time{}):
+		return FieldTypeDate
+	case typ == reflect.TypeOf(time.Duration(0)):
+		return FieldTypeDuration
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return FieldTypeString
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FieldTypeInt
+	case reflect.Float32, reflect.Float64:
+		return FieldTypeFloat
+	case reflect.Bool:
+		return FieldTypeBoolean
+	case reflect.Slice, reflect.Array:
+		return FieldTypeArray
+	case reflect.Map:
+		return FieldTypeMap
+	case reflect.Struct:
+		return FieldTypeStruct
+	}
+	return ""
+}
+
+// Validate checks data against the schema, returning an issue for each value with the
+// wrong type or format, each missing required field and each unknown field
+func (s *JSONSchema) Validate(data interface{}) []ValidationIssue {
+	// Work on the values produced by encoding/json, whatever the data was decoded from
+	var generic interface{}
+	if b, err := json.Marshal(data); err == nil && json.Unmarshal(b, &generic) == nil {
+		data = generic
+	}
+	return s.validate("", data, nil)
+}
+
+func (s *JSONSchema) validate(path string, value interface{}, issues []ValidationIssue) []ValidationIssue {
+	actual := jsonValueType(value)
+	if types := s.types(); len(types) > 0 && !matchesJSONType(actual, types) {
+		return append(issues, ValidationIssue{
+			Path:     displayPath(path),
+			Issue:    IssueType,
+			Expected: strings.Join(types, " or "),
+			Actual:   actual,
+		})
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.Format == "date-time" && (FieldValue{Value: v}).Time() == nil {
+			issues = append(issues, ValidationIssue{Path: displayPath(path), Issue: IssueFormat, Expected: s.Format, Actual: v})
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				issues = s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, issues)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, exists := v[name]; !exists {
+				issues = append(issues, ValidationIssue{Path: joinPath(path, name), Issue: IssueMissing, Expected: s.Properties[name].typeName()})
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				issues = property.validate(joinPath(path, name), v[name], issues)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				issues = append(issues, ValidationIssue{Path: joinPath(path, name), Issue: IssueUnknown, Actual: jsonValueType(v[name])})
+			}
+		}
+	}
+	return issues
+}
+
+// types returns the allowed JSON types, or nil when any type is allowed
+func (s *JSONSchema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+func (s *JSONSchema) typeName() string {
+	if s == nil {
+		return ""
+	}
+	return strings.Join(s.types(), " or ")
+}
+
+// jsonValueType returns the JSON type of a value decoded by encoding/json
+func jsonValueType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func matchesJSONType(actual string, types []string) bool {
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return path
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaLine struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price" pretty:"format=currency"`
+}
+
+type schemaOrder struct {
+	ID       string        `json:"id"`
+	Created  time.Time     `json:"created"`
+	Note     *string       `json:"note"`
+	Tags     []string      `json:"tags"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+	Lines    []schemaLine  `json:"lines" pretty:"table"`
+	internal string
+}
+
+func TestStructJSONSchema(t *testing.T) {
+	schema, err := NewStructParser().StructJSONSchema(schemaOrder{})
+	if err != nil {
+		t.Fatalf("failed to generate schema: %v", err)
+	}
+
+	if schema.Schema != JSONSchemaDraft || schema.Type != "object" {
+		t.Errorf("expected a draft 2020-12 object schema, got %s %v", schema.Schema, schema.Type)
+	}
+	if expected := []string{"id", "created"}; !reflect.DeepEqual(schema.Required, expected) {
+		t.Errorf("expected required %v, got %v", expected, schema.Required)
+	}
+	if created := schema.Properties["created"]; created.Type != "string" || created.Format != "date-time" {
+		t.Errorf("expected created to be a date-time string, got %+v", created)
+	}
+	if note := schema.Properties["note"]; !reflect.DeepEqual(note.Type, []string{"string", "null"}) {
+		t.Errorf("expected a nullable note, got %v", note.Type)
+	}
+	lines := schema.Properties["lines"]
+	if !reflect.DeepEqual(lines.Type, []string{"array", "null"}) || lines.Items == nil || lines.Items.Properties["price"].Type != "number" {
+		t.Errorf("expected lines to be an array of rows with a numeric price, got %+v", lines)
+	}
+	if _, ok := schema.Properties["internal"]; ok {
+		t.Errorf("expected unexported fields to be skipped")
+	}
+}
+
+type schemaNode struct {
+	Name     string       `json:"name"`
+	Parent   *schemaNode  `json:"parent,omitempty"`
+	Children []schemaNode `json:"children" pretty:"table"`
+}
+
+func TestStructJSONSchemaRecursive(t *testing.T) {
+	schema, err := NewStructParser().StructJSONSchema(schemaNode{})
+	if err != nil {
+		t.Fatalf("failed to generate schema: %v", err)
+	}
+
+	if parent := schema.Properties["parent"]; !reflect.DeepEqual(parent.Type, []string{"object", "null"}) || parent.Properties != nil {
+		t.Errorf("expected the parent to be an object without properties, got %+v", parent)
+	}
+	children := schema.Properties["children"]
+	if children.Items == nil || children.Items.Type != "object" || len(children.Items.Properties) != 0 {
+		t.Errorf("expected the children to be objects without properties, got %+v", children)
+	}
+}
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema := (&PrettyObject{Fields: []PrettyField{
+		{Name: "id", Type: FieldTypeString},
+		{Name: "total", Format: FormatCurrency},
+		{Name: "created", Type: FieldTypeDate},
+		{Name: "note", Type: FieldTypeString, Optional: true},
+		{Name: "customer", Type: FieldTypeStruct, Fields: []PrettyField{{Name: "email", Type: FieldTypeString}}},
+		{Name: "items", Format: FormatTable, TableOptions: PrettyTable{Fields: []PrettyField{
+			{Name: "qty", Type: FieldTypeInt},
+		}}},
+	}}).JSONSchema()
+
+	valid := map[string]interface{}{
+		"id":       "ORD-1",
+		"total":    12,
+		"created":  "2024-03-01T10:00:00Z",
+		"customer": map[string]interface{}{"email": "jane@example.com"},
+		"items":    []interface{}{map[string]interface{}{"qty": 2}},
+	}
+	if issues := schema.Validate(valid); len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+
+	invalid := map[string]interface{}{
+		"id":       42,
+		"created":  "yesterday",
+		"customer": map[string]interface{}{"email": "jane@example.com", "phone": "555"},
+		"items":    []interface{}{map[string]interface{}{"qty": 1.5}},
+		"extra":    true,
+	}
+	expected := []ValidationIssue{
+		{Path: "total", Issue: IssueMissing, Expected: "number"},
+		{Path: "created", Issue: IssueFormat, Expected: "date-time", Actual: "yesterday"},
+		{Path: "customer.phone", Issue: IssueUnknown, Actual: "string"},
+		{Path: "extra", Issue: IssueUnknown, Actual: "boolean"},
+		{Path: "id", Issue: IssueType, Expected: "string", Actual: "integer"},
+		{Path: "items[0].qty", Issue: IssueType, Expected: "integer", Actual: "number"},
+	}
+	if issues := schema.Validate(invalid); !reflect.DeepEqual(issues, expected) {
+		t.Errorf("expected %+v\ngot %+v", expected, issues)
+	}
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LayoutKind is the construct a Text with a Layout renders as
+type LayoutKind string
+
+const (
+	// LayoutPanel is a bordered box around the children, with an optional title
+	LayoutPanel LayoutKind = "panel"
+	// LayoutColumns lays the children out side by side
+	LayoutColumns LayoutKind = "columns"
+	// LayoutRule is a horizontal rule with an optional label
+	LayoutRule LayoutKind = "rule"
+	// LayoutBadge is an inline pill
+	LayoutBadge LayoutKind = "badge"
+)
+
+// Layout renders a Text as a block-level construct rather than inline content. The Style
+// of the text styles the title and, in terminals, the border of panels, the line of rules
+// and the pill of badges.
+type Layout struct {
+	Kind LayoutKind
+	// Title is the title of a panel, or the label of a rule
+	Title string
+	// Ratios are the relative widths of columns, e.g. 2, 1 gives the first column two
+	// thirds of the width. Columns without a ratio have a ratio of 1.
+	Ratios []int
+	// Width is the width of the block in terminal cells, defaulting to the available width
+	Width int
+}
+
+// badgeStyle is the style of badges without one
+const badgeStyle = "bg-gray-100 text-gray-800"
+
+// columnGap is the number of cells between terminal columns
+const columnGap = 2
+
+// Panel returns a bordered panel around the children, titled unless title is empty
+func Panel(title string, children ...Text) Text {
+	return Text{Children: children, Layout: &Layout{Kind: LayoutPanel, Title: title}}
+}
+
+// Columns lays out each of the columns side by side, with widths relative to ratios,
+// or of equal width when ratios is empty
+func Columns(ratios []int, columns ...Text) Text {
+	return Text{Children: columns, Layout: &Layout{Kind: LayoutColumns, Ratios: ratios}}
+}
+
+// Rule returns a horizontal rule, labelled unless label is empty
+func Rule(label string) Text {
+	return Text{Layout: &Layout{Kind: LayoutRule, Title: label}}
+}
+
+// Badge returns an inline pill styled with Tailwind classes, e.g. "bg-green-100 text-green-800"
+func Badge(content string, styles ...string) Text {
+	return Text{Content: content, Style: strings.Join(styles, " "), Layout: &Layout{Kind: LayoutBadge}}
+}
+
+// isBlock reports whether the text renders on lines of its own
+func (t Text) isBlock() bool {
+	return t.Layout != nil && t.Layout.Kind != LayoutBadge
+}
+
+// joinChildren appends the rendered children to content, separating block-level children
+// from the content around them with sep
+func joinChildren(content string, children []Text, render func(Text) string, sep string) string {
+	afterBlock := false
+	for _, child := range children {
+		block := child.isBlock()
+		if (block || afterBlock) && content != "" {
+			content = strings.TrimRight(content, "\n") + sep
+		}
+		content += render(child)
+		afterBlock = block
+	}
+	return content
+}
+
+// layoutText renders a text with a layout for terminals, as ANSI or plain text, within
+// width cells, or the terminal width when width is 0
+func (t Text) layoutText(width int, ansi bool) string {
+	render := Text.plain
+	if ansi {
+		render = Text.ansiWidth
+	}
+	if t.Layout.Width > 0 && (width <= 0 || t.Layout.Width < width) {
+		width = t.Layout.Width
+	}
+	if width <= 0 {
+		width = GetTerminalWidth()
+	}
+
+	// paint styles borders, lines and titles with the style of the text
+	paint := func(content string, styles ...string) string {
+		style := strings.TrimSpace(t.Style + " " + strings.Join(styles, " "))
+		return render(Text{Content: content, Class: t.Class, Style: style}, 0)
+	}
+	body := Text{Content: t.Content, Children: t.Children}
+
+	switch t.Layout.Kind {
+	case LayoutBadge:
+		if !ansi {
+			return "[" + body.String() + "]"
+		}
+		style := t.Style
+		if style == "" && t.Class == (Class{}) {
+			style = badgeStyle
+		}
+		return Hyperlink(render(Text{Content: " " + body.String() + " ", Class: t.Class, Style: style}, 0), t.Href)
+
+	case LayoutRule:
+		if t.Layout.Title == "" {
+			return paint(strings.Repeat("─", width))
+		}
+		label := " " + truncateWidth(t.Layout.Title, width-4) + " "
+		return paint("──") + paint(label, "font-bold") + paint(strings.Repeat("─", max(width-2-lipgloss.Width(label), 0)))
+
+	case LayoutPanel:
+		inner := max(width-4, 1)
+		top := paint("╭" + strings.Repeat("─", inner+2) + "╮")
+		if t.Layout.Title != "" {
+			label := " " + truncateWidth(t.Layout.Title, inner-1) + " "
+			top = paint("╭─") + paint(label, "font-bold") + paint(strings.Repeat("─", max(inner+1-lipgloss.Width(label), 0))+"╮")
+		}
+		lines := []string{top}
+		for _, line := range blockLines(render(body, inner), inner) {
+			lines = append(lines, paint("│")+" "+line+" "+paint("│"))
+		}
+		lines = append(lines, paint("╰"+strings.Repeat("─", inner+2)+"╯"))
+		return strings.Join(lines, "\n")
+
+	case LayoutColumns:
+		if len(t.Children) == 0 {
+			return ""
+		}
+		widths := columnWidths(width, t.Layout.Ratios, len(t.Children))
+		columns := make([][]string, len(t.Children))
+		height := 0
+		for i, child := range t.Children {
+			columns[i] = blockLines(render(child, widths[i]), widths[i])
+			height = max(height, len(columns[i]))
+		}
+
+		lines := make([]string, height)
+		for row := range lines {
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				if row < len(column) {
+					cells[i] = column[row]
+				} else {
+					cells[i] = strings.Repeat(" ", widths[i])
+				}
+			}
+			lines[row] = strings.TrimRight(strings.Join(cells, strings.Repeat(" ", columnGap)), " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+	return render(body, width)
+}
+
+// columnWidths divides width between count columns relative to ratios, less the gaps
+// between them
+func columnWidths(width int, ratios []int, count int) []int {
+	available := max(width-columnGap*(count-1), count)
+	total := 0
+	for i := 0; i < count; i++ {
+		total += columnRatio(ratios, i)
+	}
+
+	widths := make([]int, count)
+	remaining := available
+	for i := range widths {
+		widths[i] = max(available*columnRatio(ratios, i)/total, 1)
+		remaining -= widths[i]
+	}
+	// The rounding remainder goes to the last column
+	widths[count-1] = max(widths[count-1]+remaining, 1)
+	return widths
+}
+
+func columnRatio(ratios []int, i int) int {
+	if i < len(ratios) && ratios[i] > 0 {
+		return ratios[i]
+	}
+	return 1
+}
+
+// blockLines wraps rendered text to width, padding every line to exactly width cells
+func blockLines(text string, width int) []string {
+	wrapped := lipgloss.NewStyle().Width(width).Render(strings.TrimRight(text, "\n"))
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		if pad := width - lipgloss.Width(line); pad > 0 {
+			lines[i] = line + strings.Repeat(" ", pad)
+		}
+	}
+	return lines
+}
+
+// truncateWidth truncates plain text to width cells, ending it with an ellipsis
+func truncateWidth(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := lipgloss.Width(string(r))
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
+
+// layoutHTML renders a text with a layout as HTML with Tailwind classes
+func (t Text) layoutHTML() string {
+	body := Text{Content: t.Content, Children: t.Children}.HTML()
+	title := html.EscapeString(t.Layout.Title)
+
+	switch t.Layout.Kind {
+	case LayoutBadge:
+		style := t.Style
+		if style == "" {
+			style = badgeStyle
+		}
+		badge := `<span class="inline-flex items-center rounded-full px-2 text-xs font-medium ` + style + `">` + body + "</span>"
+		if t.Href != "" {
+			return htmlLink(badge, t.Href)
+		}
+		return badge
+
+	case LayoutRule:
+		if title == "" {
+			return `<hr class="my-4 border-t border-gray-200">`
+		}
+		return `<div class="flex items-center gap-2 my-4">` +
+			`<span class="text-sm font-semibold ` + strings.TrimSpace("text-gray-500 "+t.Style) + `">` + title + "</span>" +
+			`<span class="flex-1 border-t border-gray-200"></span></div>`
+
+	case LayoutPanel:
+		var b strings.Builder
+		b.WriteString(`<div class="rounded-lg border border-gray-200 p-4 my-2">`)
+		if title != "" {
+			b.WriteString(`<div class="` + strings.TrimSpace("mb-2 font-semibold "+t.Style) + `">` + title + "</div>")
+		}
+		b.WriteString(body + "</div>")
+		return b.String()
+
+	case LayoutColumns:
+		fractions := make([]string, len(t.Children))
+		for i := range t.Children {
+			fractions[i] = fmt.Sprintf("%dfr", columnRatio(t.Layout.Ratios, i))
+		}
+		var b strings.Builder
+		b.WriteString(`<div class="grid gap-4" style="grid-template-columns: ` + strings.Join(fractions, " ") + `">`)
+		for _, child := range t.Children {
+			b.WriteString("<div>" + child.HTML() + "</div>")
+		}
+		b.WriteString("</div>")
+		return b.String()
+	}
+	return body
+}
+
+// layoutMarkdown renders a text with a layout as Markdown, which has no panels or
+// columns: panels become block quotes and columns follow each other
+func (t Text) layoutMarkdown() string {
+	body := Text{Content: t.Content, Children: t.Children}.Markdown()
+
+	switch t.Layout.Kind {
+	case LayoutBadge:
+		badge := "`" + body + "`"
+		if t.Href != "" {
+			return markdownLink(badge, t.Href)
+		}
+		return badge
+
+	case LayoutRule:
+		if t.Layout.Title == "" {
+			return "---"
+		}
+		return "---\n\n**" + t.Layout.Title + "**"
+
+	case LayoutPanel:
+		var lines []string
+		if t.Layout.Title != "" {
+			lines = append(lines, "> **"+t.Layout.Title+"**", ">")
+		}
+		for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+			lines = append(lines, strings.TrimRight("> "+line, " "))
+		}
+		return strings.Join(lines, "\n")
+
+	case LayoutColumns:
+		columns := make([]string, 0, len(t.Children))
+		for _, child := range t.Children {
+			columns = append(columns, child.Markdown())
+		}
+		return strings.Join(columns, "\n\n")
+	}
+	return body
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayoutText(t *testing.T) {
+	fixtures := []struct {
+		name     string
+		input    Text
+		expected string
+	}{
+		{
+			name:     "rule",
+			input:    Text{Layout: &Layout{Kind: LayoutRule, Width: 10}},
+			expected: "──────────",
+		},
+		{
+			name:     "labelled rule",
+			input:    Text{Layout: &Layout{Kind: LayoutRule, Title: "Pods", Width: 12}},
+			expected: "── Pods ────",
+		},
+		{
+			name:     "badge",
+			input:    Text{Content: "Ready: ", Children: []Text{Badge("PASS", "bg-green-100")}},
+			expected: "Ready: [PASS]",
+		},
+		{
+			name: "panel",
+			input: Text{Content: "before", Children: []Text{
+				{Children: []Text{{Content: "a long line that wraps"}}, Layout: &Layout{Kind: LayoutPanel, Title: "web", Width: 16}},
+				{Content: "after"},
+			}},
+			expected: "before\n" +
+				"╭─ web ────────╮\n" +
+				"│ a long line  │\n" +
+				"│ that wraps   │\n" +
+				"╰──────────────╯\n" +
+				"after",
+		},
+		{
+			name: "columns",
+			input: Text{Children: []Text{{Content: "left side"}, Panel("", Text{Content: "right"})}, Layout: &Layout{
+				Kind: LayoutColumns, Ratios: []int{1, 2}, Width: 20,
+			}},
+			expected: "left    ╭──────────╮\n" +
+				"side    │ right    │\n" +
+				"        ╰──────────╯",
+		},
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			if got := fixture.input.String(); got != fixture.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", fixture.expected, got)
+			}
+		})
+	}
+}
+
+func TestLayoutANSI(t *testing.T) {
+	panel := Panel("web", Text{Content: "ok"})
+	panel.Layout.Width = 10
+
+	expected := "╭─\x1b[1m web \x1b[0m──╮\n│ ok     │\n╰────────╯"
+	if got := panel.ANSI(); got != expected {
+		t.Errorf("expected a bold title %q, got %q", expected, got)
+	}
+
+	badge := Badge("PASS", "font-bold")
+	if got := badge.ANSI(); got != "\x1b[1m PASS \x1b[0m" {
+		t.Errorf("expected a padded pill, got %q", got)
+	}
+}
+
+func TestLayoutMarkup(t *testing.T) {
+	dashboard := Text{Children: []Text{
+		Rule("Services"),
+		Columns([]int{2, 1},
+			Panel("web", Text{Content: "Status: "}, Badge("healthy", "bg-green-100 text-green-800")),
+			Panel("db", Text{Content: "Replicas: 3"}),
+		),
+	}}
+
+	markdown := dashboard.Markdown()
+	for _, expected := range []string{
+		"---\n\n**Services**\n\n",
+		"> **web**\n>\n> Status: `healthy`\n\n> **db**\n>\n> Replicas: 3",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("expected %q in markdown:\n%s", expected, markdown)
+		}
+	}
+
+	html := dashboard.HTML()
+	for _, expected := range []string{
+		`<span class="text-sm font-semibold text-gray-500">Services</span>`,
+		`<div class="grid gap-4" style="grid-template-columns: 2fr 1fr">`,
+		`<div class="rounded-lg border border-gray-200 p-4 my-2"><div class="mb-2 font-semibold">web</div>`,
+		`<span class="inline-flex items-center rounded-full px-2 text-xs font-medium bg-green-100 text-green-800">healthy</span>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("expected %q in HTML:\n%s", expected, html)
+		}
+	}
+
+	if Rule("").IsEmpty() {
+		t.Errorf("expected a rule not to be empty")
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	widths := columnWidths(80, []int{2, 1}, 2)
+	if widths[0]+widths[1]+columnGap != 80 || widths[0] != 52 {
+		t.Errorf("expected 2:1 columns filling 80 cells, got %v", widths)
+	}
+}
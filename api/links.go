@@ -0,0 +1,72 @@
+package api
+
+import (
+	"html"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HyperlinkEnv forces OSC 8 hyperlinks on (1) or off (0), overriding terminal detection
+const HyperlinkEnv = "FORCE_HYPERLINK"
+
+// SupportsHyperlinks reports whether the terminal renders OSC 8 hyperlinks, from the
+// environment variables set by the terminals known to support them
+func SupportsHyperlinks() bool {
+	if force := os.Getenv(HyperlinkEnv); force != "" {
+		return force != "0" && force != "false"
+	}
+	if os.Getenv("CI") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby", "rio":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" || os.Getenv("KONSOLE_VERSION") != "" {
+		return true
+	}
+	// VTE based terminals, e.g. GNOME Terminal, support hyperlinks since 0.50
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+	switch term := os.Getenv("TERM"); {
+	case strings.Contains(term, "kitty"), strings.Contains(term, "alacritty"), strings.HasPrefix(term, "foot"), term == "xterm-ghostty":
+		return true
+	}
+	return false
+}
+
+// Hyperlink wraps text in an OSC 8 hyperlink to url when the terminal supports them,
+// otherwise the text is returned unchanged so long URLs do not widen tables
+func Hyperlink(text, url string) string {
+	if url == "" || text == "" || !SupportsHyperlinks() {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// markdownLink returns a Markdown inline link, escaping the characters that would end
+// the link text or URL
+func markdownLink(text, url string) string {
+	text = strings.NewReplacer("[", "\\[", "]", "\\]").Replace(text)
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+	return "[" + text + "](" + url + ")"
+}
+
+// htmlLink wraps HTML content in an anchor to url
+func htmlLink(content, url string) string {
+	return `<a href="` + html.EscapeString(url) + `" class="text-blue-600 hover:underline">` + content + "</a>"
+}
+
+// asciidocLink returns an AsciiDoc link macro
+func asciidocLink(text, url string) string {
+	return "link:" + strings.ReplaceAll(url, " ", "%20") + "[" + strings.ReplaceAll(text, "]", "\\]") + "]"
+}
+
+// rstLink returns an anonymous RST hyperlink reference. The link text is plain as RST
+// cannot nest other inline markup in it.
+func rstLink(text, url string) string {
+	text = strings.NewReplacer("`", "\\`", "<", "\\<").Replace(text)
+	return "`" + text + " <" + url + ">`__"
+}
@@ -0,0 +1,65 @@
+package api
+
+import "testing"
+
+func TestTextLinks(t *testing.T) {
+	link := Text{Content: "PROJ-1", Style: "font-bold", Href: "https://jira/browse/PROJ-1"}
+
+	renderers := map[string]struct {
+		render   func() string
+		expected string
+	}{
+		"markdown": {link.Markdown, "[**PROJ-1**](https://jira/browse/PROJ-1)"},
+		"html":     {link.HTML, `<a href="https://jira/browse/PROJ-1" class="text-blue-600 hover:underline"><span class="font-bold"><strong>PROJ-1</strong></span></a>`},
+		"asciidoc": {link.AsciiDoc, "link:https://jira/browse/PROJ-1[**PROJ-1**]"},
+		"rst":      {link.RST, "`PROJ-1 <https://jira/browse/PROJ-1>`__"},
+		"plain":    {link.String, "PROJ-1"},
+	}
+	for name, renderer := range renderers {
+		t.Run(name, func(t *testing.T) {
+			if got := renderer.render(); got != renderer.expected {
+				t.Errorf("expected %q, got %q", renderer.expected, got)
+			}
+		})
+	}
+
+	t.Run("ansi", func(t *testing.T) {
+		plain := Text{Content: "PROJ-1", Href: "https://jira/browse/PROJ-1"}
+
+		t.Setenv(HyperlinkEnv, "1")
+		if got, expected := plain.ANSI(), "\x1b]8;;https://jira/browse/PROJ-1\x1b\\PROJ-1\x1b]8;;\x1b\\"; got != expected {
+			t.Errorf("expected an OSC 8 hyperlink %q, got %q", expected, got)
+		}
+
+		t.Setenv(HyperlinkEnv, "0")
+		if got := plain.ANSI(); got != "PROJ-1" {
+			t.Errorf("expected the text without the URL, got %q", got)
+		}
+	})
+
+	t.Run("field value", func(t *testing.T) {
+		value := FieldValue{Value: "PROJ-1", Link: "https://jira/browse/PROJ-1"}
+		if got := value.Markdown(); got != "[PROJ-1](https://jira/browse/PROJ-1)" {
+			t.Errorf("expected a Markdown link, got %q", got)
+		}
+	})
+}
+
+func TestSupportsHyperlinks(t *testing.T) {
+	for _, name := range []string{HyperlinkEnv, "CI", "TERM", "TERM_PROGRAM", "WT_SESSION", "KITTY_WINDOW_ID", "KONSOLE_VERSION", "VTE_VERSION"} {
+		t.Setenv(name, "")
+	}
+	if SupportsHyperlinks() {
+		t.Errorf("expected no hyperlinks in an unknown terminal")
+	}
+
+	t.Setenv("TERM_PROGRAM", "iTerm.app")
+	if !SupportsHyperlinks() {
+		t.Errorf("expected hyperlinks in iTerm")
+	}
+
+	t.Setenv("CI", "true")
+	if SupportsHyperlinks() {
+		t.Errorf("expected no hyperlinks in CI")
+	}
+}
@@ -0,0 +1,344 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale controls how numbers, currencies and dates are displayed
+type Locale struct {
+	Tag     string
+	Decimal string
+	Group   string
+	// Lakh groups digits as 12,34,567 instead of 1,234,567
+	Lakh bool
+	// Currency is the ISO 4217 code used when a field does not set currency
+	Currency string
+	// SymbolAfter places the currency symbol after the amount, separated by a space
+	SymbolAfter bool
+	// SymbolSpace separates a leading currency symbol from the amount
+	SymbolSpace bool
+	// Months and Weekdays replace the English names in date layouts, January and Sunday first
+	Months   []string
+	Weekdays []string
+	// LongDate is the layout used by date_format: long
+	LongDate string
+}
+
+// Locales are the supported locales by tag
+var Locales = map[string]Locale{
+	"en-US": {Tag: "en-US", Decimal: ".", Group: ",", Currency: "USD", LongDate: "January 2, 2006"},
+	"en-GB": {Tag: "en-GB", Decimal: ".", Group: ",", Currency: "GBP", LongDate: "2 January 2006"},
+	"en-IN": {Tag: "en-IN", Decimal: ".", Group: ",", Lakh: true, Currency: "INR", LongDate: "2 January 2006"},
+	"de-DE": {
+		Tag: "de-DE", Decimal: ",", Group: ".", Currency: "EUR", SymbolAfter: true, LongDate: "2. January 2006",
+		Months:   []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		Weekdays: []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	},
+	"fr-FR": {
+		Tag: "fr-FR", Decimal: ",", Group: " ", Currency: "EUR", SymbolAfter: true, LongDate: "2 January 2006",
+		Months:   []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Weekdays: []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	},
+	"es-ES": {
+		Tag: "es-ES", Decimal: ",", Group: ".", Currency: "EUR", SymbolAfter: true, LongDate: "2 de January de 2006",
+		Months:   []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		Weekdays: []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	},
+	"it-IT": {
+		Tag: "it-IT", Decimal: ",", Group: ".", Currency: "EUR", SymbolAfter: true, LongDate: "2 January 2006",
+		Months:   []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		Weekdays: []string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	},
+	"nl-NL": {
+		Tag: "nl-NL", Decimal: ",", Group: ".", Currency: "EUR", SymbolSpace: true, LongDate: "2 January 2006",
+		Months:   []string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		Weekdays: []string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+	},
+}
+
+// currencies maps ISO 4217 codes to their symbol and number of decimals
+var currencies = map[string]struct {
+	Symbol   string
+	Decimals int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"INR": {"₹", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"CHF": {"CHF", 2},
+	"AUD": {"A$", 2},
+	"CAD": {"CA$", 2},
+	"SEK": {"kr", 2},
+	"NOK": {"kr", 2},
+	"DKK": {"kr", 2},
+	"PLN": {"zł", 2},
+	"BRL": {"R$", 2},
+	"ZAR": {"R", 2},
+}
+
+var (
+	localeMu      sync.RWMutex
+	currentLocale = Locales["en-US"]
+)
+
+// CurrentLocale returns the locale used for fields that do not set locale
+func CurrentLocale() Locale {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return currentLocale
+}
+
+// SetLocale sets the locale used for fields that do not set locale
+func SetLocale(tag string) error {
+	locale, err := LookupLocale(tag)
+	if err != nil {
+		return err
+	}
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	currentLocale = locale
+	return nil
+}
+
+// LookupLocale finds a locale by tag, accepting forms such as de, de_DE and de_DE.UTF-8,
+// and falling back to another locale of the same language
+func LookupLocale(tag string) (Locale, error) {
+	normalized := strings.ReplaceAll(strings.SplitN(strings.TrimSpace(tag), ".", 2)[0], "_", "-")
+	for name, locale := range Locales {
+		if strings.EqualFold(name, normalized) {
+			return locale, nil
+		}
+	}
+
+	language := strings.ToLower(strings.SplitN(normalized, "-", 2)[0])
+	if language == "en" {
+		return Locales["en-US"], nil
+	}
+	for name, locale := range Locales {
+		if strings.HasPrefix(strings.ToLower(name), language+"-") {
+			return locale, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("unknown locale %q", tag)
+}
+
+// Locale returns the locale of the field's locale format option, or the current locale
+func (f PrettyField) Locale() Locale {
+	if tag := f.FormatOptions["locale"]; tag != "" {
+		if locale, err := LookupLocale(tag); err == nil {
+			return locale
+		}
+	}
+	return CurrentLocale()
+}
+
+// WithLocale returns a copy of the data whose fields, and the fields of its values, rows
+// and trees, use the locale unless they set their own locale format option
+func (d *PrettyData) WithLocale(tag string) *PrettyData {
+	if d == nil || tag == "" {
+		return d
+	}
+	result := *d
+	if d.Schema != nil {
+		schema := *d.Schema
+		schema.Fields = localizeFields(d.Schema.Fields, tag)
+		result.Schema = &schema
+	}
+	result.Values = localizeValues(d.Values, tag)
+	if d.Tables != nil {
+		result.Tables = make(map[string][]PrettyDataRow, len(d.Tables))
+		for name, rows := range d.Tables {
+			localized := make([]PrettyDataRow, len(rows))
+			for i, row := range rows {
+				localized[i] = localizeValues(row, tag)
+			}
+			result.Tables[name] = localized
+		}
+	}
+	if d.Trees != nil {
+		result.Trees = make(map[string]PrettyTree, len(d.Trees))
+		for name, tree := range d.Trees {
+			result.Trees[name] = localizeTree(tree, tag)
+		}
+	}
+	return &result
+}
+
+// localizeField returns a copy of the field, and of its nested fields, with the locale
+// format option set where it is not
+func localizeField(field PrettyField, tag string) PrettyField {
+	if field.FormatOptions["locale"] == "" {
+		options := make(map[string]string, len(field.FormatOptions)+1)
+		for key, value := range field.FormatOptions {
+			options[key] = value
+		}
+		options["locale"] = tag
+		field.FormatOptions = options
+	}
+	field.Fields = localizeFields(field.Fields, tag)
+	field.TableOptions.Fields = localizeFields(field.TableOptions.Fields, tag)
+	return field
+}
+
+func localizeFields(fields []PrettyField, tag string) []PrettyField {
+	if fields == nil {
+		return nil
+	}
+	localized := make([]PrettyField, len(fields))
+	for i, field := range fields {
+		localized[i] = localizeField(field, tag)
+	}
+	return localized
+}
+
+func localizeValues(values map[string]FieldValue, tag string) map[string]FieldValue {
+	if values == nil {
+		return nil
+	}
+	localized := make(map[string]FieldValue, len(values))
+	for name, value := range values {
+		localized[name] = localizeValue(value, tag)
+	}
+	return localized
+}
+
+// localizeValue returns the value with its field in the locale, formatting its text again
+// since values are formatted when they are parsed
+func localizeValue(value FieldValue, tag string) FieldValue {
+	changed := value.Field.FormatOptions["locale"] == ""
+	value.Field = localizeField(value.Field, tag)
+	value.NestedFields = localizeValues(value.NestedFields, tag)
+	if changed && value.Value != nil && value.Text != nil {
+		value.Text = value.createText()
+	}
+	return value
+}
+
+func localizeTree(tree PrettyTree, tag string) PrettyTree {
+	tree.Value = localizeValue(tree.Value, tag)
+	if tree.Children != nil {
+		children := make([]PrettyTree, len(tree.Children))
+		for i, child := range tree.Children {
+			children[i] = localizeTree(child, tag)
+		}
+		tree.Children = children
+	}
+	return tree
+}
+
+// FormatNumber formats a number with the locale's separators and the given decimals
+func (l Locale) FormatNumber(n float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	s := strconv.FormatFloat(math.Abs(n), 'f', decimals, 64)
+	integer, fraction, _ := strings.Cut(s, ".")
+
+	result := l.group(integer)
+	if fraction != "" {
+		result += l.Decimal + fraction
+	}
+	if n < 0 && strings.Trim(s, "0.") != "" {
+		result = "-" + result
+	}
+	return result
+}
+
+// group inserts the group separator into a string of digits
+func (l Locale) group(digits string) string {
+	if l.Group == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	head, tail := digits[:len(digits)-3], digits[len(digits)-3:]
+	size := 3
+	if l.Lakh {
+		size = 2
+	}
+	var parts []string
+	for len(head) > size {
+		parts = append([]string{head[len(head)-size:]}, parts...)
+		head = head[:len(head)-size]
+	}
+	parts = append([]string{head}, parts...)
+	return strings.Join(append(parts, tail), l.Group)
+}
+
+// CurrencySymbol returns the symbol and decimals of a currency code, using the locale's
+// currency when code is empty. Unknown codes are used as the symbol.
+func (l Locale) CurrencySymbol(code string) (string, int) {
+	if code == "" {
+		code = l.Currency
+	}
+	if currency, ok := currencies[strings.ToUpper(code)]; ok {
+		return currency.Symbol, currency.Decimals
+	}
+	return code, 2
+}
+
+// FormatCurrency formats an amount with the currency symbol placed for the locale.
+// An empty symbol uses the symbol of the currency code.
+func (l Locale) FormatCurrency(amount float64, code, symbol string) string {
+	currencySymbol, decimals := l.CurrencySymbol(code)
+	if symbol == "" {
+		symbol = currencySymbol
+	}
+
+	number := l.FormatNumber(math.Abs(amount), decimals)
+	sign := ""
+	if amount < 0 && strings.Trim(number, "0., ") != "" {
+		sign = "-"
+	}
+	switch {
+	case l.SymbolAfter:
+		return sign + number + " " + symbol
+	case l.SymbolSpace:
+		return sign + symbol + " " + number
+	}
+	return sign + symbol + number
+}
+
+// FormatDate formats a time with the layout, replacing English month and weekday names.
+// The layout long uses the locale's long date layout.
+func (l Locale) FormatDate(t time.Time, layout string) string {
+	if layout == "long" {
+		layout = l.LongDate
+	}
+	formatted := t.Format(layout)
+
+	if len(l.Months) == 12 {
+		month := t.Month().String()
+		switch {
+		case strings.Contains(layout, "January"):
+			formatted = strings.Replace(formatted, month, l.Months[t.Month()-1], 1)
+		case strings.Contains(layout, "Jan"):
+			formatted = strings.Replace(formatted, month[:3], shortName(l.Months[t.Month()-1]), 1)
+		}
+	}
+	if len(l.Weekdays) == 7 {
+		weekday := t.Weekday().String()
+		switch {
+		case strings.Contains(layout, "Monday"):
+			formatted = strings.Replace(formatted, weekday, l.Weekdays[t.Weekday()], 1)
+		case strings.Contains(layout, "Mon"):
+			formatted = strings.Replace(formatted, weekday[:3], shortName(l.Weekdays[t.Weekday()]), 1)
+		}
+	}
+	return formatted
+}
+
+// shortName abbreviates a month or weekday name to its first three letters
+func shortName(name string) string {
+	runes := []rune(name)
+	if len(runes) <= 3 {
+		return name
+	}
+	return string(runes[:3])
+}
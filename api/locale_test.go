@@ -0,0 +1,110 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleFormatting(t *testing.T) {
+	lookup := func(tag string) Locale {
+		locale, err := LookupLocale(tag)
+		if err != nil {
+			t.Fatalf("failed to find locale %s: %v", tag, err)
+		}
+		return locale
+	}
+
+	t.Run("Numbers", func(t *testing.T) {
+		tests := []struct {
+			locale   string
+			amount   float64
+			currency string
+			expected string
+		}{
+			{"en-US", 1234567.891, "", "$1,234,567.89"},
+			{"de-DE", 1234567.891, "", "1.234.567,89 €"},
+			{"de_DE.UTF-8", -42, "USD", "-42,00 $"},
+			{"en-IN", 1234567.891, "", "₹12,34,567.89"},
+			{"nl", 1234.5, "", "€ 1.234,50"},
+			{"en-GB", 1500, "JPY", "¥1,500"},
+			{"fr-FR", 1234.5, "CHF", "1 234,50 CHF"},
+		}
+		for _, test := range tests {
+			if got := lookup(test.locale).FormatCurrency(test.amount, test.currency, ""); got != test.expected {
+				t.Errorf("%s %v %s: expected %q, got %q", test.locale, test.amount, test.currency, test.expected, got)
+			}
+		}
+		if got := lookup("en-IN").FormatNumber(123456789, 0); got != "12,34,56,789" {
+			t.Errorf("expected lakh grouping, got %q", got)
+		}
+	})
+
+	t.Run("Dates", func(t *testing.T) {
+		date := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+		tests := map[string]string{
+			"en-US": "March 5, 2024",
+			"de-DE": "5. März 2024",
+			"es-ES": "5 de marzo de 2024",
+		}
+		for tag, expected := range tests {
+			if got := lookup(tag).FormatDate(date, "long"); got != expected {
+				t.Errorf("%s: expected %q, got %q", tag, expected, got)
+			}
+		}
+		if got := lookup("fr").FormatDate(date, "Mon 02 Jan 2006"); got != "mar 05 mar 2024" {
+			t.Errorf("expected short French names, got %q", got)
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		if _, err := LookupLocale("xx-YY"); err == nil {
+			t.Errorf("expected an error for an unknown locale")
+		}
+
+		field := PrettyField{Name: "total", Format: FormatCurrency, FormatOptions: map[string]string{"currency": "EUR", "locale": "de-DE"}}
+		value, err := field.Parse(1234.5)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+		if got := value.Formatted(); got != "1.234,50 €" {
+			t.Errorf("expected the field locale and currency, got %q", got)
+		}
+
+		if err := SetLocale("en-IN"); err != nil {
+			t.Fatalf("failed to set locale: %v", err)
+		}
+		defer func() { _ = SetLocale("en-US") }()
+		value, _ = PrettyField{Name: "amount", Format: FormatFloat}.Parse(1234567.5)
+		if got := value.Formatted(); got != "12,34,567.50" {
+			t.Errorf("expected the current locale, got %q", got)
+		}
+	})
+	t.Run("WithLocale", func(t *testing.T) {
+		amount := PrettyField{Name: "amount", Format: FormatFloat}
+		fixed := PrettyField{Name: "fixed", Format: FormatFloat, FormatOptions: map[string]string{"locale": "en-IN"}}
+		amountValue, _ := amount.Parse(1234567.5)
+		fixedValue, _ := fixed.Parse(1234567.5)
+		data := &PrettyData{
+			Schema: &PrettyObject{Fields: []PrettyField{amount, fixed, {Name: "rows", Format: FormatTable, TableOptions: PrettyTable{Fields: []PrettyField{amount}}}}},
+			Values: map[string]FieldValue{"amount": amountValue, "fixed": fixedValue},
+			Tables: map[string][]PrettyDataRow{"rows": {{"amount": amountValue}}},
+		}
+
+		localized := data.WithLocale("de-DE")
+		if got := localized.Values["amount"].Formatted(); got != "1.234.567,50" {
+			t.Errorf("expected the value in the locale, got %q", got)
+		}
+		if got := localized.Tables["rows"][0]["amount"].Formatted(); got != "1.234.567,50" {
+			t.Errorf("expected the row in the locale, got %q", got)
+		}
+		if got := localized.Values["fixed"].Formatted(); got != "12,34,567.50" {
+			t.Errorf("expected the locale of the field, got %q", got)
+		}
+		if got := localized.Schema.Fields[2].TableOptions.Fields[0].Locale().Tag; got != "de-DE" {
+			t.Errorf("expected the columns in the locale, got %s", got)
+		}
+		if got := data.Values["amount"].Formatted(); got != "1,234,567.50" || data.Schema.Fields[0].FormatOptions != nil {
+			t.Errorf("expected the data unchanged, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// markupColors are the colours AsciiDoc styles with built-in roles. RST output uses
+// the same names as custom roles, see RSTRoles.
+var markupColors = []string{
+	"aqua", "black", "blue", "fuchsia", "gray", "green", "lime", "maroon",
+	"navy", "olive", "purple", "red", "silver", "teal", "white", "yellow",
+}
+
+// AsciiDoc renders the text as AsciiDoc. Colours map to the closest built-in colour
+// role, and underline and strikethrough to the underline and line-through roles,
+// e.g. [.red.line-through]##**Failed**##.
+func (t Text) AsciiDoc() string {
+	if t.Href != "" {
+		return asciidocLink(t.asciidoc(), t.Href)
+	}
+	return t.asciidoc()
+}
+
+func (t Text) asciidoc() string {
+	content := t.Content
+	for _, child := range t.Children {
+		content += child.AsciiDoc()
+	}
+
+	text, style, ok := t.markupStyle(content)
+	if !ok {
+		return content
+	}
+
+	leading, core, trailing := splitSpace(text)
+	if core == "" {
+		return text
+	}
+
+	var roles []string
+	if name := markupColorName(style.Foreground); name != "" {
+		roles = append(roles, name)
+	} else if style.Faint {
+		roles = append(roles, "gray")
+	}
+	if name := markupColorName(style.Background); name != "" {
+		roles = append(roles, name+"-background")
+	}
+	if style.Underline {
+		roles = append(roles, "underline")
+	}
+	if style.Strikethrough {
+		roles = append(roles, "line-through")
+	}
+
+	// Unconstrained marks also apply in the middle of a word
+	if style.Bold {
+		core = "**" + core + "**"
+	}
+	if style.Italic {
+		core = "__" + core + "__"
+	}
+	if len(roles) > 0 {
+		core = "[." + strings.Join(roles, ".") + "]##" + core + "##"
+	}
+	return leading + core + trailing
+}
+
+// RST renders the text as reStructuredText. Inline markup cannot be nested in RST, so
+// a colour role such as :red:`text` takes precedence over underline and strikethrough
+// roles, roles over bold and italic, and links over any styling. Documents declare
+// the roles they use with the directives returned by RSTRoles.
+func (t Text) RST() string {
+	if t.Href != "" {
+		return rstLink(t.String(), t.Href)
+	}
+	return t.rst()
+}
+
+func (t Text) rst() string {
+	content := t.Content
+	for _, child := range t.Children {
+		content += child.RST()
+	}
+
+	text, style, ok := t.markupStyle(content)
+	if !ok {
+		return content
+	}
+
+	leading, core, trailing := splitSpace(text)
+	if core == "" {
+		return text
+	}
+
+	role := markupColorName(style.Foreground)
+	if role == "" {
+		if name := markupColorName(style.Background); name != "" {
+			role = name + "-background"
+		} else if style.Faint {
+			role = "gray"
+		} else if style.Underline {
+			role = "underline"
+		} else if style.Strikethrough {
+			role = "line-through"
+		}
+	}
+
+	switch {
+	case role != "":
+		core = ":" + role + ":`" + strings.ReplaceAll(core, "`", "\\`") + "`"
+	case style.Bold:
+		core = "**" + strings.ReplaceAll(core, "*", "\\*") + "**"
+	case style.Italic:
+		core = "*" + strings.ReplaceAll(core, "*", "\\*") + "*"
+	}
+	return leading + core + trailing
+}
+
+// RSTRoles returns the role directives declaring the custom roles used in RST output,
+// e.g. ".. role:: red"
+func RSTRoles(rst string) []string {
+	var names []string
+	for _, color := range markupColors {
+		names = append(names, color, color+"-background")
+	}
+	names = append(names, "underline", "line-through")
+
+	var directives []string
+	for _, name := range names {
+		if strings.Contains(rst, ":"+name+":`") {
+			directives = append(directives, ".. role:: "+name)
+		}
+	}
+	return directives
+}
+
+// markupStyle returns the content with its text transform applied and its style, or
+// false when the text is not styled
+func (t Text) markupStyle(content string) (string, TailwindStyle, bool) {
+	if t.Class != (Class{}) {
+		return content, classToTailwindStyle(t.Class), true
+	}
+	if t.Style != "" {
+		text, style := ApplyTailwindStyle(content, t.Style)
+		return text, style, true
+	}
+	return content, TailwindStyle{}, false
+}
+
+// splitSpace splits the leading and trailing whitespace from text, as inline markup
+// must start and end next to a non-space character
+func splitSpace(text string) (string, string, string) {
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text, "", ""
+	}
+	start := strings.Index(text, core)
+	return text[:start], core, text[start+len(core):]
+}
+
+// markupColorName returns the markup colour closest to a hex colour, picking greys by
+// lightness and other colours by hue
+func markupColorName(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+	for _, name := range markupColors {
+		if color == name {
+			return name
+		}
+	}
+
+	hex := strings.TrimPrefix(color, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 || !strings.HasPrefix(color, "#") {
+		return ""
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ""
+	}
+	r, g, b := float64(rgb>>16&0xff)/255, float64(rgb>>8&0xff)/255, float64(rgb&0xff)/255
+
+	high, low := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	chroma := high - low
+	lightness := (high + low) / 2
+	saturation := 0.0
+	if chroma > 0 {
+		saturation = chroma / (1 - math.Abs(2*lightness-1))
+	}
+
+	if saturation < 0.25 {
+		switch {
+		case lightness < 0.15:
+			return "black"
+		case lightness < 0.6:
+			return "gray"
+		case lightness < 0.85:
+			return "silver"
+		default:
+			return "white"
+		}
+	}
+
+	var hue float64
+	switch high {
+	case r:
+		hue = math.Mod((g-b)/chroma, 6)
+	case g:
+		hue = (b-r)/chroma + 2
+	default:
+		hue = (r-g)/chroma + 4
+	}
+	hue *= 60
+	if hue < 0 {
+		hue += 360
+	}
+
+	dark := lightness < 0.3
+	switch {
+	case hue < 20 || hue >= 345:
+		if dark {
+			return "maroon"
+		}
+		return "red"
+	case hue < 70:
+		if dark {
+			return "olive"
+		}
+		return "yellow"
+	case hue < 165:
+		return "green"
+	case hue < 185:
+		return "teal"
+	case hue < 200:
+		if dark {
+			return "teal"
+		}
+		return "aqua"
+	case hue < 255:
+		if dark {
+			return "navy"
+		}
+		return "blue"
+	case hue < 290:
+		return "purple"
+	default:
+		return "fuchsia"
+	}
+}
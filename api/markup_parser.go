@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markupAliases are the names markup tags accept besides Tailwind classes, matching the
+// semantic styles of TextBuilder
+var markupAliases = map[string]string{
+	"bold":      "font-bold",
+	"italic":    "italic",
+	"underline": "underline",
+	"strike":    "line-through",
+	"faint":     "opacity-60",
+	"muted":     "text-gray-500",
+	"success":   "text-green-600",
+	"error":     "text-red-600",
+	"warning":   "text-yellow-600",
+	"info":      "text-blue-600",
+}
+
+// MarkupError is a syntax error in inline markup, at a 1-based line and column
+type MarkupError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *MarkupError) Error() string {
+	if e.Line > 1 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// markupSpan is an open tag and the text parsed inside it so far
+type markupSpan struct {
+	tag    string
+	line   int
+	column int
+	text   Text
+}
+
+// ParseMarkup parses inline markup into Text. Tags hold Tailwind classes, the names of
+// markupAliases and link=<url>, and close with [/] or a repeat of the tag, e.g.
+//
+//	[bold text-red-500]failed[/] in [muted]3s[/]
+//
+// Tags nest, and \[ and \\ are a literal bracket and backslash. A [ that is not followed
+// by a letter or / is literal, so "items[0]" needs no escaping.
+func ParseMarkup(markup string) (Text, error) {
+	stack := []*markupSpan{{}}
+	var run strings.Builder
+	line, column := 1, 0
+
+	flush := func() {
+		if run.Len() > 0 {
+			top := &stack[len(stack)-1].text
+			top.Children = append(top.Children, Text{Content: run.String()})
+			run.Reset()
+		}
+	}
+
+	runes := []rune(markup)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		column++
+		switch {
+		case r == '\n':
+			run.WriteRune(r)
+			line, column = line+1, 0
+
+		case r == '\\' && i+1 < len(runes) && (runes[i+1] == '[' || runes[i+1] == ']' || runes[i+1] == '\\'):
+			run.WriteRune(runes[i+1])
+			i++
+			column++
+
+		case r == '[' && i+1 < len(runes) && isMarkupTagStart(runes[i+1]):
+			// Brackets nest inside tags for arbitrary values such as text-[#ff0000]
+			end, depth := i+1, 0
+			for ; end < len(runes) && runes[end] != '\n'; end++ {
+				if runes[end] == '[' {
+					depth++
+				} else if runes[end] == ']' {
+					if depth == 0 {
+						break
+					}
+					depth--
+				}
+			}
+			if end == len(runes) || runes[end] != ']' {
+				return Text{}, &MarkupError{Line: line, Column: column, Message: "tag is not closed with ]"}
+			}
+			tag := strings.TrimSpace(string(runes[i+1 : end]))
+			flush()
+
+			if closing, ok := strings.CutPrefix(tag, "/"); ok {
+				if len(stack) == 1 {
+					return Text{}, &MarkupError{Line: line, Column: column, Message: fmt.Sprintf("[%s] closes no open tag", tag)}
+				}
+				span := stack[len(stack)-1]
+				if closing = strings.TrimSpace(closing); closing != "" && closing != span.tag {
+					return Text{}, &MarkupError{Line: line, Column: column, Message: fmt.Sprintf("[%s] does not close [%s] opened at column %d", tag, span.tag, span.column)}
+				}
+				stack = stack[:len(stack)-1]
+				parent := &stack[len(stack)-1].text
+				parent.Children = append(parent.Children, span.text.simplify())
+			} else {
+				text, err := markupTagText(tag)
+				if err != nil {
+					return Text{}, &MarkupError{Line: line, Column: column, Message: err.Error()}
+				}
+				stack = append(stack, &markupSpan{tag: tag, line: line, column: column, text: text})
+			}
+			column += end - i
+			i = end
+
+		default:
+			run.WriteRune(r)
+		}
+	}
+	flush()
+
+	if len(stack) > 1 {
+		span := stack[len(stack)-1]
+		return Text{}, &MarkupError{Line: span.line, Column: span.column, Message: fmt.Sprintf("[%s] is not closed", span.tag)}
+	}
+	return stack[0].text.simplify(), nil
+}
+
+// Markup parses inline markup into Text, falling back to the markup as plain text when
+// it is invalid
+func Markup(markup string) Text {
+	text, err := ParseMarkup(markup)
+	if err != nil {
+		return Text{Content: markup}
+	}
+	return text
+}
+
+// EscapeMarkup escapes text so ParseMarkup reads it literally
+func EscapeMarkup(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`).Replace(text)
+}
+
+func isMarkupTagStart(r rune) bool {
+	return r == '/' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// markupTagText returns the styled, and possibly linked, text an opening tag starts
+func markupTagText(tag string) (Text, error) {
+	var text Text
+	var classes []string
+	for _, token := range strings.Fields(tag) {
+		if url, ok := strings.CutPrefix(token, "link="); ok {
+			if url == "" {
+				return Text{}, fmt.Errorf("[%s] has an empty link", tag)
+			}
+			text.Href = url
+		} else if class, ok := markupAliases[token]; ok {
+			classes = append(classes, class)
+		} else {
+			classes = append(classes, token)
+		}
+	}
+	text.Style = strings.Join(classes, " ")
+	return text, nil
+}
+
+// simplify folds a lone unstyled child into its parent, so plain runs become Content
+func (t Text) simplify() Text {
+	if len(t.Children) == 1 && t.Content == "" {
+		child := t.Children[0]
+		if child.Style == "" && child.Href == "" && child.Layout == nil && len(child.Children) == 0 {
+			t.Content, t.Children = child.Content, nil
+		}
+	}
+	return t
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		plain    string
+		markdown string
+		html     string
+	}{
+		{
+			name:     "plain",
+			markup:   "no tags",
+			plain:    "no tags",
+			markdown: "no tags",
+			html:     "no tags",
+		},
+		{
+			name:     "styles and aliases",
+			markup:   "[bold text-red-500]failed[/] in [muted]3s[/]",
+			plain:    "failed in 3s",
+			markdown: `<span style="color: #ef4444">**failed**</span> in <span style="color: #6b7280">3s</span>`,
+			html:     `<span class="font-bold text-red-500" style="color: #ef4444"><strong>failed</strong></span> in <span class="text-gray-500" style="color: #6b7280">3s</span>`,
+		},
+		{
+			name:     "nesting",
+			markup:   "[italic]a [bold]b[/bold] c[/]",
+			plain:    "a b c",
+			markdown: "*a **b** c*",
+			html:     `<span class="italic"><em>a <span class="font-bold"><strong>b</strong></span> c</em></span>`,
+		},
+		{
+			name:     "escapes",
+			markup:   `\[bold] a\\b`,
+			plain:    `[bold] a\b`,
+			markdown: `[bold] a\b`,
+			html:     `[bold] a\b`,
+		},
+		{
+			name:     "literal brackets",
+			markup:   "items[0] [ ok ]",
+			plain:    "items[0] [ ok ]",
+			markdown: "items[0] [ ok ]",
+			html:     "items[0] [ ok ]",
+		},
+		{
+			name:     "link",
+			markup:   "see [link=https://example.com/a]docs[/]",
+			plain:    "see docs",
+			markdown: "see [docs](https://example.com/a)",
+			html:     `see <a href="https://example.com/a" class="text-blue-600 hover:underline">docs</a>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := ParseMarkup(test.markup)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := text.String(); got != test.plain {
+				t.Errorf("expected plain %q, got %q", test.plain, got)
+			}
+			if got := text.Markdown(); got != test.markdown {
+				t.Errorf("expected markdown %q, got %q", test.markdown, got)
+			}
+			if got := text.HTML(); got != test.html {
+				t.Errorf("expected html %q, got %q", test.html, got)
+			}
+		})
+	}
+}
+
+func TestParseMarkupErrors(t *testing.T) {
+	tests := []struct {
+		markup   string
+		expected string
+	}{
+		{"[bold]x", "column 1: [bold] is not closed"},
+		{"ok [/]", "column 4: [/] closes no open tag"},
+		{"[bold]a [italic]b[/bold]", "column 18: [/bold] does not close [italic] opened at column 9"},
+		{"x [bold", "column 3: tag is not closed with ]"},
+		{"[link=]x[/]", "column 1: [link=] has an empty link"},
+		{"a\n b [bold]c", "line 2, column 4: [bold] is not closed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.markup, func(t *testing.T) {
+			_, err := ParseMarkup(test.markup)
+			var markupErr *MarkupError
+			if !errors.As(err, &markupErr) {
+				t.Fatalf("expected a MarkupError, got %v", err)
+			}
+			if err.Error() != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, err.Error())
+			}
+		})
+	}
+
+	if got := Markup("[bold]x").String(); got != "[bold]x" {
+		t.Errorf("expected invalid markup as plain text, got %q", got)
+	}
+}
+
+func TestEscapeMarkup(t *testing.T) {
+	for _, text := range []string{"[bold]", `a\[b`, "items[0]", "[/]"} {
+		parsed, err := ParseMarkup(EscapeMarkup(text))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", text, err)
+		}
+		if got := parsed.String(); got != text {
+			t.Errorf("expected %q to round trip, got %q", text, got)
+		}
+	}
+}
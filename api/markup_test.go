@@ -0,0 +1,104 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTextMarkup(t *testing.T) {
+	fixtures := []struct {
+		name     string
+		input    Text
+		asciidoc string
+		rst      string
+	}{
+		{
+			name:     "plain",
+			input:    Text{Content: "Hello, world!"},
+			asciidoc: "Hello, world!",
+			rst:      "Hello, world!",
+		},
+		{
+			name:     "bold child",
+			input:    Text{Content: "Hello, ", Children: []Text{{Content: "world!", Style: "font-bold"}}},
+			asciidoc: "Hello, **world!**",
+			rst:      "Hello, **world!**",
+		},
+		{
+			name:     "italic",
+			input:    Text{Content: "Emphasized", Style: "italic"},
+			asciidoc: "__Emphasized__",
+			rst:      "*Emphasized*",
+		},
+		{
+			name:     "colour",
+			input:    Text{Content: "Error", Style: "text-red-500 font-bold"},
+			asciidoc: "[.red]##**Error**##",
+			rst:      ":red:`Error`",
+		},
+		{
+			name:     "background and strikethrough",
+			input:    Text{Content: "Removed", Style: "bg-yellow-200 line-through"},
+			asciidoc: "[.yellow-background.line-through]##Removed##",
+			rst:      ":yellow-background:`Removed`",
+		},
+		{
+			name:     "class",
+			input:    Text{Content: "OK", Class: Class{Foreground: &Color{Hex: "#16a34a"}, Font: &Font{Underline: true}}},
+			asciidoc: "[.green.underline]##OK##",
+			rst:      ":green:`OK`",
+		},
+		{
+			name:     "surrounding spaces",
+			input:    Text{Content: " padded ", Style: "font-bold"},
+			asciidoc: " **padded** ",
+			rst:      " **padded** ",
+		},
+	}
+	for _, fixture := range fixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			if got := fixture.input.AsciiDoc(); got != fixture.asciidoc {
+				t.Errorf("Expected AsciiDoc %q, got %q", fixture.asciidoc, got)
+			}
+			if got := fixture.input.RST(); got != fixture.rst {
+				t.Errorf("Expected RST %q, got %q", fixture.rst, got)
+			}
+		})
+	}
+}
+
+func TestRSTRoles(t *testing.T) {
+	roles := RSTRoles("Status: :red:`failed`, :green:`ok` and :red:`error`")
+	if expected := []string{".. role:: green", ".. role:: red"}; !reflect.DeepEqual(roles, expected) {
+		t.Errorf("Expected %v, got %v", expected, roles)
+	}
+	if roles := RSTRoles("**bold** and *italic*"); len(roles) != 0 {
+		t.Errorf("Expected no roles, got %v", roles)
+	}
+}
+
+func TestMarkupColorName(t *testing.T) {
+	colors := map[string]string{
+		"#ef4444": "red",
+		"#7f1d1d": "red",
+		"#450a0a": "maroon",
+		"#f97316": "yellow",
+		"#22c55e": "green",
+		"#14b8a6": "teal",
+		"#06b6d4": "aqua",
+		"#3b82f6": "blue",
+		"#8b5cf6": "purple",
+		"#ec4899": "fuchsia",
+		"#6b7280": "gray",
+		"#d1d5db": "silver",
+		"#fff":    "white",
+		"navy":    "navy",
+		"":        "",
+		"nope":    "",
+	}
+	for color, expected := range colors {
+		if got := markupColorName(color); got != expected {
+			t.Errorf("%s: expected %q, got %q", color, expected, got)
+		}
+	}
+}
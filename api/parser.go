@@ -2,12 +2,10 @@ package api
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // StructParser handles parsing of structs into PrettyObject
@@ -269,19 +267,26 @@ func (p *StructParser) ParseValue(value interface{}, field PrettyField) (FieldVa
 	return field.Parse(value)
 }
 
-// LoadSchemaFromYAML loads a PrettyObject schema from a YAML file
-func (p *StructParser) LoadSchemaFromYAML(filepath string) (*PrettyObject, error) {
-	data, err := os.ReadFile(filepath)
+// LoadSchemaFromYAML loads a PrettyObject schema from a YAML file. Schemas can include
+// other schema files, and fields can $ref entries of definitions: with the other keys
+// of the field overriding the definition.
+func (p *StructParser) LoadSchemaFromYAML(schemaFile string) (*PrettyObject, error) {
+	doc, err := (&schemaLoader{}).load(schemaFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read schema file: %w", err)
+		return nil, err
 	}
 
-	var schema PrettyObject
-	if err := yaml.Unmarshal(data, &schema); err != nil {
-		return nil, fmt.Errorf("failed to parse YAML schema: %w", err)
+	schema, err := doc.decode()
+	if err != nil {
+		return nil, err
 	}
 
-	return &schema, nil
+	// Resolve the template relative to the schema file
+	if schema.Template != "" && !filepath.IsAbs(schema.Template) {
+		schema.Template = filepath.Join(filepath.Dir(schemaFile), schema.Template)
+	}
+
+	return schema, nil
 }
 
 // ParseWithSchema parses data using a predefined schema with heuristics
@@ -362,6 +367,9 @@ func (p *StructParser) ParseDataWithSchema(data interface{}, schema *PrettyObjec
 		}
 
 		if !fieldVal.IsValid() {
+			if defaultValue, ok := field.DefaultValue(); ok {
+				result.Values[field.Name] = defaultValue
+			}
 			continue
 		}
 
@@ -374,7 +382,7 @@ func (p *StructParser) ParseDataWithSchema(data interface{}, schema *PrettyObjec
 		if field.Format == FormatTable && (fieldVal.Kind() == reflect.Slice || fieldVal.Kind() == reflect.Array) {
 			// Parse table data
 			tableRows := p.parseTableData(fieldVal, field)
-			result.Tables[field.Name] = tableRows
+			result.Tables[field.Name] = GroupRows(field, tableRows)
 		} else {
 			// Handle nested struct/map fields - create nested FieldValues instead of string formatting
 			if (field.Type == "struct" || field.Type == "map") && (fieldVal.Kind() == reflect.Map || fieldVal.Kind() == reflect.Struct) {
@@ -435,6 +443,8 @@ func (p *StructParser) parseTableData(val reflect.Value, field PrettyField) []Pr
 				if err == nil {
 					row[tableField.Name] = fieldValue
 				}
+			} else if defaultValue, ok := tableField.DefaultValue(); ok {
+				row[tableField.Name] = defaultValue
 			}
 		}
 
@@ -506,6 +516,17 @@ func (p *StructParser) enhanceFieldWithHeuristics(field PrettyField, val reflect
 	if enhanced.Format == "" {
 		enhanced.Format = p.inferFormat(field.Name, val)
 	}
+	if enhanced.Format == FormatDuration && enhanced.FormatOptions["unit"] == "" {
+		if unit := inferDurationUnit(field.Name); unit != "" {
+			// Copy the options, which are shared with the schema
+			options := make(map[string]string, len(enhanced.FormatOptions)+1)
+			for k, v := range enhanced.FormatOptions {
+				options[k] = v
+			}
+			options["unit"] = unit
+			enhanced.FormatOptions = options
+		}
+	}
 
 	// Apply color heuristics for certain fields
 	if enhanced.Color == "" && len(enhanced.ColorOptions) == 0 {
@@ -531,6 +552,29 @@ func (p *StructParser) enhanceFieldWithHeuristics(field PrettyField, val reflect
 func (p *StructParser) inferFormat(fieldName string, val reflect.Value) string {
 	fieldNameLower := strings.ToLower(fieldName)
 
+	// Unit patterns, for numbers only so that e.g. a formatted size string is kept
+	kind := val.Kind()
+	if (kind == reflect.Ptr || kind == reflect.Interface) && !val.IsNil() {
+		kind = val.Elem().Kind()
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		switch {
+		case strings.Contains(fieldNameLower, "bytes"):
+			return FormatBytes
+		case strings.HasSuffix(fieldNameLower, "_pct"):
+			return FormatPercent
+		case strings.HasSuffix(fieldNameLower, "_ago") || strings.Contains(fieldNameLower, "last_seen"):
+			return FormatRelative
+		case inferDurationUnit(fieldNameLower) != "" || strings.Contains(fieldNameLower, "duration") ||
+			strings.Contains(fieldNameLower, "elapsed") || strings.Contains(fieldNameLower, "latency") ||
+			strings.Contains(fieldNameLower, "uptime"):
+			return FormatDuration
+		}
+	}
+
 	// Date/time patterns
 	if strings.Contains(fieldNameLower, "date") || strings.Contains(fieldNameLower, "time") ||
 		strings.Contains(fieldNameLower, "created") || strings.Contains(fieldNameLower, "updated") {
@@ -561,6 +605,17 @@ func (p *StructParser) inferFormat(fieldName string, val reflect.Value) string {
 	return ""
 }
 
+// inferDurationUnit returns the unit of numeric durations named like latency_ms or timeout_seconds
+func inferDurationUnit(fieldName string) string {
+	fieldNameLower := strings.ToLower(fieldName)
+	for _, suffix := range []string{"_ns", "_nanos", "_us", "_micros", "_ms", "_millis", "_s", "_sec", "_secs", "_seconds"} {
+		if strings.HasSuffix(fieldNameLower, suffix) {
+			return strings.TrimPrefix(suffix, "_")
+		}
+	}
+	return ""
+}
+
 // inferColorOptions applies heuristics to determine color coding for fields
 func (p *StructParser) inferColorOptions(fieldName string, val reflect.Value) map[string]string {
 	fieldNameLower := strings.ToLower(fieldName)
@@ -786,7 +841,13 @@ func (p *StructParser) ParseStructSchema(val reflect.Value) (*PrettyObject, erro
 			continue
 		}
 
+		// Key the field by its json name like table columns, keeping the Go name as the label
 		prettyField := ParsePrettyTagWithName(field.Name, prettyTag)
+		if jsonTag := field.Tag.Get("json"); jsonTag != "" && jsonTag != "-" {
+			if parts := strings.Split(jsonTag, ","); parts[0] != "" {
+				prettyField.Name = parts[0]
+			}
+		}
 
 		// Check if it's a table field (slice/array of structs)
 		fieldVal := val.Field(i)
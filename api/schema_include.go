@@ -0,0 +1,386 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaDefinition is a named field from the definitions: of a schema file
+type schemaDefinition struct {
+	file string
+	node *yaml.Node
+	// scope holds the definitions visible from the file declaring this one
+	scope map[string]*schemaDefinition
+}
+
+// schemaField is a field with its $refs resolved and the file it was declared in
+type schemaField struct {
+	file string
+	node *yaml.Node
+}
+
+// schemaDocument is a schema file with its includes and $refs resolved
+type schemaDocument struct {
+	file        string
+	root        *yaml.Node
+	definitions map[string]*schemaDefinition
+	fields      []schemaField
+}
+
+// schemaLoader loads schema files, tracking the chain of includes to detect cycles
+type schemaLoader struct {
+	chain []string
+}
+
+// load reads a schema file, resolving include:, definitions: and $ref
+func (l *schemaLoader) load(file string) (*schemaDocument, error) {
+	root, err := readSchemaNode(file)
+	if err != nil {
+		return nil, err
+	}
+	return l.resolve(file, root)
+}
+
+func readSchemaNode(file string) (*yaml.Node, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML schema %s: %w", file, err)
+	}
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, schemaError(file, root, "schema must be a mapping")
+	}
+	return root, nil
+}
+
+func (l *schemaLoader) resolve(file string, root *yaml.Node) (*schemaDocument, error) {
+	doc := &schemaDocument{
+		file:        file,
+		root:        root,
+		definitions: make(map[string]*schemaDefinition),
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		abs = file
+	}
+	l.chain = append(l.chain, abs)
+	defer func() { l.chain = l.chain[:len(l.chain)-1] }()
+
+	// Included files provide definitions and fields, which the including file can replace
+	if include := mappingValue(root, "include"); include != nil {
+		paths := []*yaml.Node{include}
+		if include.Kind == yaml.SequenceNode {
+			paths = include.Content
+		}
+		for _, path := range paths {
+			if path.Kind != yaml.ScalarNode || path.Value == "" {
+				return nil, schemaError(file, path, "include must be a file path or a list of file paths")
+			}
+			includeFile := path.Value
+			if !filepath.IsAbs(includeFile) {
+				includeFile = filepath.Join(filepath.Dir(file), includeFile)
+			}
+			if err := l.checkCycle(includeFile); err != nil {
+				return nil, schemaError(file, path, "%v", err)
+			}
+
+			includeRoot, err := readSchemaNode(includeFile)
+			if err != nil {
+				return nil, schemaError(file, path, "%v", err)
+			}
+			included, err := l.resolve(includeFile, includeRoot)
+			if err != nil {
+				return nil, err
+			}
+			for name, definition := range included.definitions {
+				doc.definitions[name] = definition
+			}
+			for _, field := range included.fields {
+				doc.fields = addSchemaField(doc.fields, field)
+			}
+		}
+	}
+
+	if definitions := mappingValue(root, "definitions"); definitions != nil {
+		if definitions.Kind != yaml.MappingNode {
+			return nil, schemaError(file, definitions, "definitions must be a mapping of names to fields")
+		}
+		for i := 0; i+1 < len(definitions.Content); i += 2 {
+			doc.definitions[definitions.Content[i].Value] = &schemaDefinition{
+				file:  file,
+				node:  definitions.Content[i+1],
+				scope: doc.definitions,
+			}
+		}
+	}
+
+	if fields := mappingValue(root, "fields"); fields != nil {
+		if fields.Kind != yaml.SequenceNode {
+			return nil, schemaError(file, fields, "fields must be a list")
+		}
+		for _, item := range fields.Content {
+			node, err := resolveSchemaField(file, item, doc.definitions, nil)
+			if err != nil {
+				return nil, err
+			}
+			doc.fields = addSchemaField(doc.fields, schemaField{file: file, node: node})
+		}
+	}
+	return doc, nil
+}
+
+// checkCycle returns an error when the file is already being loaded
+func (l *schemaLoader) checkCycle(file string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		abs = file
+	}
+	for i, loading := range l.chain {
+		if loading == abs {
+			cycle := make([]string, 0, len(l.chain)-i+1)
+			for _, f := range append(l.chain[i:], abs) {
+				cycle = append(cycle, filepath.Base(f))
+			}
+			return fmt.Errorf("include cycle %s", strings.Join(cycle, " -> "))
+		}
+	}
+	return nil
+}
+
+// decode converts the resolved schema into a PrettyObject
+func (d *schemaDocument) decode() (*PrettyObject, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(d.root.Content); i += 2 {
+		switch d.root.Content[i].Value {
+		case "include", "definitions", "fields":
+			continue
+		}
+		root.Content = append(root.Content, d.root.Content[i], d.root.Content[i+1])
+	}
+
+	var schema PrettyObject
+	if err := root.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", d.file, err)
+	}
+	for _, field := range d.fields {
+		if err := checkSchemaMarkup(field.file, field.node); err != nil {
+			return nil, err
+		}
+		var prettyField PrettyField
+		if err := field.node.Decode(&prettyField); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.file, err)
+		}
+		schema.Fields = append(schema.Fields, prettyField)
+	}
+	return &schema, nil
+}
+
+// checkSchemaMarkup returns an error for a label or default of the field, or of its nested
+// fields, that uses markup with a closing tag but is not valid markup. Other brackets, as in
+// "Amount [USD]", are read literally like Markup does.
+func checkSchemaMarkup(file string, node *yaml.Node) error {
+	for _, key := range []string{"label", "default"} {
+		if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode && strings.Contains(value.Value, "[/") {
+			if _, err := ParseMarkup(value.Value); err != nil {
+				return schemaError(file, value, "field %s: invalid %s markup: %v", schemaFieldName(node), key, err)
+			}
+		}
+	}
+	for _, fields := range []*yaml.Node{mappingValue(node, "fields"), mappingValue(mappingValue(node, "table_options"), "fields")} {
+		if fields == nil || fields.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, field := range fields.Content {
+			if err := checkSchemaMarkup(file, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSchemaField returns a copy of the field with its $ref and those of its nested
+// fields replaced by the referenced definitions, with the other keys as overrides
+func resolveSchemaField(file string, node *yaml.Node, definitions map[string]*schemaDefinition, refs []string) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, schemaError(file, node, "field must be a mapping")
+	}
+
+	field := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	var ref *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		var err error
+		switch key.Value {
+		case "$ref":
+			ref = value
+			continue
+		case "fields":
+			value, err = resolveSchemaFields(file, value, definitions, refs)
+		case "table_options":
+			if columns := mappingValue(value, "fields"); columns != nil {
+				if columns, err = resolveSchemaFields(file, columns, definitions, refs); err == nil {
+					value = withMappingValue(value, "fields", columns)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		field.Content = append(field.Content, key, value)
+	}
+	if ref == nil {
+		return field, nil
+	}
+
+	name := strings.TrimPrefix(ref.Value, "#/definitions/")
+	definition, ok := definitions[name]
+	if !ok {
+		return nil, schemaError(file, ref, "unknown definition %q", ref.Value)
+	}
+	for _, r := range refs {
+		if r == name {
+			return nil, schemaError(file, ref, "circular $ref %s", strings.Join(append(refs, name), " -> "))
+		}
+	}
+	base, err := resolveSchemaField(definition.file, definition.node, definition.scope, append(refs[:len(refs):len(refs)], name))
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergeSchemaNodes(base, field)
+	if mappingValue(merged, "name") == nil {
+		merged = withMappingValue(merged, "name", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+	}
+	return merged, nil
+}
+
+func resolveSchemaFields(file string, node *yaml.Node, definitions map[string]*schemaDefinition, refs []string) (*yaml.Node, error) {
+	if node.Kind != yaml.SequenceNode {
+		return nil, schemaError(file, node, "fields must be a list")
+	}
+	fields := &yaml.Node{Kind: yaml.SequenceNode, Tag: node.Tag, Line: node.Line, Column: node.Column}
+	for _, item := range node.Content {
+		field, err := resolveSchemaField(file, item, definitions, refs)
+		if err != nil {
+			return nil, err
+		}
+		fields.Content = append(fields.Content, field)
+	}
+	return fields, nil
+}
+
+// mergeSchemaNodes returns the base mapping with the keys of override applied on top.
+// Nested mappings are merged, and nested fields are merged by name so an override can
+// restyle a single field of a referenced struct.
+func mergeSchemaNodes(base, override *yaml.Node) *yaml.Node {
+	if base.Kind != yaml.MappingNode || override.Kind != yaml.MappingNode {
+		return override
+	}
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: base.Tag, Line: override.Line, Column: override.Column}
+	merged.Content = append(merged.Content, base.Content...)
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, value := override.Content[i], override.Content[i+1]
+		if existing := mappingValue(merged, key.Value); existing != nil {
+			switch {
+			case key.Value == "fields" && existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+				value = mergeSchemaFieldLists(existing, value)
+			case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+				value = mergeSchemaNodes(existing, value)
+			}
+		}
+		merged = withMappingValue(merged, key.Value, value)
+	}
+	return merged
+}
+
+func mergeSchemaFieldLists(base, override *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{Kind: yaml.SequenceNode, Tag: base.Tag, Line: override.Line, Column: override.Column}
+	merged.Content = append(merged.Content, base.Content...)
+	for _, field := range override.Content {
+		replaced := false
+		for i, existing := range merged.Content {
+			if name := schemaFieldName(field); name != "" && name == schemaFieldName(existing) {
+				merged.Content[i] = mergeSchemaNodes(existing, field)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Content = append(merged.Content, field)
+		}
+	}
+	return merged
+}
+
+// addSchemaField appends a field, replacing an earlier field with the same name
+func addSchemaField(fields []schemaField, field schemaField) []schemaField {
+	name := schemaFieldName(field.node)
+	for i, existing := range fields {
+		if name != "" && schemaFieldName(existing.node) == name {
+			fields[i] = field
+			return fields
+		}
+	}
+	return append(fields, field)
+}
+
+func schemaFieldName(node *yaml.Node) string {
+	if name := mappingValue(node, "name"); name != nil {
+		return name.Value
+	}
+	return ""
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// withMappingValue returns a copy of a mapping node with the key set to value
+func withMappingValue(node *yaml.Node, key string, value *yaml.Node) *yaml.Node {
+	updated := *node
+	updated.Content = make([]*yaml.Node, 0, len(node.Content)+2)
+	found := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			updated.Content = append(updated.Content, node.Content[i], value)
+			found = true
+			continue
+		}
+		updated.Content = append(updated.Content, node.Content[i], node.Content[i+1])
+	}
+	if !found {
+		updated.Content = append(updated.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+	return &updated
+}
+
+// schemaError reports a problem at a line of a schema file
+func schemaError(file string, node *yaml.Node, format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d: %s", file, node.Line, fmt.Sprintf(format, args...))
+}
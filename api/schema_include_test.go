@@ -0,0 +1,135 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadSchemaComposition(t *testing.T) {
+	common := `
+definitions:
+  money:
+    type: float
+    format: currency
+    style: text-green-600
+  address:
+    type: struct
+    fields:
+      - name: street
+      - name: city
+        style: text-gray-500
+fields:
+  - name: id
+    style: font-bold
+`
+
+	t.Run("IncludeAndRef", func(t *testing.T) {
+		dir := writeSchemaFiles(t, map[string]string{
+			"common.yaml": common,
+			"order.yaml": `
+include: common.yaml
+definitions:
+  total:
+    $ref: money
+    label: Total
+fields:
+  - name: total_amount
+    $ref: total
+    style: text-red-600
+  - name: shipping
+    $ref: "#/definitions/address"
+    fields:
+      - name: city
+        style: font-bold
+      - name: country
+  - $ref: money
+`,
+		})
+
+		schema, err := NewStructParser().LoadSchemaFromYAML(filepath.Join(dir, "order.yaml"))
+		if err != nil {
+			t.Fatalf("failed to load schema: %v", err)
+		}
+
+		var names []string
+		for _, field := range schema.Fields {
+			names = append(names, field.Name)
+		}
+		if got := strings.Join(names, ","); got != "id,total_amount,shipping,money" {
+			t.Fatalf("unexpected fields %s", got)
+		}
+
+		total := schema.Fields[1]
+		if total.Format != FormatCurrency || total.Label != "Total" || total.Style != "text-red-600" {
+			t.Errorf("expected the definition with overrides, got %+v", total)
+		}
+
+		shipping := schema.Fields[2]
+		if shipping.Type != FieldTypeStruct || len(shipping.Fields) != 3 {
+			t.Fatalf("expected the address fields plus country, got %+v", shipping.Fields)
+		}
+		if city := shipping.Fields[1]; city.Name != "city" || city.Style != "font-bold" {
+			t.Errorf("expected the city style to be overridden, got %+v", city)
+		}
+	})
+
+	t.Run("LiteralBrackets", func(t *testing.T) {
+		dir := writeSchemaFiles(t, map[string]string{
+			"brackets.yaml": "fields:\n  - name: amount\n    label: Amount [USD]\n  - name: status\n    default: \"[DEPRECATED] foo\"\n",
+		})
+		schema, err := NewStructParser().LoadSchemaFromYAML(filepath.Join(dir, "brackets.yaml"))
+		if err != nil {
+			t.Fatalf("failed to load schema: %v", err)
+		}
+		if label := schema.Fields[0].Label; label != "Amount [USD]" {
+			t.Errorf("expected the label kept as written, got %q", label)
+		}
+		if text := Markup(schema.Fields[1].Default).String(); text != "[DEPRECATED] foo" {
+			t.Errorf("expected the default rendered literally, got %q", text)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		dir := writeSchemaFiles(t, map[string]string{
+			"a.yaml":       "include: b.yaml\n",
+			"b.yaml":       "fields: []\ninclude:\n  - a.yaml\n",
+			"unknown.yaml": "fields:\n  - name: id\n  - name: total\n    $ref: money\n",
+			"markup.yaml":  "fields:\n  - name: items\n    format: table\n    table_options:\n      fields:\n        - name: id\n          label: \"[bold]ID[/italic]\"\n",
+			"circular.yaml": `
+definitions:
+  a:
+    $ref: b
+  b:
+    $ref: a
+fields:
+  - $ref: a
+`,
+		})
+
+		tests := map[string]string{
+			"a.yaml":        "b.yaml:3: include cycle a.yaml -> b.yaml -> a.yaml",
+			"unknown.yaml":  `unknown.yaml:4: unknown definition "money"`,
+			"circular.yaml": "circular $ref a -> b -> a",
+			"markup.yaml":   "markup.yaml:7: field id: invalid label markup: column 9: [/italic] does not close [bold] opened at column 1",
+		}
+		for file, expected := range tests {
+			_, err := NewStructParser().LoadSchemaFromYAML(filepath.Join(dir, file))
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("%s: expected error containing %q, got %v", file, expected, err)
+			}
+		}
+	})
+}
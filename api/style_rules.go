@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StyleRule applies Tailwind classes to a value when its expression matches, e.g.
+//
+//	when: value > 90
+//	style: text-red-600 font-bold
+//
+// The expression uses the filter syntax, where value is the field being styled and
+// other names are columns of the same row. Row rules style every cell of the row.
+type StyleRule struct {
+	When  string `json:"when" yaml:"when"`
+	Style string `json:"style" yaml:"style"`
+	Row   bool   `json:"row,omitempty" yaml:"row,omitempty"`
+}
+
+// ParseStyleRule parses the short form of a rule, "value > 90 -> text-red-600 font-bold",
+// where the arrow may also be written as →
+func ParseStyleRule(s string) (StyleRule, bool) {
+	for _, arrow := range []string{"→", "->"} {
+		if i := strings.LastIndex(s, arrow); i > 0 {
+			rule := StyleRule{
+				When:  strings.TrimSpace(s[:i]),
+				Style: strings.TrimSpace(s[i+len(arrow):]),
+			}
+			return rule, rule.When != "" && rule.Style != ""
+		}
+	}
+	return StyleRule{}, false
+}
+
+// UnmarshalYAML accepts a rule mapping or its short form
+func (r *StyleRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		rule, ok := ParseStyleRule(node.Value)
+		if !ok {
+			return fmt.Errorf("line %d: invalid style rule %q, expected \"<expression> -> <classes>\"", node.Line, node.Value)
+		}
+		*r = rule
+		return nil
+	}
+
+	type plain StyleRule
+	return node.Decode((*plain)(r))
+}
@@ -0,0 +1,470 @@
+package tailwind
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// preflight is the subset of the Tailwind base styles the utilities rely on
+const preflight = `*,::before,::after{box-sizing:border-box;border:0 solid #e5e7eb}
+html{line-height:1.5;-webkit-text-size-adjust:100%;font-family:ui-sans-serif,system-ui,sans-serif,"Apple Color Emoji","Segoe UI Emoji"}
+body{margin:0;line-height:inherit}
+h1,h2,h3,h4,h5,h6{font-size:inherit;font-weight:inherit;margin:0}
+p,dl,dd,ol,ul,pre,blockquote,figure{margin:0}
+ol,ul{list-style:none;padding:0}
+table{border-collapse:collapse;text-indent:0;border-color:inherit}
+th{text-align:inherit}
+a{color:inherit;text-decoration:inherit}
+img,svg{display:block;vertical-align:middle}
+`
+
+// breakpoints are the minimum widths of the responsive variants
+var breakpoints = map[string]string{
+	"sm":  "640px",
+	"md":  "768px",
+	"lg":  "1024px",
+	"xl":  "1280px",
+	"2xl": "1536px",
+}
+
+// stateVariants are the selector suffixes of state variants, e.g. hover:bg-gray-50
+var stateVariants = map[string]string{
+	"hover":  ":hover",
+	"focus":  ":focus",
+	"active": ":active",
+	"first":  ":first-child",
+	"last":   ":last-child",
+	"odd":    ":nth-child(odd)",
+	"even":   ":nth-child(even)",
+}
+
+// utilities are the declarations of classes without a value from the color or spacing tables
+var utilities = map[string]string{
+	"block":               "display:block",
+	"inline-block":        "display:inline-block",
+	"inline":              "display:inline",
+	"flex":                "display:flex",
+	"inline-flex":         "display:inline-flex",
+	"grid":                "display:grid",
+	"hidden":              "display:none",
+	"flex-1":              "flex:1 1 0%",
+	"flex-auto":           "flex:1 1 auto",
+	"flex-none":           "flex:none",
+	"flex-shrink-0":       "flex-shrink:0",
+	"shrink-0":            "flex-shrink:0",
+	"flex-grow":           "flex-grow:1",
+	"grow":                "flex-grow:1",
+	"flex-row":            "flex-direction:row",
+	"flex-col":            "flex-direction:column",
+	"flex-wrap":           "flex-wrap:wrap",
+	"items-start":         "align-items:flex-start",
+	"items-center":        "align-items:center",
+	"items-end":           "align-items:flex-end",
+	"items-baseline":      "align-items:baseline",
+	"items-stretch":       "align-items:stretch",
+	"justify-start":       "justify-content:flex-start",
+	"justify-center":      "justify-content:center",
+	"justify-end":         "justify-content:flex-end",
+	"justify-between":     "justify-content:space-between",
+	"justify-around":      "justify-content:space-around",
+	"mx-auto":             "margin-left:auto;margin-right:auto",
+	"ml-auto":             "margin-left:auto",
+	"mr-auto":             "margin-right:auto",
+	"table-auto":          "table-layout:auto",
+	"table-fixed":         "table-layout:fixed",
+	"overflow-auto":       "overflow:auto",
+	"overflow-hidden":     "overflow:hidden",
+	"overflow-x-auto":     "overflow-x:auto",
+	"overflow-y-auto":     "overflow-y:auto",
+	"truncate":            "overflow:hidden;text-overflow:ellipsis;white-space:nowrap",
+	"whitespace-nowrap":   "white-space:nowrap",
+	"whitespace-normal":   "white-space:normal",
+	"whitespace-pre":      "white-space:pre",
+	"whitespace-pre-wrap": "white-space:pre-wrap",
+	"break-all":           "word-break:break-all",
+	"text-left":           "text-align:left",
+	"text-center":         "text-align:center",
+	"text-right":          "text-align:right",
+	"text-justify":        "text-align:justify",
+	"align-top":           "vertical-align:top",
+	"align-middle":        "vertical-align:middle",
+	"align-bottom":        "vertical-align:bottom",
+	"font-sans":           `font-family:ui-sans-serif,system-ui,sans-serif`,
+	"font-serif":          `font-family:ui-serif,Georgia,Cambria,"Times New Roman",Times,serif`,
+	"font-mono":           `font-family:ui-monospace,SFMono-Regular,Menlo,Monaco,Consolas,"Liberation Mono","Courier New",monospace`,
+	"font-thin":           "font-weight:100",
+	"font-extralight":     "font-weight:200",
+	"font-light":          "font-weight:300",
+	"font-normal":         "font-weight:400",
+	"font-medium":         "font-weight:500",
+	"font-semibold":       "font-weight:600",
+	"font-bold":           "font-weight:700",
+	"font-extrabold":      "font-weight:800",
+	"font-black":          "font-weight:900",
+	"italic":              "font-style:italic",
+	"not-italic":          "font-style:normal",
+	"underline":           "text-decoration-line:underline",
+	"line-through":        "text-decoration-line:line-through",
+	"no-underline":        "text-decoration-line:none",
+	"uppercase":           "text-transform:uppercase",
+	"lowercase":           "text-transform:lowercase",
+	"capitalize":          "text-transform:capitalize",
+	"normal-case":         "text-transform:none",
+	"tracking-tighter":    "letter-spacing:-0.05em",
+	"tracking-tight":      "letter-spacing:-0.025em",
+	"tracking-normal":     "letter-spacing:0em",
+	"tracking-wide":       "letter-spacing:0.025em",
+	"tracking-wider":      "letter-spacing:0.05em",
+	"tracking-widest":     "letter-spacing:0.1em",
+	"leading-none":        "line-height:1",
+	"leading-tight":       "line-height:1.25",
+	"leading-normal":      "line-height:1.5",
+	"leading-relaxed":     "line-height:1.625",
+	"list-disc":           "list-style-type:disc",
+	"list-decimal":        "list-style-type:decimal",
+	"list-inside":         "list-style-position:inside",
+	"cursor-pointer":      "cursor:pointer",
+	"select-none":         "user-select:none",
+	"rounded-none":        "border-radius:0",
+	"rounded-sm":          "border-radius:0.125rem",
+	"rounded":             "border-radius:0.25rem",
+	"rounded-md":          "border-radius:0.375rem",
+	"rounded-lg":          "border-radius:0.5rem",
+	"rounded-xl":          "border-radius:0.75rem",
+	"rounded-2xl":         "border-radius:1rem",
+	"rounded-full":        "border-radius:9999px",
+	"shadow-sm":           "box-shadow:0 1px 2px 0 rgb(0 0 0 / 0.05)",
+	"shadow":              "box-shadow:0 1px 3px 0 rgb(0 0 0 / 0.1),0 1px 2px -1px rgb(0 0 0 / 0.1)",
+	"shadow-md":           "box-shadow:0 4px 6px -1px rgb(0 0 0 / 0.1),0 2px 4px -2px rgb(0 0 0 / 0.1)",
+	"shadow-lg":           "box-shadow:0 10px 15px -3px rgb(0 0 0 / 0.1),0 4px 6px -4px rgb(0 0 0 / 0.1)",
+	"shadow-none":         "box-shadow:none",
+	"w-full":              "width:100%",
+	"w-auto":              "width:auto",
+	"w-screen":            "width:100vw",
+	"h-full":              "height:100%",
+	"h-auto":              "height:auto",
+	"h-screen":            "height:100vh",
+	"min-w-full":          "min-width:100%",
+	"min-h-screen":        "min-height:100vh",
+	"min-h-full":          "min-height:100%",
+	"sticky":              "position:sticky",
+	"relative":            "position:relative",
+	"absolute":            "position:absolute",
+}
+
+// maxWidths are the max-w- sizes in rem
+var maxWidths = map[string]string{
+	"xs": "20rem", "sm": "24rem", "md": "28rem", "lg": "32rem", "xl": "36rem",
+	"2xl": "42rem", "3xl": "48rem", "4xl": "56rem", "5xl": "64rem", "6xl": "72rem",
+	"7xl": "80rem", "full": "100%", "none": "none", "prose": "65ch",
+}
+
+// spacingProperties map spacing prefixes to the properties they set
+var spacingProperties = map[string][]string{
+	"p":     {"padding"},
+	"px":    {"padding-left", "padding-right"},
+	"py":    {"padding-top", "padding-bottom"},
+	"pt":    {"padding-top"},
+	"pr":    {"padding-right"},
+	"pb":    {"padding-bottom"},
+	"pl":    {"padding-left"},
+	"m":     {"margin"},
+	"mx":    {"margin-left", "margin-right"},
+	"my":    {"margin-top", "margin-bottom"},
+	"mt":    {"margin-top"},
+	"mr":    {"margin-right"},
+	"mb":    {"margin-bottom"},
+	"ml":    {"margin-left"},
+	"gap":   {"gap"},
+	"gap-x": {"column-gap"},
+	"gap-y": {"row-gap"},
+	"w":     {"width"},
+	"h":     {"height"},
+	"min-w": {"min-width"},
+	"max-h": {"max-height"},
+	"top":   {"top"},
+	"right": {"right"},
+	"left":  {"left"},
+}
+
+// colorProperties map color prefixes to the properties they set
+var colorProperties = map[string]string{
+	"text":   "color",
+	"bg":     "background-color",
+	"border": "border-color",
+	"fill":   "fill",
+	"stroke": "stroke",
+}
+
+var classAttribute = regexp.MustCompile(`class="([^"]*)"`)
+
+// HTMLClasses returns the distinct classes of the class attributes in an HTML document
+func HTMLClasses(html string) []string {
+	seen := make(map[string]bool)
+	var classes []string
+	for _, match := range classAttribute.FindAllStringSubmatch(html, -1) {
+		for _, class := range strings.Fields(match[1]) {
+			if !seen[class] {
+				seen[class] = true
+				classes = append(classes, class)
+			}
+		}
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// Stylesheet returns the CSS of the Tailwind classes used in an HTML document, so it
+// renders offline without the Tailwind CDN. Unknown classes are skipped, and
+// responsive variants come last so they override the base utilities.
+func Stylesheet(html string) string {
+	var base, responsive []string
+	for _, class := range HTMLClasses(html) {
+		rule, ok := CSSRule(class)
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(rule, "@media") {
+			responsive = append(responsive, rule)
+		} else {
+			base = append(base, rule)
+		}
+	}
+	sort.SliceStable(responsive, func(i, j int) bool {
+		return mediaWidth(responsive[i]) < mediaWidth(responsive[j])
+	})
+	return preflight + strings.Join(append(base, responsive...), "\n") + "\n"
+}
+
+// mediaWidth returns the min-width of a responsive rule in px
+func mediaWidth(rule string) int {
+	var width int
+	fmt.Sscanf(rule, "@media (min-width:%dpx)", &width)
+	return width
+}
+
+// CSSRule returns the CSS rule of a utility class, including hover: style state
+// and md: style responsive variants
+func CSSRule(class string) (string, bool) {
+	parts := strings.Split(class, ":")
+	declarations, children, ok := Declarations(parts[len(parts)-1])
+	if !ok {
+		return "", false
+	}
+
+	selector := "." + escapeClass(class)
+	var media string
+	for _, variant := range parts[:len(parts)-1] {
+		if suffix, ok := stateVariants[variant]; ok {
+			selector += suffix
+		} else if width, ok := breakpoints[variant]; ok {
+			media = width
+		} else {
+			return "", false
+		}
+	}
+	if children {
+		// space-y and divide-y style every child after the first
+		selector += ">:not([hidden])~:not([hidden])"
+	}
+
+	rule := selector + "{" + declarations + "}"
+	if media != "" {
+		rule = "@media (min-width:" + media + "){" + rule + "}"
+	}
+	return rule, true
+}
+
+// Declarations returns the CSS declarations of a utility class without variants,
+// and whether they apply to the children of the element, as with space-y-4
+func Declarations(utility string) (string, bool, bool) {
+	if declarations, ok := utilities[utility]; ok {
+		return declarations, false, true
+	}
+
+	switch utility {
+	case "border":
+		return "border-width:1px", false, true
+	case "divide-y":
+		return "border-top-width:1px", true, true
+	case "divide-x":
+		return "border-left-width:1px", true, true
+	}
+
+	if size, ok := strings.CutPrefix(utility, "text-"); ok {
+		if rem, exists := TailwindFontSizes[size]; exists {
+			return "font-size:" + formatRem(rem), false, true
+		}
+	}
+	if size, ok := strings.CutPrefix(utility, "max-w-"); ok {
+		if width, exists := maxWidths[size]; exists {
+			return "max-width:" + width, false, true
+		}
+	}
+	if n, ok := strings.CutPrefix(utility, "grid-cols-"); ok {
+		if cols, err := strconv.Atoi(n); err == nil && cols > 0 {
+			return fmt.Sprintf("grid-template-columns:repeat(%d,minmax(0,1fr))", cols), false, true
+		}
+	}
+	if n, ok := strings.CutPrefix(utility, "col-span-"); ok {
+		if cols, err := strconv.Atoi(n); err == nil && cols > 0 {
+			return fmt.Sprintf("grid-column:span %d/span %d", cols, cols), false, true
+		}
+	}
+	if n, ok := strings.CutPrefix(utility, "opacity-"); ok {
+		if percent, err := strconv.Atoi(n); err == nil && percent <= 100 {
+			return "opacity:" + strconv.FormatFloat(float64(percent)/100, 'f', -1, 64), false, true
+		}
+	}
+	if declarations, ok := borderWidth(utility); ok {
+		return declarations, false, true
+	}
+	if declarations, children, ok := spacing(utility); ok {
+		return declarations, children, true
+	}
+
+	for prefix, property := range colorProperties {
+		if name, ok := strings.CutPrefix(utility, prefix+"-"); ok {
+			if color, exists := paletteColor(name); exists {
+				return property + ":" + color, false, true
+			}
+		}
+	}
+	if name, ok := strings.CutPrefix(utility, "divide-"); ok {
+		if color, exists := paletteColor(name); exists {
+			return "border-color:" + color, true, true
+		}
+	}
+	return "", false, false
+}
+
+// borderSides map the side of border-t style classes to the widths they set
+var borderSides = map[string][]string{
+	"":   {"border-width"},
+	"-t": {"border-top-width"},
+	"-r": {"border-right-width"},
+	"-b": {"border-bottom-width"},
+	"-l": {"border-left-width"},
+	"-x": {"border-left-width", "border-right-width"},
+	"-y": {"border-top-width", "border-bottom-width"},
+}
+
+// borderWidth returns the declarations of border-2 and side borders such as border-t-2
+func borderWidth(utility string) (string, bool) {
+	rest, ok := strings.CutPrefix(utility, "border")
+	if !ok || rest == "" {
+		return "", false
+	}
+	side, width := "", rest
+	for _, s := range []string{"-t", "-r", "-b", "-l", "-x", "-y"} {
+		if rest == s || strings.HasPrefix(rest, s+"-") {
+			side, width = s, strings.TrimPrefix(rest, s)
+			break
+		}
+	}
+
+	px := "1px"
+	if width != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(width, "-"))
+		if err != nil || !strings.HasPrefix(width, "-") {
+			return "", false
+		}
+		px = strconv.Itoa(n) + "px"
+	}
+	properties := borderSides[side]
+	declarations := make([]string, len(properties))
+	for i, property := range properties {
+		declarations[i] = property + ":" + px
+	}
+	return strings.Join(declarations, ";"), true
+}
+
+// spacing returns the declarations of padding, margin, gap, size and space-x/y classes
+// from the spacing scale, fractions such as w-1/2 and arbitrary values such as p-[10px]
+func spacing(utility string) (string, bool, bool) {
+	children := false
+	var properties []string
+	var value string
+	if rest, ok := strings.CutPrefix(utility, "space-y-"); ok {
+		children, properties, value = true, []string{"margin-top"}, rest
+	} else if rest, ok := strings.CutPrefix(utility, "space-x-"); ok {
+		children, properties, value = true, []string{"margin-left"}, rest
+	} else {
+		// The longest prefix wins, e.g. gap-x over gap
+		var prefix string
+		for p := range spacingProperties {
+			if strings.HasPrefix(utility, p+"-") && len(p) > len(prefix) {
+				prefix = p
+			}
+		}
+		if prefix == "" {
+			return "", false, false
+		}
+		properties, value = spacingProperties[prefix], strings.TrimPrefix(utility, prefix+"-")
+	}
+
+	var size string
+	if rem, ok := TailwindSpacing[value]; ok {
+		size = formatRem(rem)
+	} else if numerator, denominator, ok := strings.Cut(value, "/"); ok {
+		n, errN := strconv.Atoi(numerator)
+		d, errD := strconv.Atoi(denominator)
+		if errN != nil || errD != nil || d == 0 {
+			return "", false, false
+		}
+		size = strconv.FormatFloat(float64(n)*100/float64(d), 'f', -1, 64) + "%"
+	} else if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		rem, err := parseCustomSpacing(value[1 : len(value)-1])
+		if err != nil {
+			return "", false, false
+		}
+		size = formatRem(rem)
+	} else {
+		return "", false, false
+	}
+
+	declarations := make([]string, len(properties))
+	for i, property := range properties {
+		declarations[i] = property + ":" + size
+	}
+	return strings.Join(declarations, ";"), children, true
+}
+
+// paletteColor returns the hex color of a palette name such as gray-500 or white
+func paletteColor(name string) (string, bool) {
+	if color, ok := TailwindSpecialColors[name]; ok {
+		return color, true
+	}
+	base, shade, ok := strings.Cut(name, "-")
+	if !ok {
+		return "", false
+	}
+	color, exists := TailwindColors[base][shade]
+	return color, exists
+}
+
+// formatRem formats a size from the rem based scales
+func formatRem(rem float64) string {
+	if rem == 0 {
+		return "0"
+	}
+	if rem == TailwindSpacing["px"] {
+		return "1px"
+	}
+	return strconv.FormatFloat(rem, 'f', -1, 64) + "rem"
+}
+
+// escapeClass escapes the characters of a class name that are special in CSS selectors
+func escapeClass(class string) string {
+	var b strings.Builder
+	for _, r := range class {
+		if strings.ContainsRune(`:./[]%#()`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
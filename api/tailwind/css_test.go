@@ -0,0 +1,61 @@
+package tailwind
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSSRule(t *testing.T) {
+	tests := []struct {
+		class    string
+		expected string
+	}{
+		{"bg-white", ".bg-white{background-color:#ffffff}"},
+		{"text-gray-500", ".text-gray-500{color:#6b7280}"},
+		{"text-sm", ".text-sm{font-size:0.875rem}"},
+		{"text-center", ".text-center{text-align:center}"},
+		{"px-6", ".px-6{padding-left:1.5rem;padding-right:1.5rem}"},
+		{"gap-x-2", ".gap-x-2{column-gap:0.5rem}"},
+		{"w-1/2", `.w-1\/2{width:50%}`},
+		{"p-[10px]", `.p-\[10px\]{padding:0.625rem}`},
+		{"border", ".border{border-width:1px}"},
+		{"border-t-2", ".border-t-2{border-top-width:2px}"},
+		{"border-gray-200", ".border-gray-200{border-color:#e5e7eb}"},
+		{"max-w-7xl", ".max-w-7xl{max-width:80rem}"},
+		{"grid-cols-3", ".grid-cols-3{grid-template-columns:repeat(3,minmax(0,1fr))}"},
+		{"space-y-8", ".space-y-8>:not([hidden])~:not([hidden]){margin-top:2rem}"},
+		{"divide-y", ".divide-y>:not([hidden])~:not([hidden]){border-top-width:1px}"},
+		{"hover:bg-gray-50", `.hover\:bg-gray-50:hover{background-color:#f9fafb}`},
+		{"md:grid-cols-2", `@media (min-width:768px){.md\:grid-cols-2{grid-template-columns:repeat(2,minmax(0,1fr))}}`},
+	}
+	for _, test := range tests {
+		got, ok := CSSRule(test.class)
+		if !ok || got != test.expected {
+			t.Errorf("%s: expected %s, got %s (%v)", test.class, test.expected, got, ok)
+		}
+	}
+
+	for _, class := range []string{"tree-node", "text-bogus-500", "print:hidden", "border-x-y"} {
+		if rule, ok := CSSRule(class); ok {
+			t.Errorf("%s: expected no rule, got %s", class, rule)
+		}
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	css := Stylesheet(`<div class="md:grid-cols-2 grid p-6"><span class="text-sm custom text-sm">x</span></div>`)
+	if !strings.HasPrefix(css, "*,::before,::after") {
+		t.Errorf("expected the base styles first, got:\n%s", css)
+	}
+	for _, rule := range []string{".grid{display:grid}", ".p-6{padding:1.5rem}", ".text-sm{font-size:0.875rem}"} {
+		if strings.Count(css, rule) != 1 {
+			t.Errorf("expected %s once, got:\n%s", rule, css)
+		}
+	}
+	if strings.Contains(css, "custom") {
+		t.Errorf("expected unknown classes to be skipped, got:\n%s", css)
+	}
+	if strings.Index(css, "@media") < strings.Index(css, ".text-sm") {
+		t.Errorf("expected responsive rules after the base utilities, got:\n%s", css)
+	}
+}
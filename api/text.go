@@ -19,6 +19,12 @@ type Text struct {
 	Class    Class
 	Style    string
 	Children []Text
+	// Href links the text to a URL, rendered as an OSC 8 hyperlink in terminals that
+	// support them, an <a> tag in HTML and [text](url) in Markdown
+	Href string
+	// Layout renders the text as a panel, columns, rule or badge rather than inline
+	// content, see Panel, Columns, Rule and Badge
+	Layout *Layout
 }
 
 func (t Text) Add(child Text) Text {
@@ -98,6 +104,10 @@ func (t Text) IsEmpty() bool {
 	if t.Content != "" {
 		return false
 	}
+	// Rules and titled panels render without any content
+	if t.Layout != nil && (t.Layout.Kind == LayoutRule || t.Layout.Title != "") {
+		return false
+	}
 	for _, child := range t.Children {
 		if !child.IsEmpty() {
 			return false
@@ -107,10 +117,17 @@ func (t Text) IsEmpty() bool {
 }
 
 func (t Text) String() string {
-	content := t.Content
-	for _, child := range t.Children {
-		content += child.String()
+	return t.plain(0)
+}
+
+// plain renders the text without styles, laying out blocks within width cells
+func (t Text) plain(width int) string {
+	if t.Layout != nil {
+		return t.layoutText(width, false)
 	}
+	content := joinChildren(t.Content, t.Children, func(child Text) string {
+		return child.plain(width)
+	}, "\n")
 
 	// Check if we have any style to apply
 	if t.Class != (Class{}) {
@@ -126,6 +143,19 @@ func (t Text) String() string {
 }
 
 func (t Text) ANSI() string {
+	return t.ansiWidth(0)
+}
+
+// ansiWidth renders the text as ANSI, laying out blocks within width cells, or the
+// terminal width when width is 0
+func (t Text) ansiWidth(width int) string {
+	if t.Layout != nil {
+		return t.layoutText(width, true)
+	}
+	return Hyperlink(t.ansi(width), t.Href)
+}
+
+func (t Text) ansi(width int) string {
 	// Get the effective style (Class takes precedence over Style string)
 	var style TailwindStyle
 	var transformedText string
@@ -139,27 +169,31 @@ func (t Text) ANSI() string {
 		transformedText, style = ApplyTailwindStyle(t.Content, t.Style)
 	} else {
 		// No style, just return content with children
-		result := t.Content
-		for _, child := range t.Children {
-			result += child.ANSI()
-		}
-		return result
+		return joinChildren(t.Content, t.Children, func(child Text) string {
+			return child.ansiWidth(width)
+		}, "\n")
 	}
 
 	// Apply tailwind styles using ANSI escape codes
-	content := transformedText
-	for _, child := range t.Children {
-		content += child.ANSI()
-	}
+	content := joinChildren(transformedText, t.Children, func(child Text) string {
+		return child.ansiWidth(width)
+	}, "\n")
 
 	return formatANSI(content, style)
 }
 
 func (t Text) Markdown() string {
-	content := t.Content
-	for _, child := range t.Children {
-		content += child.Markdown()
+	if t.Layout != nil {
+		return t.layoutMarkdown()
 	}
+	if t.Href != "" {
+		return markdownLink(t.markdown(), t.Href)
+	}
+	return t.markdown()
+}
+
+func (t Text) markdown() string {
+	content := joinChildren(t.Content, t.Children, Text.Markdown, "\n\n")
 
 	// Get the effective style (Class takes precedence over Style string)
 	var style TailwindStyle
@@ -243,10 +277,17 @@ func (t Text) Markdown() string {
 }
 
 func (t Text) HTML() string {
-	content := t.Content
-	for _, child := range t.Children {
-		content += child.HTML()
+	if t.Layout != nil {
+		return t.layoutHTML()
+	}
+	if t.Href != "" {
+		return htmlLink(t.html(), t.Href)
 	}
+	return t.html()
+}
+
+func (t Text) html() string {
+	content := joinChildren(t.Content, t.Children, Text.HTML, "")
 
 	// Get the effective style (Class takes precedence over Style string)
 	var style TailwindStyle
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flanksource/clicky/api/tailwind"
+)
+
+// TreeGlyphs are the prefixes drawing the branches of trees
+type TreeGlyphs struct {
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Last     string `json:"last,omitempty" yaml:"last,omitempty"`
+	Indent   string `json:"indent,omitempty" yaml:"indent,omitempty"`
+	Continue string `json:"continue,omitempty" yaml:"continue,omitempty"`
+}
+
+// Apply sets the prefixes of options to the glyphs that are set
+func (g TreeGlyphs) Apply(options *TreeOptions) {
+	if g.Branch != "" {
+		options.BranchPrefix = g.Branch
+	}
+	if g.Last != "" {
+		options.LastPrefix = g.Last
+	}
+	if g.Indent != "" {
+		options.IndentPrefix = g.Indent
+	}
+	if g.Continue != "" {
+		options.ContinuePrefix = g.Continue
+	}
+}
+
+// Themes are the built-in themes by name
+var Themes = map[string]func() Theme{
+	"default": DefaultTheme,
+	"dark":    DarkTheme,
+	"light":   LightTheme,
+	"notty":   NoTTYTheme,
+	"auto":    AutoTheme,
+}
+
+// ThemeNames returns the names of the built-in themes and of the theme files in ThemeDir
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	if dir := ThemeDir(); dir != "" {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if name := strings.TrimSuffix(entry.Name(), ext); isThemeFile(ext) && Themes[name] == nil {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeDir returns the directory holding named theme files, $XDG_CONFIG_HOME/clicky/themes
+// on Linux, or an empty string when there is no user config directory
+func ThemeDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "clicky", "themes")
+}
+
+func isThemeFile(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+var (
+	themeMu       sync.RWMutex
+	selectedTheme *Theme
+)
+
+// SelectedTheme returns the theme selected with SetTheme, and whether one was
+func SelectedTheme() (Theme, bool) {
+	themeMu.RLock()
+	defer themeMu.RUnlock()
+	if selectedTheme == nil {
+		return Theme{}, false
+	}
+	return *selectedTheme, true
+}
+
+// CurrentTheme returns the selected theme, or AutoTheme when none was selected
+func CurrentTheme() Theme {
+	if theme, ok := SelectedTheme(); ok {
+		return theme
+	}
+	return AutoTheme()
+}
+
+// SetTheme selects the theme of formatters, trees and tasks
+func SetTheme(theme Theme) {
+	themeMu.Lock()
+	defer themeMu.Unlock()
+	selectedTheme = &theme
+}
+
+// LoadTheme returns a built-in theme, or loads a YAML or JSON theme file from a path or
+// by name from ThemeDir. Theme files set any of the colours, table_style, table_header,
+// table_border, tree glyphs and icons of the theme they extend, which defaults to default:
+//
+//	name: ocean
+//	extends: dark
+//	primary: "#0ea5e9"
+//	success: green-400
+//	table_style: rounded
+//	tree:
+//	  branch: "├─ "
+//	  last: "╰─ "
+//	icons:
+//	  success: "✔"
+//
+// Colours are hex values, ANSI colour numbers or Tailwind colours such as green-400.
+func LoadTheme(name string) (Theme, error) {
+	return loadTheme(name, nil)
+}
+
+func loadTheme(name string, chain []string) (Theme, error) {
+	if theme, ok := Themes[name]; ok {
+		return theme(), nil
+	}
+
+	file := name
+	if !isThemeFile(filepath.Ext(name)) && !strings.ContainsRune(name, filepath.Separator) {
+		file = ""
+		if dir := ThemeDir(); dir != "" {
+			for _, ext := range []string{".yaml", ".yml", ".json"} {
+				if path := filepath.Join(dir, name+ext); fileExists(path) {
+					file = path
+					break
+				}
+			}
+		}
+		if file == "" {
+			return Theme{}, fmt.Errorf("unknown theme %q, expected one of %s or a theme file", name, strings.Join(ThemeNames(), ", "))
+		}
+	}
+
+	for _, previous := range chain {
+		if previous == file {
+			return Theme{}, fmt.Errorf("theme %s extends itself: %s", file, strings.Join(append(chain, file), " -> "))
+		}
+	}
+	return readThemeFile(file, append(chain, file))
+}
+
+// themeFile is a theme file, which extends another theme
+type themeFile struct {
+	Extends string `yaml:"extends,omitempty"`
+	Theme   `yaml:",inline"`
+}
+
+func readThemeFile(file string, chain []string) (Theme, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Theme{}, fmt.Errorf("failed to read theme file: %w", err)
+	}
+
+	// JSON is YAML, so both are read with the YAML decoder
+	var header themeFile
+	if err := yaml.Unmarshal(data, &header); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme %s: %w", file, err)
+	}
+
+	base := DefaultTheme()
+	if header.Extends != "" {
+		extends := header.Extends
+		if isThemeFile(filepath.Ext(extends)) && !filepath.IsAbs(extends) {
+			extends = filepath.Join(filepath.Dir(file), extends)
+		}
+		if base, err = loadTheme(extends, chain); err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %w", file, err)
+		}
+	}
+
+	// Decoding into copies of the tree glyphs and icons of the base theme merges them
+	theme := themeFile{Theme: base}
+	theme.Name = ""
+	if base.Tree != nil {
+		glyphs := *base.Tree
+		theme.Tree = &glyphs
+	}
+	theme.Icons = make(map[string]string, len(base.Icons))
+	for status, icon := range base.Icons {
+		theme.Icons[status] = icon
+	}
+	if err := yaml.Unmarshal(data, &theme); err != nil {
+		return Theme{}, fmt.Errorf("failed to parse theme %s: %w", file, err)
+	}
+	if theme.Name == "" {
+		theme.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	for _, color := range []struct {
+		name  string
+		color *lipgloss.Color
+	}{
+		{"primary", &theme.Primary},
+		{"secondary", &theme.Secondary},
+		{"success", &theme.Success},
+		{"warning", &theme.Warning},
+		{"error", &theme.Error},
+		{"info", &theme.Info},
+		{"muted", &theme.Muted},
+		{"table_header", &theme.TableHeader},
+		{"table_border", &theme.TableBorder},
+	} {
+		resolved, err := themeColor(string(*color.color))
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %s: %w", file, color.name, err)
+		}
+		*color.color = resolved
+	}
+	return theme.Theme, nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// themeColor validates a colour of a theme file, resolving Tailwind colours to hex
+func themeColor(color string) (lipgloss.Color, error) {
+	switch {
+	case color == "" || hexColor.MatchString(color):
+		return lipgloss.Color(color), nil
+	case isANSIColor(color):
+		return lipgloss.Color(color), nil
+	}
+	if name, shade, ok := strings.Cut(color, "-"); ok {
+		if hex, ok := tailwind.TailwindColors[name][shade]; ok {
+			return lipgloss.Color(hex), nil
+		}
+	}
+	if hex, ok := tailwind.TailwindColors[color]["500"]; ok {
+		return lipgloss.Color(hex), nil
+	}
+	return "", fmt.Errorf("invalid colour %q, expected a hex colour, an ANSI colour number or a Tailwind colour such as green-500", color)
+}
+
+func isANSIColor(color string) bool {
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// ColorHex returns a theme colour as #rrggbb, converting ANSI colour numbers, or an
+// empty string when the colour is not set
+func ColorHex(color lipgloss.Color) string {
+	value := string(color)
+	if isANSIColor(value) {
+		n, _ := strconv.Atoi(value)
+		return ansi256Color(n)
+	}
+	if len(value) == 4 && hexColor.MatchString(value) {
+		return "#" + strings.Repeat(value[1:2], 2) + strings.Repeat(value[2:3], 2) + strings.Repeat(value[3:4], 2)
+	}
+	return value
+}
+
+// HeaderColor returns the colour of table headers
+func (t Theme) HeaderColor() lipgloss.Color {
+	if t.TableHeader != "" {
+		return t.TableHeader
+	}
+	return t.Primary
+}
+
+// BorderColor returns the colour of table borders
+func (t Theme) BorderColor() lipgloss.Color {
+	if t.TableBorder != "" {
+		return t.TableBorder
+	}
+	return t.Muted
+}
+
+// Icon returns the icon of a task status, or fallback when the theme does not set one
+func (t Theme) Icon(status, fallback string) string {
+	if icon, ok := t.Icons[status]; ok {
+		return icon
+	}
+	return fallback
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func writeTheme(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadTheme(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+
+	base := writeTheme(t, dir, "base.yaml", `
+extends: dark
+primary: "#0ea5e9"
+success: green-400
+table_style: rounded
+tree:
+  branch: "├─ "
+  last: "╰─ "
+icons:
+  success: "✔"
+  failed: "✘"
+`)
+	child := writeTheme(t, dir, "child.json", `{
+  "name": "ocean",
+  "extends": "base.yaml",
+  "muted": "244",
+  "table_border": "#abc",
+  "tree": {"last": "└─ "},
+  "icons": {"failed": "x"}
+}`)
+
+	theme, err := LoadTheme(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "base" {
+		t.Errorf("name = %q, want the file name", theme.Name)
+	}
+	if theme.Primary != "#0ea5e9" || theme.Success != "#4ade80" {
+		t.Errorf("primary, success = %q, %q", theme.Primary, theme.Success)
+	}
+	if theme.Error != DarkTheme().Error {
+		t.Errorf("error = %q, want the colour of dark", theme.Error)
+	}
+	if theme.TableStyle != TableStyleRounded || theme.HeaderColor() != theme.Primary || theme.BorderColor() != theme.Muted {
+		t.Errorf("table style, header, border = %q, %q, %q", theme.TableStyle, theme.HeaderColor(), theme.BorderColor())
+	}
+
+	theme, err = LoadTheme(child)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "ocean" || theme.Primary != "#0ea5e9" || theme.Muted != "244" || theme.BorderColor() != "#abc" {
+		t.Errorf("name, primary, muted, border = %q, %q, %q, %q", theme.Name, theme.Primary, theme.Muted, theme.BorderColor())
+	}
+	if *theme.Tree != (TreeGlyphs{Branch: "├─ ", Last: "└─ "}) {
+		t.Errorf("tree = %+v, want the glyphs of both files", *theme.Tree)
+	}
+	if theme.Icon("success", "?") != "✔" || theme.Icon("failed", "?") != "x" || theme.Icon("running", "⟳") != "⟳" {
+		t.Errorf("icons = %v", theme.Icons)
+	}
+
+	options := DefaultTreeOptions()
+	theme.Tree.Apply(options)
+	if options.BranchPrefix != "├─ " || options.LastPrefix != "└─ " || options.ContinuePrefix != "│   " {
+		t.Errorf("tree options = %q %q %q", options.BranchPrefix, options.LastPrefix, options.ContinuePrefix)
+	}
+}
+
+func TestLoadThemeByName(t *testing.T) {
+	config := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	if err := os.MkdirAll(ThemeDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTheme(t, ThemeDir(), "ocean.yml", "primary: sky-500\n")
+
+	theme, err := LoadTheme("ocean")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "ocean" || theme.Primary != "#0ea5e9" {
+		t.Errorf("name, primary = %q, %q", theme.Name, theme.Primary)
+	}
+	if theme.Secondary != DefaultTheme().Secondary {
+		t.Errorf("secondary = %q, want the colour of default", theme.Secondary)
+	}
+
+	theme, err = LoadTheme("light")
+	if err != nil || theme.Name != "light" {
+		t.Errorf("LoadTheme(light) = %q, %v", theme.Name, err)
+	}
+
+	names := strings.Join(ThemeNames(), ",")
+	if names != "auto,dark,default,light,notty,ocean" {
+		t.Errorf("ThemeNames() = %s", names)
+	}
+}
+
+func TestLoadThemeErrors(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	loop := filepath.Join(dir, "loop.yaml")
+	writeTheme(t, dir, "loop.yaml", "extends: loop.yaml\n")
+
+	tests := []struct {
+		name, theme, want string
+	}{
+		{"unknown", "solarized", `unknown theme "solarized", expected one of auto, dark, default, light, notty`},
+		{"missing file", filepath.Join(dir, "missing.yaml"), "failed to read theme file"},
+		{"invalid colour", writeTheme(t, dir, "colour.yaml", "error: reddish\n"), `error: invalid colour "reddish"`},
+		{"invalid yaml", writeTheme(t, dir, "yaml.yaml", "primary: [\n"), "failed to parse theme"},
+		{"cycle", loop, "extends itself: " + loop + " -> " + loop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTheme(tt.theme)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadTheme(%s) error = %v, want %q", tt.theme, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorHex(t *testing.T) {
+	tests := map[lipgloss.Color]string{
+		"":        "",
+		"#0ea5e9": "#0ea5e9",
+		"#abc":    "#aabbcc",
+		"196":     "#ff0000",
+		"2":       ansiPalette[2],
+	}
+	for color, want := range tests {
+		if got := ColorHex(color); got != want {
+			t.Errorf("ColorHex(%q) = %q, want %q", color, got, want)
+		}
+	}
+}
+
+func TestSelectedTheme(t *testing.T) {
+	defer func() { selectedTheme = nil }()
+
+	if _, ok := SelectedTheme(); ok {
+		t.Fatal("a theme is selected before SetTheme")
+	}
+	SetTheme(LightTheme())
+	theme, ok := SelectedTheme()
+	if !ok || theme.Name != "light" || CurrentTheme().Name != "light" {
+		t.Errorf("SelectedTheme() = %q, %v", theme.Name, ok)
+	}
+}
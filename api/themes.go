@@ -115,17 +115,31 @@ type Class struct {
 // Theme provides a consistent color palette for semantic styling
 // across different UI states (success, error, warning, etc.).
 type Theme struct {
-	Primary   lipgloss.Color
-	Secondary lipgloss.Color
-	Success   lipgloss.Color
-	Warning   lipgloss.Color
-	Error     lipgloss.Color
-	Info      lipgloss.Color
-	Muted     lipgloss.Color
+	// Name is the name of a built-in theme or theme file
+	Name      string         `json:"name,omitempty" yaml:"name,omitempty"`
+	Primary   lipgloss.Color `json:"primary,omitempty" yaml:"primary,omitempty"`
+	Secondary lipgloss.Color `json:"secondary,omitempty" yaml:"secondary,omitempty"`
+	Success   lipgloss.Color `json:"success,omitempty" yaml:"success,omitempty"`
+	Warning   lipgloss.Color `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Error     lipgloss.Color `json:"error,omitempty" yaml:"error,omitempty"`
+	Info      lipgloss.Color `json:"info,omitempty" yaml:"info,omitempty"`
+	Muted     lipgloss.Color `json:"muted,omitempty" yaml:"muted,omitempty"`
+	// TableStyle is the border style of tables, e.g. rounded or ascii
+	TableStyle string `json:"table_style,omitempty" yaml:"table_style,omitempty"`
+	// TableHeader and TableBorder colour table headers and borders, defaulting to
+	// Primary and Muted
+	TableHeader lipgloss.Color `json:"table_header,omitempty" yaml:"table_header,omitempty"`
+	TableBorder lipgloss.Color `json:"table_border,omitempty" yaml:"table_border,omitempty"`
+	// Tree overrides the glyphs drawing the branches of trees
+	Tree *TreeGlyphs `json:"tree,omitempty" yaml:"tree,omitempty"`
+	// Icons overrides the icons of task statuses: pending, running, success, failed,
+	// warning and canceled
+	Icons map[string]string `json:"icons,omitempty" yaml:"icons,omitempty"`
 }
 
 func DefaultTheme() Theme {
 	return Theme{
+		Name:      "default",
 		Primary:   lipgloss.Color("#8A2BE2"), // BlueViolet
 		Secondary: lipgloss.Color("#4169E1"), // RoyalBlue
 		Success:   lipgloss.Color("#32CD32"), // LimeGreen
@@ -138,6 +152,7 @@ func DefaultTheme() Theme {
 
 func DarkTheme() Theme {
 	return Theme{
+		Name:      "dark",
 		Primary:   lipgloss.Color("#BB86FC"), // Purple
 		Secondary: lipgloss.Color("#03DAC6"), // Teal
 		Success:   lipgloss.Color("#4CAF50"), // Green
@@ -150,6 +165,7 @@ func DarkTheme() Theme {
 
 func LightTheme() Theme {
 	return Theme{
+		Name:      "light",
 		Primary:   lipgloss.Color("#6200EA"), // Deep Purple
 		Secondary: lipgloss.Color("#00BCD4"), // Cyan
 		Success:   lipgloss.Color("#388E3C"), // Dark Green
@@ -164,6 +180,7 @@ func LightTheme() Theme {
 func NoTTYTheme() Theme {
 	noColor := lipgloss.Color("")
 	return Theme{
+		Name:      "notty",
 		Primary:   noColor,
 		Secondary: noColor,
 		Success:   noColor,
@@ -47,6 +47,20 @@ type PrettyField struct {
 	// For custom rendering
 	RenderFunc   RenderFunc `json:"-" yaml:"-"`
 	CompactItems bool       `json:"compact_items,omitempty" yaml:"compact_items,omitempty"`
+	// Optional fields may be missing from the data when validating it against the schema
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
+	// Aggregate adds a sum, avg, min, max or count of this column to subtotal and total rows
+	Aggregate string `json:"aggregate,omitempty" yaml:"aggregate,omitempty"`
+	// Rules style the value, or its whole row, with the first rule that matches
+	Rules []StyleRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Link is a Go template building the URL the value links to from the other
+	// columns of its row, e.g. https://jira/browse/{{.id}}
+	Link string `json:"link,omitempty" yaml:"link,omitempty"`
+	// Wrap wraps values too wide for their terminal table column onto more lines,
+	// rather than truncating them with an ellipsis
+	Wrap bool `json:"wrap,omitempty" yaml:"wrap,omitempty"`
+	// Truncate is the widest a terminal table column grows, in cells
+	Truncate int `json:"truncate,omitempty" yaml:"truncate,omitempty"`
 }
 
 // PrettyTable configures tabular data presentation including column definitions,
@@ -59,12 +73,24 @@ type PrettyTable struct {
 	SortDirection string                   `json:"sort_direction,omitempty" yaml:"sort_direction,omitempty"`
 	HeaderStyle   string                   `json:"header_style,omitempty" yaml:"header_style,omitempty"`
 	RowStyle      string                   `json:"row_style,omitempty" yaml:"row_style,omitempty"`
+	// Filter is an expression rows must match to be shown, e.g. "status == 'failed'"
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	// GroupBy is the column rows are grouped by, adding a header row before each group
+	GroupBy string `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	// Key is the column identifying a row when comparing two data sets
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Chart plots the rows next to the table
+	Chart *TableChart `json:"chart,omitempty" yaml:"chart,omitempty"`
+	// TableStyle is the border style of the table in the terminal, e.g. rounded or ascii
+	TableStyle string `json:"table_style,omitempty" yaml:"table_style,omitempty"`
 }
 
 // PrettyObject defines the schema for formatting structured data,
 // containing field definitions that control how each property is displayed.
 type PrettyObject struct {
 	Fields []PrettyField `json:"fields" yaml:"fields"`
+	// Template is the Go template used by the template format, relative to the schema file
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
 }
 
 // FieldValue wraps a raw value with type-safe accessors and formatting metadata.
@@ -82,6 +108,10 @@ type FieldValue struct {
 	MapValue     map[string]interface{}
 	NestedFields map[string]FieldValue
 	Text         *Text
+	// Style holds the classes of the style rules matching the value or its row
+	Style string
+	// Link is the URL built from the link template of the field
+	Link string
 }
 
 func (v FieldValue) Formatted() string {
@@ -95,14 +125,24 @@ func (v FieldValue) Formatted() string {
 }
 
 func (v FieldValue) Pretty() Text {
+	// Fallback - create basic Text object
+	text := Text{Content: fmt.Sprintf("%v", v.Value)}
 	if v.Text != nil {
-		return *v.Text
+		text = *v.Text
 	}
 
-	// Fallback - create basic Text object
-	return Text{
-		Content: fmt.Sprintf("%v", v.Value),
+	if v.Style != "" {
+		// Classes of matching style rules come last, so they override the field style
+		if text.Class == (Class{}) && len(text.Children) == 0 {
+			text.Style = strings.TrimSpace(text.Style + " " + v.Style)
+		} else {
+			text = Text{Style: v.Style, Children: []Text{text}}
+		}
+	}
+	if v.Link != "" {
+		text.Href = v.Link
 	}
+	return text
 }
 
 func (v FieldValue) Plain() string {
@@ -113,22 +153,36 @@ func (v FieldValue) Plain() string {
 }
 
 func (v FieldValue) ANSI() string {
-	if v.Text != nil {
-		return v.Text.ANSI()
+	if v.Text != nil || v.Style != "" || v.Link != "" {
+		return v.Pretty().ANSI()
 	}
 	return fmt.Sprintf("%v", v.Value)
 }
 
 func (v FieldValue) HTML() string {
-	if v.Text != nil {
-		return v.Text.HTML()
+	if v.Text != nil || v.Style != "" || v.Link != "" {
+		return v.Pretty().HTML()
 	}
 	return fmt.Sprintf("%v", v.Value)
 }
 
 func (v FieldValue) Markdown() string {
-	if v.Text != nil {
-		return v.Text.Markdown()
+	if v.Text != nil || v.Style != "" || v.Link != "" {
+		return v.Pretty().Markdown()
+	}
+	return fmt.Sprintf("%v", v.Value)
+}
+
+func (v FieldValue) AsciiDoc() string {
+	if v.Text != nil || v.Style != "" || v.Link != "" {
+		return v.Pretty().AsciiDoc()
+	}
+	return fmt.Sprintf("%v", v.Value)
+}
+
+func (v FieldValue) RST() string {
+	if v.Text != nil || v.Style != "" || v.Link != "" {
+		return v.Pretty().RST()
 	}
 	return fmt.Sprintf("%v", v.Value)
 }
@@ -239,6 +293,14 @@ func (v FieldValue) Float() *float64 {
 		return lo.ToPtr(float64(int64(val)))
 	case int:
 		return lo.ToPtr(float64(int64(val)))
+	case float32:
+		return lo.ToPtr(float64(val))
+	case uint:
+		return lo.ToPtr(float64(val))
+	case uint32:
+		return lo.ToPtr(float64(val))
+	case uint64:
+		return lo.ToPtr(float64(val))
 	case string:
 		if i, err := strconv.ParseFloat(val, 64); err == nil {
 			return lo.ToPtr(i)
@@ -257,72 +319,91 @@ func (v FieldValue) Int() *int64 {
 	return lo.ToPtr(int64(*i))
 }
 
-// formatCurrency formats a value as currency
+// formatCurrency formats a value as currency, using the currency and symbol format
+// options and the separators and symbol placement of the field's locale
 func (v FieldValue) formatCurrency() string {
-	// Get currency symbol from format options or default to $
-	symbol := "$"
-	if sym, ok := v.Field.FormatOptions["symbol"]; ok {
-		symbol = sym
-	}
-
-	if v.FloatValue != nil {
-		return fmt.Sprintf("%s%.2f", symbol, *v.FloatValue)
-	}
-	if v.IntValue != nil {
-		return fmt.Sprintf("%s%d.00", symbol, *v.IntValue)
-	}
-
-	// Try to parse from Value
-	if val, ok := v.Value.(float64); ok {
-		return fmt.Sprintf("%s%.2f", symbol, val)
-	}
-	if val, ok := v.Value.(int); ok {
-		return fmt.Sprintf("%s%d.00", symbol, val)
+	amount := v.Float()
+	if amount == nil {
+		return fmt.Sprintf("%v", v.Value)
 	}
-
-	return fmt.Sprintf("%v", v.Value)
+	return v.Field.Locale().FormatCurrency(*amount, v.Field.FormatOptions["currency"], v.Field.FormatOptions["symbol"])
 }
 
 // formatDate formats a value as a date
 func (v FieldValue) formatDate() string {
 
 	if t := v.Time(); t != nil {
-		return t.Format(v.DateTimeFormat())
+		return v.Field.Locale().FormatDate(*t, v.DateTimeFormat())
 	}
 	return ""
 }
 
-// formatFloat formats a float value
-func (v FieldValue) formatFloat() string {
-	digits := 2
+// digits returns the digits format option, or def when it is not set
+func (v FieldValue) digits(def int) int {
 	if d, ok := v.Field.FormatOptions["digits"]; ok {
 		if parsed, err := strconv.Atoi(d); err == nil {
-			digits = parsed
+			return parsed
 		}
 	}
+	return def
+}
 
-	format := fmt.Sprintf("%%.%df", digits)
-
+// formatFloat formats a float value
+func (v FieldValue) formatFloat() string {
 	if v.Float() != nil {
-		return fmt.Sprintf(format, *v.Float())
+		return v.Field.Locale().FormatNumber(*v.Float(), v.digits(2))
 	}
 	return ""
 }
 
-// formatDuration formats a duration value
-func (v FieldValue) formatDuration() string {
-	if val, ok := v.Value.(time.Duration); ok {
-		return val.String()
+// formatUnits formats a number as bytes, a percentage or with SI suffixes
+func (v FieldValue) formatUnits() string {
+	n := v.Float()
+	if n == nil {
+		return fmt.Sprintf("%v", v.Value)
 	}
 
-	// Try to parse as int64 (nanoseconds)
-	if val, ok := v.Value.(int64); ok {
-		return time.Duration(val).String()
+	locale := v.Field.Locale()
+	switch v.Field.Format {
+	case FormatBytes, FormatIBytes:
+		return locale.FormatBytes(*n, v.Field.Format == FormatIBytes, v.digits(1))
+	case FormatPercent:
+		return locale.FormatPercent(*n, v.Field.FormatOptions["ratio"] == "true", v.digits(1))
 	}
+	return locale.FormatSI(*n, v.digits(1))
+}
 
+// formatRelative formats a time relative to Now
+func (v FieldValue) formatRelative() string {
+	if t := v.Time(); t != nil {
+		return RelativeTime(*t, Now())
+	}
 	return fmt.Sprintf("%v", v.Value)
 }
 
+// formatDuration formats a duration, reading numbers in the unit format option (nanoseconds by default)
+func (v FieldValue) formatDuration() string {
+	switch val := v.Value.(type) {
+	case time.Duration:
+		return val.String()
+	case string:
+		if d, err := time.ParseDuration(val); err == nil {
+			return HumanDuration(d, 2)
+		}
+		return val
+	}
+
+	n := v.Float()
+	if n == nil {
+		return fmt.Sprintf("%v", v.Value)
+	}
+	unit, err := DurationUnit(v.Field.FormatOptions["unit"])
+	if err != nil {
+		unit = time.Nanosecond
+	}
+	return HumanDuration(time.Duration(*n*float64(unit)), 2)
+}
+
 // formatArray formats an array value
 func (v FieldValue) formatArray() string {
 	if v.ArrayValue != nil {
@@ -460,6 +541,25 @@ func (v FieldValue) Primitive() interface{} {
 	return v.Value
 }
 
+// LabelText returns the label of the field parsed as inline markup, defaulting to its
+// prettified name
+func (f PrettyField) LabelText() Text {
+	if f.Label == "" {
+		return Text{Content: PrettifyFieldName(f.Name)}
+	}
+	return Markup(f.Label)
+}
+
+// DefaultValue returns the value of a field with a Default, parsed as inline markup,
+// shown in place of missing and null values
+func (f PrettyField) DefaultValue() (FieldValue, bool) {
+	if f.Default == "" {
+		return FieldValue{}, false
+	}
+	text := Markup(f.Default)
+	return FieldValue{Field: f, Text: &text}, true
+}
+
 // Parse converts a raw value into a FieldValue with type inference and validation.
 // It performs type conversion based on the field's configured type, handles nested
 // structures, and creates appropriate Text objects for rich formatting.
@@ -470,6 +570,9 @@ func (f PrettyField) Parse(value interface{}) (FieldValue, error) {
 	}
 
 	if value == nil {
+		if defaultValue, ok := f.DefaultValue(); ok {
+			return defaultValue, nil
+		}
 		return v, nil
 	}
 
@@ -618,6 +721,12 @@ func (v FieldValue) createText() *Text {
 
 	// Format based on field format
 	switch v.Field.Format {
+	case FormatMarkup:
+		text := Markup(fmt.Sprintf("%v", v.Value))
+		if v.Field.Style != "" {
+			text = Text{Style: v.Field.Style, Children: []Text{text}}
+		}
+		return &text
 	case "currency":
 		content = v.formatCurrency()
 		style = "text-green-600 font-medium" // Green for currency
@@ -627,9 +736,15 @@ func (v FieldValue) createText() *Text {
 	case FieldTypeFloat:
 		content = v.formatFloat()
 		style = "text-purple-600" // Purple for numbers
-	case FieldTypeDuration:
+	case FormatDuration:
 		content = v.formatDuration()
 		style = "text-orange-600" // Orange for durations
+	case FormatBytes, FormatIBytes, FormatPercent, FormatSI:
+		content = v.formatUnits()
+		style = "text-purple-600"
+	case FormatRelative:
+		content = v.formatRelative()
+		style = "text-blue-600"
 	case FieldTypeArray:
 		content = v.formatArray()
 	default:
@@ -994,6 +1109,25 @@ func ParsePrettyTagWithName(fieldName, tag string) PrettyField {
 				field.TableOptions.RowStyle = value
 			case "title":
 				field.TableOptions.Title = value
+			case "group_by":
+				field.TableOptions.GroupBy = value
+			case "key":
+				field.TableOptions.Key = value
+			case "table_style":
+				field.TableOptions.TableStyle = value
+			case "aggregate":
+				field.Aggregate = value
+			case "link":
+				field.Link = value
+			case "truncate":
+				if width, err := strconv.Atoi(value); err == nil {
+					field.Truncate = width
+				}
+			case "when", "row_when":
+				if rule, ok := ParseStyleRule(value); ok {
+					rule.Row = key == "row_when"
+					field.Rules = append(field.Rules, rule)
+				}
 			case "indent":
 				if field.TreeOptions == nil {
 					field.TreeOptions = DefaultTreeOptions()
@@ -1030,12 +1164,17 @@ func ParsePrettyTagWithName(fieldName, tag string) PrettyField {
 				}
 			case "struct":
 				field.Format = "struct"
+			case FormatCurrency, FormatDate, FormatFloat, FormatDuration, FormatBytes, FormatIBytes,
+				FormatPercent, FormatSI, FormatRelative, FormatSparkline, FormatBar, FormatGauge, FormatMarkup:
+				field.Format = part
 			case FormatHide:
 				field.Format = FormatHide
 			case SortAsc, SortDesc:
 				field.FormatOptions["dir"] = part
 			case "compact":
 				field.CompactItems = true
+			case "wrap":
+				field.Wrap = true
 			case "no_icons":
 				if field.TreeOptions == nil {
 					field.TreeOptions = DefaultTreeOptions()
@@ -1079,6 +1218,36 @@ type PrettyTree struct {
 // PrettyDataRow maps column names to their formatted values within a table.
 type PrettyDataRow map[string]FieldValue
 
+// TableColumns returns the columns of a table field, falling back to the
+// sorted keys of the first data row when the field does not declare them
+func TableColumns(field PrettyField, rows []PrettyDataRow) []PrettyField {
+	if len(field.TableOptions.Fields) > 0 {
+		return field.TableOptions.Fields
+	}
+	if len(field.Fields) > 0 {
+		return field.Fields
+	}
+
+	for _, row := range rows {
+		if row.Kind() != "" {
+			continue
+		}
+		var names []string
+		for name := range row {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		columns := make([]PrettyField, len(names))
+		for i, name := range names {
+			columns[i] = row[name].Field
+			columns[i].Name = name
+		}
+		return columns
+	}
+	return nil
+}
+
 func (d *PrettyData) GetTableNames() []string {
 	if len(d.Tables) == 0 {
 		return nil
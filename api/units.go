@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Now returns the time that relative formats are measured against
+var Now = time.Now
+
+var (
+	decimalByteUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+	binaryByteUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siUnits          = []string{"", "k", "M", "G", "T", "P", "E"}
+)
+
+// durationUnits are the units of human durations, largest first
+var durationUnits = []struct {
+	suffix string
+	size   time.Duration
+}{
+	{"y", 365 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+}
+
+// FormatBytes formats a size in bytes with decimal (KB) or binary (KiB) units
+func (l Locale) FormatBytes(n float64, binary bool, digits int) string {
+	if binary {
+		return l.scaled(n, 1024, binaryByteUnits, digits, " ")
+	}
+	return l.scaled(n, 1000, decimalByteUnits, digits, " ")
+}
+
+// FormatSI formats a number with SI suffixes such as 1.2k and 3.4M
+func (l Locale) FormatSI(n float64, digits int) string {
+	return l.scaled(n, 1000, siUnits, digits, "")
+}
+
+// FormatPercent formats a percentage, multiplying ratios such as 0.42 by 100 first
+func (l Locale) FormatPercent(n float64, ratio bool, digits int) string {
+	if ratio {
+		n *= 100
+	}
+	return l.FormatNumber(n, digits) + "%"
+}
+
+// scaled divides n by base until it fits the largest unit below base, trimming trailing zeros
+func (l Locale) scaled(n float64, base float64, units []string, digits int, sep string) string {
+	abs := math.Abs(n)
+	unit := 0
+	pow := math.Pow(10, float64(max(digits, 0)))
+	for unit < len(units)-1 && math.Round(abs*pow)/pow >= base {
+		abs /= base
+		unit++
+	}
+	if n < 0 {
+		abs = -abs
+	}
+
+	number := l.FormatNumber(abs, digits)
+	if strings.Contains(number, l.Decimal) {
+		number = strings.TrimSuffix(strings.TrimRight(number, "0"), l.Decimal)
+	}
+	if units[unit] == "" {
+		return number
+	}
+	return number + sep + units[unit]
+}
+
+// HumanDuration formats a duration with at most parts units, e.g. 2d7h or 1m30s
+func HumanDuration(d time.Duration, parts int) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if parts < 1 {
+		parts = 1
+	}
+
+	start := len(durationUnits) - 1
+	for i, unit := range durationUnits {
+		if d >= unit.size {
+			start = i
+			break
+		}
+	}
+	end := min(start+parts, len(durationUnits))
+	d = d.Round(durationUnits[end-1].size)
+	// Rounding can carry into a larger unit, e.g. 59m59.9s to 1h
+	for start > 0 && d >= durationUnits[start-1].size {
+		start--
+		end--
+	}
+
+	var b strings.Builder
+	for _, unit := range durationUnits[start:end] {
+		if n := d / unit.size; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.size
+		}
+	}
+	if b.Len() == 0 {
+		return "0s"
+	}
+	return sign + b.String()
+}
+
+// RelativeTime formats a time relative to now, e.g. 3h ago or in 2d
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d > -time.Second && d < time.Second:
+		return "now"
+	case d > 0:
+		return HumanDuration(d, 1) + " ago"
+	}
+	return "in " + HumanDuration(-d, 1)
+}
+
+// DurationUnit returns the duration of one unit of a numeric duration: ns (the default), us, ms, s, m or h
+func DurationUnit(unit string) (time.Duration, error) {
+	switch strings.ToLower(unit) {
+	case "", "ns", "nanos", "nanoseconds":
+		return time.Nanosecond, nil
+	case "us", "µs", "micros", "microseconds":
+		return time.Microsecond, nil
+	case "ms", "millis", "milliseconds":
+		return time.Millisecond, nil
+	case "s", "sec", "secs", "seconds":
+		return time.Second, nil
+	case "m", "min", "mins", "minutes":
+		return time.Minute, nil
+	case "h", "hours":
+		return time.Hour, nil
+	}
+	return 0, fmt.Errorf("unknown duration unit %q", unit)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnitFormats(t *testing.T) {
+	t.Run("Numbers", func(t *testing.T) {
+		locale := Locales["en-US"]
+		tests := []struct {
+			name     string
+			got      string
+			expected string
+		}{
+			{"bytes", locale.FormatBytes(1536, false, 1), "1.5 KB"},
+			{"ibytes", locale.FormatBytes(1536, true, 1), "1.5 KiB"},
+			{"small bytes", locale.FormatBytes(512, false, 1), "512 B"},
+			{"rounded bytes", locale.FormatBytes(999_999, false, 1), "1 MB"},
+			{"si", locale.FormatSI(1234, 1), "1.2k"},
+			{"si millions", locale.FormatSI(-3_400_000, 1), "-3.4M"},
+			{"si small", locale.FormatSI(999, 1), "999"},
+			{"percent", locale.FormatPercent(42.5, false, 1), "42.5%"},
+			{"ratio", locale.FormatPercent(0.426, true, 0), "43%"},
+			{"german bytes", Locales["de-DE"].FormatBytes(1536, true, 2), "1,5 KiB"},
+		}
+		for _, test := range tests {
+			if test.got != test.expected {
+				t.Errorf("%s: expected %q, got %q", test.name, test.expected, test.got)
+			}
+		}
+	})
+
+	t.Run("Durations", func(t *testing.T) {
+		now := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+		tests := []struct {
+			got      string
+			expected string
+		}{
+			{HumanDuration(90*time.Minute, 2), "1h30m"},
+			{HumanDuration(200000*time.Second, 2), "2d8h"},
+			{HumanDuration(59*time.Minute+59900*time.Millisecond, 1), "1h"},
+			{HumanDuration(250*time.Millisecond, 2), "250ms"},
+			{HumanDuration(0, 2), "0s"},
+			{RelativeTime(now.Add(-3*time.Hour), now), "3h ago"},
+			{RelativeTime(now.Add(49*time.Hour), now), "in 2d"},
+			{RelativeTime(now, now), "now"},
+		}
+		for _, test := range tests {
+			if test.got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, test.got)
+			}
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		now := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+		Now = func() time.Time { return now }
+		defer func() { Now = time.Now }()
+
+		tests := []struct {
+			tag      string
+			value    interface{}
+			expected string
+		}{
+			{"bytes", int64(1536), "1.5 KB"},
+			{"ibytes,digits=2", uint64(1 << 30), "1 GiB"},
+			{"percent,ratio=true", 0.125, "12.5%"},
+			{"si", 2500000, "2.5M"},
+			{"duration", int64(90 * time.Second), "1m30s"},
+			{"duration,unit=s", 90, "1m30s"},
+			{"duration,unit=ms", 1500.0, "1s500ms"},
+			{"duration", 5 * time.Minute, "5m0s"},
+			{"relative", now.Add(-26 * time.Hour), "1d ago"},
+			{"relative", now.Add(3 * time.Minute).Unix(), "in 3m"},
+		}
+		for _, test := range tests {
+			field := ParsePrettyTagWithName("value", test.tag)
+			value, err := field.Parse(test.value)
+			if err != nil {
+				t.Fatalf("%s: failed to parse: %v", test.tag, err)
+			}
+			if got := value.Formatted(); got != test.expected {
+				t.Errorf("%s %v: expected %q, got %q", test.tag, test.value, test.expected, got)
+			}
+			if value.Value != test.value {
+				t.Errorf("%s: expected the raw value to be kept for sorting, got %v", test.tag, value.Value)
+			}
+		}
+	})
+
+	t.Run("Inference", func(t *testing.T) {
+		schema := &PrettyObject{Fields: []PrettyField{
+			{Name: "size_bytes"}, {Name: "cpu_pct"}, {Name: "latency_ms"}, {Name: "uptime"}, {Name: "name"},
+		}}
+		data := map[string]interface{}{"size_bytes": 2048, "cpu_pct": 12.5, "latency_ms": 250, "uptime": 3600, "name": "web"}
+		enhanced, err := NewStructParser().ParseWithSchema(data, schema)
+		if err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+
+		expected := map[string]string{"size_bytes": FormatBytes, "cpu_pct": FormatPercent, "latency_ms": FormatDuration, "uptime": FormatDuration, "name": ""}
+		for _, field := range enhanced.Fields {
+			if field.Format != expected[field.Name] {
+				t.Errorf("%s: expected format %q, got %q", field.Name, expected[field.Name], field.Format)
+			}
+		}
+		if unit := enhanced.Fields[2].FormatOptions["unit"]; unit != "ms" {
+			t.Errorf("expected the ms unit from the field name, got %q", unit)
+		}
+	})
+}
@@ -8,11 +8,13 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
 	"github.com/flanksource/clicky/api"
 	"github.com/flanksource/clicky/formatters"
+	"github.com/flanksource/clicky/task"
 )
 
 // Build information (set by goreleaser)
@@ -30,6 +32,21 @@ func main() {
 	}
 }
 
+// resolveOptions resolves the format options, draws task statuses in their theme, and makes
+// their locale the default of the process for values formatted outside of the format manager
+func resolveOptions(options *formatters.FormatOptions) error {
+	if err := options.ResolveFormat(); err != nil {
+		return err
+	}
+	if theme, ok := options.SelectedTheme(); ok {
+		task.SetTheme(theme)
+	}
+	if options.Locale != "" {
+		return api.SetLocale(options.Locale)
+	}
+	return nil
+}
+
 func newRootCommand() *cobra.Command {
 	var schemaFile string
 	var options formatters.FormatOptions
@@ -39,7 +56,7 @@ func newRootCommand() *cobra.Command {
 		Short: "A CLI tool for formatting structured data using YAML schema definitions",
 		Long: `Clicky is a flexible CLI tool that formats structured data (JSON, YAML, etc.)
 using YAML schema definitions. It supports multiple output formats including
-pretty-printed tables, HTML, PDF, Markdown, and more.
+pretty-printed tables, HTML, PDF, Markdown, AsciiDoc, reStructuredText, and more.
 
 For backward compatibility, you can use the root command directly, or use the
 'pretty' subcommand explicitly.`,
@@ -64,11 +81,6 @@ For backward compatibility, you can use the root command directly, or use the
 				return fmt.Errorf("--schema flag is required when using data files")
 			}
 
-			// Resolve format from format-specific flags
-			if err := options.ResolveFormat(); err != nil {
-				return err
-			}
-
 			// Load schema directly into options
 			parser := api.NewStructParser()
 			schema, err := parser.LoadSchemaFromYAML(schemaFile)
@@ -77,6 +89,16 @@ For backward compatibility, you can use the root command directly, or use the
 			}
 			options.Schema = schema
 
+			// A template: key in the schema selects the template format unless --template is given
+			if options.Template == "" {
+				options.Template = schema.Template
+			}
+
+			// Resolve format from format-specific flags
+			if err := resolveOptions(&options); err != nil {
+				return err
+			}
+
 			// Set verbose to true for CLI usage
 			options.Verbose = true
 
@@ -98,8 +120,11 @@ For backward compatibility, you can use the root command directly, or use the
 
 	// Add subcommands
 	rootCmd.AddCommand(newPrettyCommand())
+	rootCmd.AddCommand(newDiffCommand())
+	rootCmd.AddCommand(newValidateCommand())
 	rootCmd.AddCommand(newVersionCommand())
 	rootCmd.AddCommand(newSchemaCommand())
+	rootCmd.AddCommand(newThemeCommand())
 	// TODO: Re-enable MCP command after fixing compatibility issues
 	// rootCmd.AddCommand(mcp.NewCommand())
 
@@ -116,7 +141,12 @@ func newPrettyCommand() *cobra.Command {
 		Long: `Format structured data files (JSON, YAML, etc.) using a YAML schema definition.
 
 The pretty command is the main functionality of clicky, allowing you to transform
-raw data into beautifully formatted output using customizable schemas.`,
+raw data into beautifully formatted output using customizable schemas.
+
+HTML output embeds the CSS of the Tailwind classes it uses, so reports open offline.
+Use --tailwind-cdn to load the Tailwind CDN instead. With --interactive, tables sort by
+their raw values when a header is clicked, have a search box and a column picker, and
+tree nodes collapse.`,
 		Example: `  clicky pretty --schema order-schema.yaml order1.json order2.yaml
   clicky pretty --schema user-schema.yaml --format html --output reports/ users.json
   clicky pretty --schema product-schema.yaml --format csv products.json`,
@@ -126,11 +156,6 @@ raw data into beautifully formatted output using customizable schemas.`,
 				return fmt.Errorf("--schema flag is required")
 			}
 
-			// Resolve format from format-specific flags
-			if err := options.ResolveFormat(); err != nil {
-				return err
-			}
-
 			// Load schema directly into options
 			parser := api.NewStructParser()
 			schema, err := parser.LoadSchemaFromYAML(schemaFile)
@@ -139,6 +164,16 @@ raw data into beautifully formatted output using customizable schemas.`,
 			}
 			options.Schema = schema
 
+			// A template: key in the schema selects the template format unless --template is given
+			if options.Template == "" {
+				options.Template = schema.Template
+			}
+
+			// Resolve format from format-specific flags
+			if err := resolveOptions(&options); err != nil {
+				return err
+			}
+
 			// Set verbose to true for CLI usage
 			options.Verbose = true
 
@@ -164,6 +199,73 @@ raw data into beautifully formatted output using customizable schemas.`,
 	return cmd
 }
 
+func newDiffCommand() *cobra.Command {
+	var schemaFile string
+	var key string
+	var options formatters.FormatOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] <old-file> <new-file>",
+		Short: "Compare two data files field by field",
+		Long: `Compare two data files (JSON, YAML) and report the added, removed and changed values.
+
+Table rows are matched by the key: option of the table in the schema, by --key,
+or by an id, key or name column, falling back to the row position.
+JSON and YAML output is an RFC 6902 JSON patch from the old to the new file.`,
+		Example: `  clicky diff --schema cost-schema.yaml costs-monday.json costs-tuesday.json
+  clicky diff --key sku --format html --output diff.html old.yaml new.yaml
+  clicky diff --format json old.json new.json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if schemaFile != "" {
+				schema, err := api.NewStructParser().LoadSchemaFromYAML(schemaFile)
+				if err != nil {
+					return fmt.Errorf("failed to load schema: %w", err)
+				}
+				options.Schema = schema
+			}
+
+			// Resolve format from format-specific flags
+			if err := resolveOptions(&options); err != nil {
+				return err
+			}
+
+			oldData, err := loadDataFile(args[0])
+			if err != nil {
+				return err
+			}
+			newData, err := loadDataFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			output, err := formatters.NewFormatManager().Diff(oldData, newData, key, options)
+			if err != nil {
+				return err
+			}
+
+			if options.Output == "" {
+				fmt.Println(output)
+				return nil
+			}
+			if err := os.MkdirAll(filepath.Dir(options.Output), 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			if err := os.WriteFile(options.Output, []byte(output), 0644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Printf("Output written to %s\n", options.Output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&schemaFile, "schema", "", "YAML file containing PrettyObject schema")
+	cmd.Flags().StringVar(&key, "key", "", "Column used to match table rows, unless the table sets key:")
+	formatters.BindPFlags(cmd.Flags(), &options)
+
+	return cmd
+}
+
 func newVersionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -191,6 +293,7 @@ The schema command provides tools for understanding and working with clicky's YA
 	// Add subcommands
 	cmd.AddCommand(newSchemaHelpCommand())
 	cmd.AddCommand(newSchemaValidateCommand())
+	cmd.AddCommand(newSchemaJSONSchemaCommand())
 	cmd.AddCommand(newSchemaExampleCommand())
 
 	return cmd
@@ -228,6 +331,247 @@ func newSchemaValidateCommand() *cobra.Command {
 	}
 }
 
+func newSchemaJSONSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "jsonschema <schema-file>",
+		Short: "Generate a JSON Schema for the data described by a schema file",
+		Long: `Print a JSON Schema describing the shape of the data a schema file formats.
+
+Field types and formats map to JSON Schema types, struct and map fields with fields
+become nested objects, and tables become arrays of row objects. Fields are required
+unless they set optional: true or a default.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := api.NewStructParser().LoadSchemaFromYAML(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to load schema: %w", err)
+			}
+
+			output, err := json.MarshalIndent(schema.JSONSchema(), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+func newThemeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "List and preview themes",
+		Long: `Themes set the colours of pretty, tree, HTML and task output, the border style and
+colours of tables, the glyphs of trees and the icons of task statuses.
+
+Select a theme with --theme or CLICKY_THEME, by name or as the path to a YAML or
+JSON theme file. Named theme files are read from ` + "`" + `$XDG_CONFIG_HOME/clicky/themes` + "`" + `.`,
+	}
+
+	cmd.AddCommand(newThemeListCommand())
+	cmd.AddCommand(newThemePreviewCommand())
+
+	return cmd
+}
+
+func newThemeListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the built-in and installed themes",
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, name := range api.ThemeNames() {
+				fmt.Println(name)
+			}
+		},
+	}
+}
+
+func newThemePreviewCommand() *cobra.Command {
+	var options formatters.FormatOptions
+
+	cmd := &cobra.Command{
+		Use:   "preview [name|path]",
+		Short: "Render a sample in a theme",
+		Long: `Render the colours of a theme, task statuses, a table and a tree in the theme given,
+or in the theme selected with --theme or CLICKY_THEME.`,
+		Example: `  clicky theme preview dark
+  clicky theme preview ./ocean.yaml --table-style rounded
+  CLICKY_THEME=light clicky theme preview --html --output preview.html`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				options.Theme = args[0]
+			}
+			if err := resolveOptions(&options); err != nil {
+				return err
+			}
+			theme, ok := options.SelectedTheme()
+			if !ok {
+				theme = api.CurrentTheme()
+			}
+
+			output, err := formatters.NewFormatManager().FormatWithOptions(options, themeSample())
+			if err != nil {
+				return err
+			}
+			if options.Format == "pretty" {
+				palette := themePalette(theme)
+				if options.NoColor {
+					output = palette.String() + "\n" + output
+				} else {
+					output = palette.ANSI() + "\n" + output
+				}
+			}
+
+			if options.Output == "" {
+				fmt.Println(output)
+				return nil
+			}
+			if err := os.MkdirAll(filepath.Dir(options.Output), 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			if err := os.WriteFile(options.Output, []byte(output), 0644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Printf("Output written to %s\n", options.Output)
+			return nil
+		},
+	}
+
+	formatters.BindPFlags(cmd.Flags(), &options)
+
+	return cmd
+}
+
+// themePalette returns a swatch of each colour of a theme, followed by the task statuses
+func themePalette(theme api.Theme) api.Text {
+	text := api.Text{Content: "Theme: " + theme.Name + "\n\n", Style: "font-bold"}
+	for _, color := range []struct {
+		name  string
+		color lipgloss.Color
+	}{
+		{"primary", theme.Primary},
+		{"secondary", theme.Secondary},
+		{"success", theme.Success},
+		{"warning", theme.Warning},
+		{"error", theme.Error},
+		{"info", theme.Info},
+		{"muted", theme.Muted},
+		{"table header", theme.HeaderColor()},
+		{"table border", theme.BorderColor()},
+	} {
+		swatch := api.Text{Content: "  ████  "}
+		if hex := api.ColorHex(color.color); hex != "" {
+			swatch.Class.Foreground = &api.Color{Hex: hex}
+		}
+		value := string(color.color)
+		if value == "" {
+			value = "(none)"
+		}
+		text = text.Add(swatch).Append(fmt.Sprintf("%-13s %s\n", color.name, value))
+	}
+
+	text = text.Append("\n")
+	for _, status := range []task.Status{task.StatusPending, task.StatusRunning, task.StatusSuccess, task.StatusWarning, task.StatusFailed, task.StatusCancelled} {
+		text = text.Add(status.ApplyTheme(&theme, api.Text{Content: string(status)})).Append("\n")
+	}
+	return text
+}
+
+// themeSampleService is a row of the table previewed in a theme
+type themeSampleService struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status" pretty:"color,green=healthy,yellow=degraded,red=down"`
+	Requests int     `json:"requests"`
+	Cost     float64 `json:"cost" pretty:"currency"`
+}
+
+// themeSampleData is the table and tree previewed in a theme
+type themeSampleData struct {
+	Services []themeSampleService `json:"services" pretty:"table"`
+	Files    *api.SimpleTreeNode  `json:"files" pretty:"tree"`
+}
+
+func themeSample() themeSampleData {
+	return themeSampleData{
+		Services: []themeSampleService{
+			{Name: "api", Status: "healthy", Requests: 12840, Cost: 412.5},
+			{Name: "worker", Status: "degraded", Requests: 3120, Cost: 98.25},
+			{Name: "billing", Status: "down", Requests: 0, Cost: 12},
+		},
+		Files: &api.SimpleTreeNode{Label: "src", Children: []api.TreeNode{
+			&api.SimpleTreeNode{Label: "cmd", Children: []api.TreeNode{&api.SimpleTreeNode{Label: "main.go"}}},
+			&api.SimpleTreeNode{Label: "api", Children: []api.TreeNode{
+				&api.SimpleTreeNode{Label: "themes.go"},
+				&api.SimpleTreeNode{Label: "theme_loader.go"},
+			}},
+			&api.SimpleTreeNode{Label: "README.md"},
+		}},
+	}
+}
+
+func newValidateCommand() *cobra.Command {
+	var schemaFile string
+	var options formatters.FormatOptions
+
+	cmd := &cobra.Command{
+		Use:   "validate [flags] <data-file1> [data-file2...]",
+		Short: "Validate data files against a schema",
+		Long: `Check data files against the JSON Schema generated from a YAML schema, and report
+values with the wrong type or format, missing fields and unknown fields as a table.`,
+		Example: `  clicky validate --schema order-schema.yaml order1.json order2.yaml
+  clicky validate --schema order-schema.yaml --format json order.json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := api.NewStructParser().LoadSchemaFromYAML(schemaFile)
+			if err != nil {
+				return fmt.Errorf("failed to load schema: %w", err)
+			}
+			jsonSchema := schema.JSONSchema()
+
+			// Resolve format from format-specific flags
+			if err := resolveOptions(&options); err != nil {
+				return err
+			}
+
+			manager := formatters.NewFormatManager()
+			failed := 0
+			for _, dataFile := range args {
+				data, err := loadDataFile(dataFile)
+				if err != nil {
+					return fmt.Errorf("error processing %s: %w", dataFile, err)
+				}
+
+				// Status lines go to stderr, so stdout only holds the issues in the chosen format
+				issues := jsonSchema.Validate(data)
+				if len(issues) == 0 {
+					fmt.Fprintf(os.Stderr, "✓ %s matches the schema\n", dataFile)
+					continue
+				}
+				failed++
+
+				output, err := manager.FormatWithOptions(options, issues)
+				if err != nil {
+					return fmt.Errorf("failed to format issues: %w", err)
+				}
+				fmt.Fprintf(os.Stderr, "✗ %s has %d issues\n", dataFile, len(issues))
+				fmt.Println(output)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d files do not match the schema", failed, len(args))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&schemaFile, "schema", "", "YAML file containing PrettyObject schema (required)")
+	cmd.MarkFlagRequired("schema")
+	formatters.BindPFlags(cmd.Flags(), &options)
+
+	return cmd
+}
+
 func newSchemaExampleCommand() *cobra.Command {
 	var outputFile string
 
@@ -273,6 +617,7 @@ fields:
     format: "format_type"      # Optional: special formatting (currency, date, table, etc.)
     style: "tailwind_classes"  # Optional: Tailwind CSS classes for styling
     label: "Display Label"     # Optional: custom label for display
+    optional: true             # Optional: field may be missing when running 'clicky validate'
 
 ## Field Types
 
@@ -289,8 +634,24 @@ fields:
 - currency: Format as currency (e.g., $1,234.56)
 - date: Format as date/time
 - float: Format with specific decimal places
+- bytes / ibytes: Format sizes with decimal or binary units (e.g., 1.5 KB, 1.5 KiB)
+- percent: Format as a percentage (e.g., 42.5%)
+- si: Format with SI suffixes (e.g., 1.2k, 3.4M)
+- relative: Format a time relative to now (e.g., 3h ago, in 2d)
+- duration: Format nanoseconds, or numbers in the unit option, as a duration (e.g., 1h30m)
+- sparkline: Draw a series of numbers as a sparkline (▁▃▂█)
+- bar: Draw a number as a horizontal bar, or a series as vertical bars
+- gauge: Draw a number as a gauge coloured by the warn/crit thresholds
 - table: Display array as a table
 - tree: Display as a tree structure
+- markup: Parse the value as inline markup (see Markup)
+
+Charts are drawn with block characters in the terminal (chars: braille for
+braille), inline SVG in HTML and vector lines in PDF. Their format_options are
+min, max, width, color (a Tailwind class) and, for gauges, warn and crit.
+
+Sorting and filtering use the raw values. Without a format, numeric fields named
+like size_bytes, cpu_pct, latency_ms or *_ago use these formats.
 
 ## Styling
 
@@ -309,6 +670,53 @@ color_options:
   red: "failed"         # Use red when value is "failed"
   yellow: ">= 50"       # Use yellow for numeric comparisons
 
+## Style Rules
+
+Ordered rules using the --filter syntax, where value is the field and other names
+are columns of the same row. The first matching rule applies in every format:
+
+rules:
+  - "value > 90 -> text-red-600 font-bold"       # Short form, → also works
+  - "value between 50 and 90 -> text-yellow-600"
+  - "name =~ '^web-' -> italic"
+  - when: "status == 'failed'"
+    style: "bg-red-100"
+    row: true                                    # Style the whole table row
+
+In struct tags: pretty:"when=value > 90 -> text-red-600,row_when=value == 'failed' -> bg-red-100"
+
+## Markup
+
+Inline markup styles parts of a label, default or markup value with Tailwind
+classes or the aliases bold, italic, underline, strike, faint, muted, success,
+error, warning and info:
+
+fields:
+  - name: "status"
+    label: "[bold]Status[/] [muted](last run)[/]"
+    default: "[warning]pending[/]"
+  - name: "summary"
+    format: "markup"   # e.g. "[bold text-red-500]failed[/] in [muted]3s[/]"
+
+Tags nest and close with [/] or [/tag], [link=https://example.com]text[/] links
+the text, and \[ is a literal bracket. Invalid markup in a schema is an error
+giving its line and column.
+
+## Links
+
+A Go template building the URL a value links to from the columns of its row, or
+the top-level fields, where value is the field itself:
+
+fields:
+  - name: "id"
+    link: "https://jira.example.com/browse/{{.id}}"
+  - name: "name"
+    link: "https://search.example.com/?q={{.value | urlquery}}"
+
+Terminals that support OSC 8 hyperlinks show the value as a clickable link (set
+FORCE_HYPERLINK=1 or 0 to override detection), HTML uses <a> tags, Markdown
+[text](url), and PDF clickable text. In struct tags: pretty:"link=https://jira.example.com/browse/{{.id}}"
+
 ## Table Options
 
 For array fields with format: "table":
@@ -316,10 +724,33 @@ For array fields with format: "table":
 table_options:
   title: "Table Title"
   header_style: "bg-blue-50 font-bold"
+  filter: "status == 'failed' || amount > 1000"   # Only show matching rows
+  group_by: "category"  # Add a header row before each group of rows
+  key: "sku"            # Column matching rows in 'clicky diff'
+  table_style: "ascii" # single, rounded, double, heavy, ascii, markdown, minimal or compact
+  chart:                # Plot the rows next to the table
+    type: "bar"         # bar, line or pie
+    x: "month"          # Column labelling each row or slice
+    y: ["revenue", "cost"]  # Numeric columns, pie charts use the first
+    title: "Revenue"
+    colors: ["blue-500"]    # Tailwind colors, defaulting to the theme
   fields:
     - name: "column1"
       type: "string"
       style: "text-gray-700"
+    - name: "amount"
+      format: "currency"
+      aggregate: "sum"  # sum, avg, min, max or count in subtotal and total rows
+    - name: "description"
+      wrap: true        # Wrap long values onto more lines in the terminal
+      truncate: 30      # Never wider than 30 cells, ending with …
+
+Terminal tables are fitted to the terminal width by shrinking the widest columns
+first. Values too wide for their column are truncated with … unless wrap is set.
+In struct tags: pretty:"wrap" or pretty:"truncate=30"
+
+The --table-style flag overrides the table_style of every table, e.g. ascii for
+tickets and emails, or minimal for output that is grepped.
 
 ## Format Options
 
@@ -327,9 +758,51 @@ Additional formatting parameters:
 
 format_options:
   format: "epoch"       # For dates: parse from epoch timestamp
-  digits: "2"           # For floats: decimal places
+  digits: "2"           # For floats, bytes, percent and si: decimal places
+  ratio: "true"         # For percent: multiply ratios such as 0.42 by 100
+  unit: "ms"            # For duration: unit of numbers (ns, us, ms, s, m, h)
   sort: "field_name"    # For tables: sort by field
   dir: "desc"           # Sort direction: asc/desc
+  currency: "EUR"       # For currency: ISO 4217 code (default from locale)
+  locale: "de-DE"       # Per-field locale overriding --locale
+
+## Locales
+
+Numbers, currencies and dates follow --locale (or CLICKY_LOCALE), e.g. en-US,
+en-GB, en-IN, de-DE, fr-FR, es-ES, it-IT and nl-NL. Month and weekday names are
+translated, and date_format: long uses the locale's long date layout:
+
+fields:
+  - name: "created"
+    type: "date"
+    date_format: "long"   # de-DE: 5. März 2024
+
+JSON and YAML output keep raw values.
+
+## Themes
+
+--theme (or CLICKY_THEME) selects default, dark, light, notty, auto, a theme file
+in $XDG_CONFIG_HOME/clicky/themes by name, or the path to a YAML or JSON theme file.
+Theme files override the colours, table style, tree glyphs and status icons of the
+theme they extend:
+
+name: "ocean"
+extends: "dark"               # Optional: built-in theme or theme file, defaults to default
+primary: "#0ea5e9"            # Hex, ANSI colour number or Tailwind colour
+success: "green-400"
+table_style: "rounded"
+table_header: "sky-300"       # Defaults to primary
+table_border: "slate-500"     # Defaults to muted
+tree:
+  branch: "├─ "
+  last: "╰─ "
+  continue: "│  "
+  indent: "   "
+icons:                        # pending, running, success, failed, warning, canceled
+  success: "✔"
+  failed: "✘"
+
+'clicky theme preview [name|path]' renders a sample in a theme.
 
 ## Nested Fields
 
@@ -344,10 +817,45 @@ fields:
       - name: "city"
         type: "string"
 
+## Includes and Definitions
+
+Share field blocks between schemas. Included files (relative to the schema file)
+provide their definitions and fields, and fields can $ref a definition with any
+other keys overriding it. Nested fields are overridden by name:
+
+include:
+  - common.yaml
+definitions:
+  money:
+    type: "float"
+    format: "currency"
+fields:
+  - name: "total_amount"
+    $ref: "money"
+    style: "text-red-600 font-bold"
+  - name: "shipping_address"
+    $ref: "#/definitions/address"
+    fields:
+      - name: "city"
+        style: "font-bold"
+
+## Templates
+
+Render the data with a Go template instead of a built-in format. Templates
+ending in .html use html/template, the path is relative to the schema file:
+
+template: "report.tmpl"
+
+Inside the template the dot is the parsed data, with helpers such as:
+  {{ render (value . "id") }}
+  {{ range table . "items" }}{{ formatted .name }}{{ end }}
+  {{ style "text-red-600 font-bold" (value . "status") }}
+
 ## Example Usage
 
 clicky --schema my-schema.yaml data.json
 clicky pretty --schema my-schema.yaml --format html data.json
+clicky pretty --schema my-schema.yaml --template report.tmpl data.json
 clicky schema validate my-schema.yaml
 clicky schema example -o example-schema.yaml
 `
@@ -561,6 +1069,8 @@ func getOutputExtension(format string) string {
 	switch strings.ToLower(format) {
 	case "json":
 		return ".json"
+	case "ndjson", "jsonl":
+		return ".ndjson"
 	case "yaml", "yml":
 		return ".yaml"
 	case "csv":
@@ -569,8 +1079,14 @@ func getOutputExtension(format string) string {
 		return ".html"
 	case "markdown", "md":
 		return ".md"
+	case "asciidoc", "adoc":
+		return ".adoc"
+	case "rst":
+		return ".rst"
 	case "pdf":
 		return ".pdf"
+	case "xlsx", "excel":
+		return ".xlsx"
 	default:
 		return ".txt"
 	}
@@ -30,6 +30,12 @@ func (p Process) Out() string {
 	return p.Stderr.String() + p.Stdout.String()
 }
 
+// OutText returns the output of the process as Text, with its colours and styles parsed
+// from ANSI escape sequences so it can be embedded in HTML, Markdown and PDF reports
+func (p Process) OutText() api.Text {
+	return api.ParseANSI(p.Out())
+}
+
 func (p Process) Pretty() api.Text {
 	return api.Text{Content: p.Name()}
 }
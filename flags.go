@@ -3,6 +3,7 @@ package clicky
 import (
 	"time"
 
+	"github.com/flanksource/clicky/task"
 	"github.com/flanksource/commons/logger"
 	"github.com/spf13/pflag"
 )
@@ -65,10 +66,20 @@ func BindAllFlags(flags *pflag.FlagSet) AllFlags {
 
 	// Format Options
 
-	flags.StringVar(&Flags.FormatOptions.Format, "format", "", "Output format: pretty, json, yaml, csv, html, pdf, markdown")
+	flags.StringVar(&Flags.FormatOptions.Format, "format", "", "Output format: pretty, json, ndjson, yaml, csv, html, pdf, xlsx, markdown, asciidoc, rst, template")
 	flags.BoolVar(&Flags.FormatOptions.NoColor, "no-color", false, "Disable colored output")
 	flags.BoolVar(&Flags.FormatOptions.Verbose, "verbose", false, "Enable verbose output")
 	flags.BoolVar(&Flags.FormatOptions.DumpSchema, "dump-schema", false, "Dump the schema to stderr for debugging")
+	flags.StringVar(&Flags.FormatOptions.PDFBackend, "pdf-backend", "", "PDF rendering backend: native (default) or playwright")
+	flags.StringVar(&Flags.FormatOptions.Template, "template", "", "Go template file for the template format (html/template for .html files)")
+	flags.StringVar(&Flags.FormatOptions.Fields, "fields", "", "Comma separated fields to output, use a.b for nested fields and table columns")
+	flags.StringVar(&Flags.FormatOptions.Query, "query", "", "JSONPath expression selecting the data to output, e.g. $.items[*]")
+	flags.StringVar(&Flags.FormatOptions.Filter, "filter", "", "Only output table rows matching the expression, e.g. \"status == 'failed' && amount > 1000\"")
+	flags.StringVar(&Flags.FormatOptions.Locale, "locale", "", "Locale for numbers, currencies and dates, e.g. en-US, de-DE, en-IN (default $CLICKY_LOCALE)")
+	flags.BoolVar(&Flags.FormatOptions.TailwindCDN, "tailwind-cdn", false, "Load the Tailwind CDN in HTML output instead of embedding the CSS, which needs network access to view")
+	flags.BoolVar(&Flags.FormatOptions.Interactive, "interactive", false, "Make HTML tables sortable, searchable and their columns hideable, and trees collapsible")
+	flags.StringVar(&Flags.FormatOptions.TableStyle, "table-style", "", "Border style of pretty tables: single, rounded, double, heavy, ascii, markdown, minimal or compact")
+	flags.StringVar(&Flags.FormatOptions.Theme, "theme", "", "Theme name (default, dark, light, notty, auto) or path to a YAML or JSON theme file (default $CLICKY_THEME)")
 
 	// Format-specific flags (mutually exclusive)
 	flags.BoolVar(&Flags.FormatOptions.JSON, "json", false, "Output in JSON format")
@@ -78,6 +89,7 @@ func BindAllFlags(flags *pflag.FlagSet) AllFlags {
 	flags.BoolVar(&Flags.FormatOptions.Pretty, "pretty", false, "Output in pretty format (default)")
 	flags.BoolVar(&Flags.FormatOptions.HTML, "html", false, "Output in HTML format")
 	flags.BoolVar(&Flags.FormatOptions.PDF, "pdf", false, "Output in PDF format")
+	flags.BoolVar(&Flags.FormatOptions.XLSX, "xlsx", false, "Output in Excel (XLSX) format")
 
 	// Dependency Scanner flags
 	flags.DurationVar(&Flags.DependencyScannerOptions.CacheTTL, "cache-ttl", 24*time.Hour, "Cache TTL for dependency scans")
@@ -96,4 +108,12 @@ func (a AllFlags) UseFlags() {
 	logger.Debugf("Using logger flags: %s", a)
 	a.TaskManagerOptions.Apply()
 	UseFormatter(a.FormatOptions)
+
+	// Task statuses are drawn outside of the formatters, in the theme of --theme
+	options := a.FormatOptions
+	if err := options.ResolveFormat(); err != nil {
+		logger.Warnf("Invalid format options: %v", err)
+	} else if theme, ok := options.SelectedTheme(); ok {
+		task.SetTheme(theme)
+	}
 }
@@ -37,10 +37,28 @@ func Text(content string, tailwindClasses ...string) api.Text {
 	}
 }
 
-func Textf(content string, args ...any) api.Text {
-	return api.Text{
-		Content: fmt.Sprintf(content, args...),
+// Textf formats inline markup, e.g. Textf("[bold]%s[/] took [muted]%s[/]", name, elapsed).
+// The arguments are escaped, so brackets in them are never read as tags.
+func Textf(markup string, args ...any) api.Text {
+	escaped := make([]any, len(args))
+	for i, arg := range args {
+		if n, ok := arg.(int); ok {
+			// ints hold no brackets, and stay ints for widths such as %*s
+			escaped[i] = n
+		} else {
+			escaped[i] = markupArg{arg}
+		}
 	}
+	return api.Markup(fmt.Sprintf(markup, escaped...))
+}
+
+// markupArg formats an argument to Textf as escaped markup
+type markupArg struct {
+	value any
+}
+
+func (a markupArg) Format(f fmt.State, verb rune) {
+	_, _ = f.Write([]byte(api.EscapeMarkup(fmt.Sprintf(fmt.FormatString(f, verb), a.value))))
 }
 
 func UseFormatter(opts FormatOptions) {
@@ -0,0 +1,51 @@
+package clicky
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type formatOrder struct {
+	ID     string  `json:"id"`
+	Amount float64 `json:"amount"`
+}
+
+type formatReport struct {
+	Orders []formatOrder `json:"orders" pretty:"table"`
+}
+
+func TestFormatHTMLOptions(t *testing.T) {
+	report := formatReport{Orders: []formatOrder{{ID: "a-1", Amount: 1500}, {ID: "a-2", Amount: 30}}}
+
+	output, err := Format(report, FormatOptions{Format: "html"})
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	if strings.Contains(output, "<script>") || strings.Contains(output, "data-clicky-table") {
+		t.Errorf("expected static HTML without --interactive")
+	}
+
+	output, err = Format(report, FormatOptions{Format: "html", Interactive: true, TailwindCDN: true})
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	for _, expected := range []string{"<script>", "data-clicky-table", "cdn.tailwindcss.com"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %s in HTML formatted with --interactive and --tailwind-cdn", expected)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "report.html")
+	if err := FormatToFile(report, FormatOptions{Format: "html", Interactive: true}, file); err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "data-clicky-table") {
+		t.Errorf("expected interactive HTML in %s", file)
+	}
+}
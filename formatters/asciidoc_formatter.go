@@ -0,0 +1,169 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// asciidocListMarkers are the description list delimiters of each nesting level
+var asciidocListMarkers = []string{"::", ":::", "::::", ";;"}
+
+// AsciiDocFormatter handles AsciiDoc formatting, e.g. for Antora sites
+type AsciiDocFormatter struct{}
+
+// NewAsciiDocFormatter creates a new AsciiDoc formatter
+func NewAsciiDocFormatter() *AsciiDocFormatter {
+	return &AsciiDocFormatter{}
+}
+
+// Format formats data as AsciiDoc
+func (f *AsciiDocFormatter) Format(data interface{}) (string, error) {
+	switch value := data.(type) {
+	case api.Text:
+		return value.AsciiDoc(), nil
+	case api.Pretty:
+		return value.Pretty().AsciiDoc(), nil
+	}
+
+	prettyData, err := ToPrettyData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+
+	if prettyData == nil || prettyData.Schema == nil {
+		return "", nil
+	}
+
+	return f.FormatPrettyData(prettyData)
+}
+
+// FormatPrettyData formats PrettyData as AsciiDoc: summary fields as a description list,
+// followed by tables and trees with their titles
+func (f *AsciiDocFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return "", err
+	}
+
+	var sections []string
+	var summaryFields []api.PrettyField
+	for _, field := range data.Schema.Fields {
+		if field.Format != api.FormatTable && field.Format != api.FormatTree {
+			summaryFields = append(summaryFields, field)
+		}
+	}
+	if summary := f.formatFields(summaryFields, data.Values, 0); summary != "" {
+		sections = append(sections, summary)
+	}
+
+	for _, field := range data.Schema.Fields {
+		switch field.Format {
+		case api.FormatTable:
+			if rows := data.Tables[field.Name]; len(rows) > 0 {
+				sections = append(sections, f.formatTable(rows, field))
+			}
+		case api.FormatTree:
+			if fieldValue, exists := data.Values[field.Name]; exists {
+				sections = append(sections, f.formatTree(field, fieldValue))
+			}
+		}
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
+
+// formatFields formats fields as a description list, nesting the fields of maps
+func (f *AsciiDocFormatter) formatFields(fields []api.PrettyField, values map[string]api.FieldValue, depth int) string {
+	marker := asciidocListMarkers[min(depth, len(asciidocListMarkers)-1)]
+
+	var result strings.Builder
+	for _, field := range fields {
+		fieldValue, exists := values[field.Name]
+		if !exists {
+			continue
+		}
+		if fieldValue.HasNestedFields() {
+			result.WriteString(fmt.Sprintf("%s%s\n", fieldLabel(field), marker))
+			result.WriteString(f.formatFields(nestedFields(field, fieldValue), fieldValue.NestedFields, depth+1))
+			continue
+		}
+		result.WriteString(fmt.Sprintf("%s%s %s\n", fieldLabel(field), marker, f.formatValue(fieldValue, field)))
+	}
+	return result.String()
+}
+
+// formatValue formats a value as inline AsciiDoc, with images as inline images
+func (f *AsciiDocFormatter) formatValue(fieldValue api.FieldValue, field api.PrettyField) string {
+	if isImageField(fieldValue, field) {
+		if src, ok := fieldValue.Value.(string); ok && src != "" {
+			return fmt.Sprintf("image:%s[%s]", src, fieldLabel(field))
+		}
+	}
+	// A trailing + keeps the line breaks of multi-line values
+	return strings.ReplaceAll(fieldValue.AsciiDoc(), "\n", " +\n")
+}
+
+// formatTable formats table rows as an AsciiDoc table with a header row
+func (f *AsciiDocFormatter) formatTable(rows []api.PrettyDataRow, field api.PrettyField) string {
+	columns := api.TableColumns(field, rows)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf(".%s\n", tableTitle(field)))
+	result.WriteString("[%header]\n")
+	result.WriteString("|===\n")
+
+	var headers []string
+	for _, column := range columns {
+		headers = append(headers, "|"+asciidocCell(fieldLabel(column)))
+	}
+	result.WriteString(strings.Join(headers, " ") + "\n\n")
+
+	for _, row := range rows {
+		var cells []string
+		for _, column := range columns {
+			var cell string
+			if fieldValue, exists := row[column.Name]; exists {
+				cell = asciidocCell(f.formatValue(fieldValue, column))
+				// Group headers and aggregate rows are bold
+				if row.Kind() != "" && cell != "" {
+					cell = "**" + cell + "**"
+				}
+			}
+			cells = append(cells, "|"+cell)
+		}
+		result.WriteString(strings.Join(cells, " ") + "\n")
+	}
+
+	result.WriteString("|===\n")
+	return result.String()
+}
+
+// formatTree formats a tree as a titled, nested unordered list
+func (f *AsciiDocFormatter) formatTree(field api.PrettyField, fieldValue api.FieldValue) string {
+	node, ok := fieldValue.Value.(api.TreeNode)
+	if !ok {
+		return fmt.Sprintf("%s:: %s\n", fieldLabel(field), f.formatValue(fieldValue, field))
+	}
+	return fmt.Sprintf(".%s\n%s", fieldLabel(field), f.formatTreeNode(node, 1))
+}
+
+// formatTreeNode formats a tree node and its children, one more * for each level
+func (f *AsciiDocFormatter) formatTreeNode(node api.TreeNode, depth int) string {
+	if node == nil {
+		return ""
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%s %s\n", strings.Repeat("*", depth), node.Pretty().AsciiDoc()))
+	for _, child := range node.GetChildren() {
+		result.WriteString(f.formatTreeNode(child, depth+1))
+	}
+	return result.String()
+}
+
+// asciidocCell escapes the cell separator in table cell content
+func asciidocCell(content string) string {
+	return strings.ReplaceAll(content, "|", "\\|")
+}
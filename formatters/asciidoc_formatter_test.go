@@ -0,0 +1,78 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// docsReport has the summary fields, nested map, image, titled table and tree covered by
+// the documentation formats
+func docsReport(t *testing.T) *api.PrettyData {
+	data := map[string]interface{}{
+		"name":   "web",
+		"status": "failed",
+		"logo":   "https://example.com/logo.png",
+		"labels": map[string]interface{}{
+			"team": "platform",
+			"owner": map[string]interface{}{
+				"email": "ops@example.com",
+			},
+		},
+		"orders": []map[string]interface{}{
+			{"id": "a|1", "amount": 1500},
+			{"id": "a-2", "amount": 30},
+		},
+		"files": &api.SimpleTreeNode{Label: "src", Children: []api.TreeNode{
+			&api.SimpleTreeNode{Label: "cmd", Children: []api.TreeNode{&api.SimpleTreeNode{Label: "main.go"}}},
+		}},
+	}
+	schema := &api.PrettyObject{
+		Fields: []api.PrettyField{
+			{Name: "name", Type: "string"},
+			{Name: "status", Type: "string", Style: "text-red-500 font-bold"},
+			{Name: "logo", Type: "string"},
+			{Name: "labels", Type: "map", Format: "map"},
+			{Name: "orders", Format: api.FormatTable, TableOptions: api.PrettyTable{
+				Title:  "Recent orders",
+				Fields: []api.PrettyField{{Name: "id"}, {Name: "amount", Type: "int"}},
+			}},
+			{Name: "files", Format: api.FormatTree},
+		},
+	}
+
+	prettyData, err := api.NewStructParser().ParseDataWithSchema(data, schema)
+	if err != nil {
+		t.Fatalf("failed to parse data: %v", err)
+	}
+	return prettyData
+}
+
+func TestAsciiDocFormatter(t *testing.T) {
+	output, err := NewAsciiDocFormatter().FormatPrettyData(docsReport(t))
+	if err != nil {
+		t.Fatalf("asciidoc failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Name:: web\n",
+		"Status:: [.red]##**failed**##\n",
+		"Logo:: image:https://example.com/logo.png[Logo]\n",
+		"Labels::\nOwner:::\nEmail:::: ops@example.com\nTeam::: platform\n",
+		".Recent orders\n[%header]\n|===\n|Id |Amount\n\n|a\\|1 |1500\n|a-2 |30\n|===\n",
+		".Files\n* src\n** cmd\n*** main.go\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in AsciiDoc output, got:\n%s", expected, output)
+		}
+	}
+
+	output, err = NewFormatManager().Format("adoc", api.Text{Content: "ok", Style: "text-green-600"})
+	if err != nil {
+		t.Fatalf("asciidoc failed: %v", err)
+	}
+	if output != "[.green]##ok##" {
+		t.Errorf("expected the Pretty text as AsciiDoc, got %q", output)
+	}
+}
@@ -17,6 +17,33 @@ func init() {
 	api.RegisterRenderFunc("complexity_colored", RenderComplexityColored)
 	api.RegisterRenderFunc("line_number", RenderLineNumber)
 	api.RegisterRenderFunc("icon_label", RenderIconLabel)
+	api.RegisterRenderFunc(api.FormatSparkline, RenderSparkline)
+	api.RegisterRenderFunc(api.FormatBar, RenderBar)
+	api.RegisterRenderFunc(api.FormatGauge, RenderGauge)
+}
+
+// RenderSparkline renders a series of numbers as a sparkline with block characters,
+// or braille characters with chars=braille
+func RenderSparkline(value interface{}, field api.PrettyField, theme api.Theme) string {
+	return renderMiniChart(api.FormatSparkline, value, field)
+}
+
+// RenderBar renders a number as a horizontal bar, or a series as vertical bars
+func RenderBar(value interface{}, field api.PrettyField, theme api.Theme) string {
+	return renderMiniChart(api.FormatBar, value, field)
+}
+
+// RenderGauge renders a number, or the last number of a series, as a gauge coloured by the warn and crit thresholds
+func RenderGauge(value interface{}, field api.PrettyField, theme api.Theme) string {
+	return renderMiniChart(api.FormatGauge, value, field)
+}
+
+func renderMiniChart(kind string, value interface{}, field api.PrettyField) string {
+	field.Format = kind
+	if chart, ok := newMiniChart(value, field); ok {
+		return chart.ANSI()
+	}
+	return fmt.Sprintf("%v", value)
 }
 
 // RenderASTNode renders an AST node in compact format
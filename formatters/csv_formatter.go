@@ -3,7 +3,6 @@ package formatters
 import (
 	"encoding/csv"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/flanksource/clicky/api"
@@ -103,15 +102,13 @@ func (f *CSVFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
 	} else if tableField != nil && len(nonTableFields) == 0 {
 		// If we have table data and it's the primary data, format it as CSV rows
 		if tableData, exists := data.Tables[tableField.Name]; exists && len(tableData) > 0 {
-			// Get headers from the first row
+			// Get headers from the first data row, sorted for consistent output.
+			// Group headers and aggregate rows are written as rows with the same columns.
 			var headers []string
-			for key := range tableData[0] {
-				headers = append(headers, key)
+			for _, column := range api.TableColumns(api.PrettyField{}, tableData) {
+				headers = append(headers, column.Name)
 			}
 
-			// Sort headers for consistent output
-			sort.Strings(headers)
-
 			// Write headers
 			if err := writer.Write(headers); err != nil {
 				return "", err
@@ -0,0 +1,485 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// Kinds of differences reported by DiffPrettyData
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// diffKeyColumns are used to match table rows when no key is configured
+var diffKeyColumns = []string{"id", "key", "name"}
+
+// DiffEntry is a single difference between two data sets
+type DiffEntry struct {
+	Kind string      `json:"kind" yaml:"kind"`
+	Path string      `json:"path" yaml:"path"`
+	Old  interface{} `json:"old,omitempty" yaml:"old,omitempty"`
+	New  interface{} `json:"new,omitempty" yaml:"new,omitempty"`
+
+	// OldText and NewText are the values formatted with the field format
+	OldText string `json:"-" yaml:"-"`
+	NewText string `json:"-" yaml:"-"`
+
+	// pointer is the JSON pointer of the value in the old data, or of the
+	// table for added rows. index is the position of removed rows in the table.
+	pointer string
+	row     bool
+	index   int
+}
+
+// Diff is the result of comparing two PrettyData objects
+type Diff struct {
+	Entries []DiffEntry
+	Theme   api.Theme
+}
+
+// PatchOperation is an RFC 6902 JSON patch operation
+type PatchOperation struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// MarshalJSON omits the value of remove operations, but keeps null and zero values otherwise
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// DiffPrettyData compares two data sets field by field. Table rows are matched by
+// the table's key: option, then by key, then by an id, key or name column, falling
+// back to the row position. A configured key must be a column of every table, with
+// a distinct value in every row.
+func DiffPrettyData(old, new *api.PrettyData, key string) (*Diff, error) {
+	d := &Diff{Theme: api.DefaultTheme()}
+	if old == nil {
+		old = &api.PrettyData{}
+	}
+	if new == nil {
+		new = &api.PrettyData{}
+	}
+
+	// Rows of a top-level slice have no table name in their paths, an empty slice has no fields
+	topLevel := (old.Schema == nil || len(old.Schema.Fields) == 0 || isTopLevelTable(old)) &&
+		(new.Schema == nil || len(new.Schema.Fields) == 0 || isTopLevelTable(new))
+	for _, field := range mergeSchemaFields(old.Schema, new.Schema) {
+		if field.Format == api.FormatTable {
+			path, pointer := field.Name, "/"+escapePointer(field.Name)
+			if topLevel {
+				path, pointer = "", ""
+			}
+			if err := d.diffTable(field, path, pointer, old.Tables[field.Name], new.Tables[field.Name], key); err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			continue
+		}
+
+		oldValue, hasOld := old.Values[field.Name]
+		newValue, hasNew := new.Values[field.Name]
+		d.diffFieldValue(field, field.Name, "/"+escapePointer(field.Name), oldValue, newValue, hasOld, hasNew)
+	}
+	return d, nil
+}
+
+// mergeSchemaFields returns the fields of both schemas, in the order of the new schema
+func mergeSchemaFields(old, new *api.PrettyObject) []api.PrettyField {
+	var fields []api.PrettyField
+	if new != nil {
+		fields = append(fields, new.Fields...)
+	}
+	if old != nil {
+		fields = append(fields, old.Fields...)
+	}
+	return uniqueFields(fields)
+}
+
+// Counts returns the number of added, removed and changed entries
+func (d Diff) Counts() (added, removed, changed int) {
+	for _, entry := range d.Entries {
+		switch entry.Kind {
+		case DiffAdded:
+			added++
+		case DiffRemoved:
+			removed++
+		case DiffChanged:
+			changed++
+		}
+	}
+	return added, removed, changed
+}
+
+func (d *Diff) add(entry DiffEntry) {
+	d.Entries = append(d.Entries, entry)
+}
+
+// diffFieldValue compares a field value, recursing into nested maps
+func (d *Diff) diffFieldValue(field api.PrettyField, path, pointer string, oldValue, newValue api.FieldValue, hasOld, hasNew bool) {
+	switch {
+	case !hasOld && !hasNew:
+		return
+	case !hasOld:
+		d.add(DiffEntry{Kind: DiffAdded, Path: path, New: rawFieldValue(newValue), NewText: diffText(newValue, field), pointer: pointer})
+		return
+	case !hasNew:
+		d.add(DiffEntry{Kind: DiffRemoved, Path: path, Old: rawFieldValue(oldValue), OldText: diffText(oldValue, field), pointer: pointer})
+		return
+	}
+
+	oldRaw, newRaw := genericValue(rawFieldValue(oldValue)), genericValue(rawFieldValue(newValue))
+	if reflect.DeepEqual(oldRaw, newRaw) {
+		return
+	}
+	_, oldMap := oldRaw.(map[string]interface{})
+	_, newMap := newRaw.(map[string]interface{})
+	if oldMap && newMap {
+		d.diffGeneric(path, pointer, oldRaw, newRaw)
+		return
+	}
+	d.add(DiffEntry{
+		Kind:    DiffChanged,
+		Path:    path,
+		Old:     oldRaw,
+		New:     newRaw,
+		OldText: diffText(oldValue, field),
+		NewText: diffText(newValue, field),
+		pointer: pointer,
+	})
+}
+
+// diffGeneric compares raw values, recursing into maps and lists
+func (d *Diff) diffGeneric(path, pointer string, old, new interface{}) {
+	if reflect.DeepEqual(old, new) {
+		return
+	}
+
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := make([]string, 0, len(oldMap)+len(newMap))
+		for key := range oldMap {
+			keys = append(keys, key)
+		}
+		for key := range newMap {
+			if _, exists := oldMap[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath, childPointer := joinDiffPath(path, key), pointer+"/"+escapePointer(key)
+			oldValue, hasOld := oldMap[key]
+			newValue, hasNew := newMap[key]
+			switch {
+			case !hasOld:
+				d.add(DiffEntry{Kind: DiffAdded, Path: childPath, New: newValue, NewText: diffRawText(newValue), pointer: childPointer})
+			case !hasNew:
+				d.add(DiffEntry{Kind: DiffRemoved, Path: childPath, Old: oldValue, OldText: diffRawText(oldValue), pointer: childPointer})
+			default:
+				d.diffGeneric(childPath, childPointer, oldValue, newValue)
+			}
+		}
+		return
+	}
+
+	d.add(DiffEntry{
+		Kind:    DiffChanged,
+		Path:    path,
+		Old:     old,
+		New:     new,
+		OldText: diffRawText(old),
+		NewText: diffRawText(new),
+		pointer: pointer,
+	})
+}
+
+// diffTable matches the rows of a table by key and compares matched rows column by column
+func (d *Diff) diffTable(field api.PrettyField, path, pointer string, oldRows, newRows []api.PrettyDataRow, key string) error {
+	oldRows, newRows = api.DataRows(oldRows), api.DataRows(newRows)
+	columns := uniqueFields(append(api.TableColumns(field, newRows), api.TableColumns(field, oldRows)...))
+
+	if field.TableOptions.Key != "" {
+		key = field.TableOptions.Key
+	}
+	configured := key != ""
+	if configured && len(oldRows)+len(newRows) > 0 {
+		column, ok := findField(columns, key)
+		if !ok {
+			return fmt.Errorf("key: %w", unknownFieldError(key, columns))
+		}
+		key = column.Name
+	}
+	if !configured {
+		for _, name := range diffKeyColumns {
+			if _, ok := findField(columns, name); ok {
+				key = name
+				break
+			}
+		}
+	}
+
+	var oldIndex map[string]int
+	if key != "" {
+		var err error
+		if oldIndex, err = diffRowIndex(oldRows, key); err != nil {
+			err = fmt.Errorf("old rows: %w", err)
+		} else if _, err = diffRowIndex(newRows, key); err != nil {
+			err = fmt.Errorf("new rows: %w", err)
+		}
+		if err != nil && configured {
+			return err
+		}
+		if err != nil {
+			// The id, key or name column does not identify the rows, so they are matched by position
+			key = ""
+		}
+	}
+	if key == "" {
+		oldIndex = make(map[string]int, len(oldRows))
+		for i := range oldRows {
+			oldIndex[strconv.Itoa(i)] = i
+		}
+	}
+
+	rowKey := func(row api.PrettyDataRow, index int) string {
+		if key == "" {
+			return strconv.Itoa(index)
+		}
+		return fmt.Sprintf("%v", rawFieldValue(row[key]))
+	}
+	rowPath := func(row api.PrettyDataRow, index int) string {
+		if key == "" {
+			return fmt.Sprintf("%s[%d]", path, index)
+		}
+		return fmt.Sprintf("%s[%s=%s]", path, key, rowKey(row, index))
+	}
+
+	matched := make(map[int]bool, len(oldRows))
+	for i, row := range newRows {
+		index, exists := oldIndex[rowKey(row, i)]
+		if !exists {
+			d.add(DiffEntry{Kind: DiffAdded, Path: rowPath(row, i), New: rawRow(row), NewText: diffRowText(row, columns), pointer: pointer, row: true})
+			continue
+		}
+		matched[index] = true
+
+		oldRow := oldRows[index]
+		for _, column := range columns {
+			oldValue, hasOld := oldRow[column.Name]
+			newValue, hasNew := row[column.Name]
+			d.diffFieldValue(column, joinDiffPath(rowPath(row, i), column.Name),
+				fmt.Sprintf("%s/%d/%s", pointer, index, escapePointer(column.Name)), oldValue, newValue, hasOld, hasNew)
+		}
+	}
+
+	for i, row := range oldRows {
+		if !matched[i] {
+			d.add(DiffEntry{Kind: DiffRemoved, Path: rowPath(row, i), Old: rawRow(row), OldText: diffRowText(row, columns),
+				pointer: fmt.Sprintf("%s/%d", pointer, i), row: true, index: i})
+		}
+	}
+	return nil
+}
+
+// diffRowIndex returns the position of each row by the value of its key column,
+// failing on rows without a value and on values shared by several rows
+func diffRowIndex(rows []api.PrettyDataRow, key string) (map[string]int, error) {
+	index := make(map[string]int, len(rows))
+	for i, row := range rows {
+		value := rawFieldValue(row[key])
+		if value == nil {
+			return nil, fmt.Errorf("row %d has no %s", i, key)
+		}
+		text := fmt.Sprintf("%v", value)
+		if previous, exists := index[text]; exists {
+			return nil, fmt.Errorf("rows %d and %d have the same %s %s", previous, i, key, text)
+		}
+		index[text] = i
+	}
+	return index, nil
+}
+
+// Patch returns the differences as RFC 6902 operations that turn the old data into the new data.
+// Values are replaced before rows are removed, highest index first, so indexes stay valid.
+func (d Diff) Patch() []PatchOperation {
+	ops := make([]PatchOperation, 0, len(d.Entries))
+	var removals, additions []PatchOperation
+	var removedRows []DiffEntry
+	for _, entry := range d.Entries {
+		switch {
+		case entry.Kind == DiffChanged:
+			ops = append(ops, PatchOperation{Op: "replace", Path: entry.pointer, Value: genericValue(entry.New)})
+		case entry.Kind == DiffRemoved && entry.row:
+			removedRows = append(removedRows, entry)
+		case entry.Kind == DiffRemoved:
+			removals = append(removals, PatchOperation{Op: "remove", Path: entry.pointer})
+		case entry.row:
+			additions = append(additions, PatchOperation{Op: "add", Path: entry.pointer + "/-", Value: genericValue(entry.New)})
+		default:
+			ops = append(ops, PatchOperation{Op: "add", Path: entry.pointer, Value: genericValue(entry.New)})
+		}
+	}
+
+	sort.SliceStable(removedRows, func(i, j int) bool { return removedRows[i].index > removedRows[j].index })
+	for _, entry := range removedRows {
+		removals = append(removals, PatchOperation{Op: "remove", Path: entry.pointer})
+	}
+
+	ops = append(ops, removals...)
+	return append(ops, additions...)
+}
+
+// Pretty renders the differences as one line per entry, coloured with the theme
+func (d Diff) Pretty() api.Text {
+	lines := d.lines()
+	text := api.Text{}
+	for i, line := range lines {
+		if i > 0 {
+			text = text.Append("\n")
+		}
+		text = text.Add(line)
+	}
+	return text
+}
+
+// Markdown renders the differences as a list
+func (d Diff) Markdown() string {
+	var result strings.Builder
+	result.WriteString("## Differences\n\n")
+	for _, line := range d.lines() {
+		result.WriteString("- ")
+		result.WriteString(line.Markdown())
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// HTML renders the differences as a list, without the surrounding document
+func (d Diff) HTML() string {
+	var result strings.Builder
+	result.WriteString("<ul class=\"space-y-1 font-mono text-sm\">\n")
+	for _, line := range d.lines() {
+		result.WriteString("    <li>")
+		result.WriteString(escapeText(line).HTML())
+		result.WriteString("</li>\n")
+	}
+	result.WriteString("</ul>")
+	return result.String()
+}
+
+// lines renders a summary line followed by a line per entry
+func (d Diff) lines() []api.Text {
+	added, removed, changed := d.Counts()
+	muted := d.style(d.Theme.Muted, false)
+	if len(d.Entries) == 0 {
+		return []api.Text{{Content: "No differences", Class: muted}}
+	}
+
+	lines := []api.Text{{
+		Content: fmt.Sprintf("%d changed, %d added, %d removed", changed, added, removed),
+		Class:   api.Class{Font: &api.Font{Bold: true}},
+	}}
+	for _, entry := range d.Entries {
+		path := entry.Path
+		if path == "" {
+			path = "$"
+		}
+		var line api.Text
+		switch entry.Kind {
+		case DiffAdded:
+			line = api.Text{Content: "+ " + path + ": " + entry.NewText, Class: d.style(d.Theme.Success, false)}
+		case DiffRemoved:
+			line = api.Text{Content: "- " + path + ": " + entry.OldText, Class: d.style(d.Theme.Error, false)}
+		default:
+			line = api.Text{Content: "~ " + path + ": ", Class: d.style(d.Theme.Warning, false)}.
+				Add(api.Text{Content: entry.OldText, Class: d.style(d.Theme.Error, true)}).
+				Add(api.Text{Content: " → ", Class: muted}).
+				Add(api.Text{Content: entry.NewText, Class: d.style(d.Theme.Success, false)})
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// style returns a class with a theme colour, striking through old values
+func (d Diff) style(color lipgloss.Color, strikethrough bool) api.Class {
+	class := api.Class{Foreground: &api.Color{Hex: string(color)}}
+	if strikethrough {
+		class.Font = &api.Font{Strikethrough: true}
+	}
+	return class
+}
+
+// diffText formats a value with its field format
+func diffText(value api.FieldValue, field api.PrettyField) string {
+	if value.Value == nil && value.Text == nil && len(value.NestedFields) > 0 {
+		return diffRawText(rawFieldValue(value))
+	}
+	if value.Value != nil {
+		switch value.Value.(type) {
+		case map[string]interface{}, []interface{}:
+			return diffRawText(value.Value)
+		}
+	}
+	return parseFieldValue(value, field).Formatted()
+}
+
+// diffRawText formats a raw value, using compact JSON for maps and lists
+func diffRawText(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}, []interface{}:
+		if b, err := json.Marshal(value); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// diffRowText summarises a row as column=value pairs
+func diffRowText(row api.PrettyDataRow, columns []api.PrettyField) string {
+	var parts []string
+	for _, column := range columns {
+		if value, ok := row[column.Name]; ok {
+			parts = append(parts, column.Name+"="+diffText(value, column))
+		}
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// joinDiffPath appends a field name to a report path
+func joinDiffPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// escapePointer escapes a JSON pointer reference token
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,186 @@
+package formatters
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestDiff(t *testing.T) {
+	type item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price" pretty:"currency"`
+	}
+	type order struct {
+		ID     string  `json:"id"`
+		Status string  `json:"status"`
+		Total  float64 `json:"total" pretty:"currency"`
+		Items  []item  `json:"items" pretty:"table"`
+	}
+
+	old := order{
+		ID:     "ORD-1",
+		Status: "pending",
+		Total:  12.5,
+		Items:  []item{{Name: "a", Price: 1.5}, {Name: "b", Price: 2}},
+	}
+	new := order{
+		ID:     "ORD-1",
+		Status: "shipped",
+		Total:  12.5,
+		Items:  []item{{Name: "a", Price: 0}, {Name: "c", Price: 3}},
+	}
+
+	t.Run("Entries", func(t *testing.T) {
+		oldData, _ := ToPrettyData(old)
+		newData, _ := ToPrettyData(new)
+		diff, err := DiffPrettyData(oldData, newData, "")
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+
+		var paths []string
+		for _, entry := range diff.Entries {
+			paths = append(paths, entry.Kind+" "+entry.Path)
+		}
+		expected := []string{
+			"changed status",
+			"changed items[name=a].price",
+			"added items[name=c]",
+			"removed items[name=b]",
+		}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Errorf("expected %v, got %v", expected, paths)
+		}
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		output, err := NewFormatManager().Diff(old, new, "", FormatOptions{Format: "json"})
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		var patch []map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &patch); err != nil {
+			t.Fatalf("invalid JSON patch %q: %v", output, err)
+		}
+		expected := []map[string]interface{}{
+			{"op": "replace", "path": "/status", "value": "shipped"},
+			{"op": "replace", "path": "/items/0/price", "value": 0.0},
+			{"op": "remove", "path": "/items/1"},
+			{"op": "add", "path": "/items/-", "value": map[string]interface{}{"name": "c", "price": 3.0}},
+		}
+		if !reflect.DeepEqual(patch, expected) {
+			t.Errorf("expected %v, got %v", expected, patch)
+		}
+	})
+
+	t.Run("Pretty", func(t *testing.T) {
+		output, err := NewFormatManager().Diff(old, new, "", FormatOptions{Format: "pretty", NoColor: true})
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		for _, line := range []string{"2 changed, 1 added, 1 removed", "~ status: pending → shipped", "+ items[name=c]", "- items[name=b]"} {
+			if !strings.Contains(output, line) {
+				t.Errorf("expected %q in:\n%s", line, output)
+			}
+		}
+
+		output, err = NewFormatManager().Diff(old, old, "", FormatOptions{Format: "pretty", NoColor: true})
+		if err != nil || output != "No differences" {
+			t.Errorf("expected no differences, got %q (%v)", output, err)
+		}
+	})
+
+	t.Run("Key", func(t *testing.T) {
+		schema := &api.PrettyObject{Fields: []api.PrettyField{
+			{Name: "items", Format: api.FormatTable, TableOptions: api.PrettyTable{
+				Key:    "sku",
+				Fields: []api.PrettyField{{Name: "sku"}, {Name: "qty", Type: api.FieldTypeInt}},
+			}},
+		}}
+		oldData := map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"sku": "A1", "qty": 1},
+			map[string]interface{}{"sku": "B2", "qty": 2},
+		}}
+		newData := map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"sku": "B2", "qty": 5},
+		}}
+
+		output, err := NewFormatManager().Diff(oldData, newData, "", FormatOptions{Format: "markdown", Schema: schema})
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		if !strings.Contains(output, "items[sku=B2].qty") || !strings.Contains(output, "items[sku=A1]") {
+			t.Errorf("expected rows matched by sku, got:\n%s", output)
+		}
+
+		// An object whose only field is a table keeps the table name in its paths
+		output, err = NewFormatManager().Diff(oldData, newData, "", FormatOptions{Format: "json", Schema: schema})
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+		var patch []map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &patch); err != nil {
+			t.Fatalf("invalid JSON patch %q: %v", output, err)
+		}
+		for _, op := range patch {
+			if path, _ := op["path"].(string); !strings.HasPrefix(path, "/items/") {
+				t.Errorf("expected paths under /items, got %v", patch)
+				break
+			}
+		}
+	})
+
+	t.Run("KeyErrors", func(t *testing.T) {
+		type skuItem struct {
+			SKU *string `json:"sku"`
+			Qty int     `json:"qty"`
+		}
+		type skuOrder struct {
+			Items []skuItem `json:"items" pretty:"table"`
+		}
+		sku := func(sku string) *string { return &sku }
+		a1, b2, unkeyed := skuItem{SKU: sku("A1"), Qty: 1}, skuItem{SKU: sku("B2"), Qty: 2}, skuItem{Qty: 3}
+
+		tests := []struct {
+			name     string
+			key      string
+			old, new skuOrder
+			expected string
+		}{
+			{"unknown", "id", skuOrder{Items: []skuItem{a1}}, skuOrder{Items: []skuItem{b2}}, `key: unknown field "id", valid fields are: sku, qty`},
+			{"nil", "sku", skuOrder{Items: []skuItem{a1}}, skuOrder{Items: []skuItem{a1, unkeyed}}, "new rows: row 1 has no sku"},
+			{"duplicate", "sku", skuOrder{Items: []skuItem{a1, b2, a1}}, skuOrder{Items: []skuItem{b2}}, "old rows: rows 0 and 2 have the same sku A1"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				oldData, _ := ToPrettyData(test.old)
+				newData, _ := ToPrettyData(test.new)
+				_, err := DiffPrettyData(oldData, newData, test.key)
+				if err == nil || !strings.HasSuffix(err.Error(), test.expected) {
+					t.Errorf("expected error %q, got %v", test.expected, err)
+				}
+			})
+		}
+	})
+
+	t.Run("DuplicateNames", func(t *testing.T) {
+		oldData, _ := ToPrettyData([]item{{Name: "a", Price: 1}, {Name: "a", Price: 2}})
+		newData, _ := ToPrettyData([]item{{Name: "a", Price: 1}, {Name: "a", Price: 3}})
+		diff, err := DiffPrettyData(oldData, newData, "")
+		if err != nil {
+			t.Fatalf("diff failed: %v", err)
+		}
+
+		var paths []string
+		for _, entry := range diff.Entries {
+			paths = append(paths, entry.Kind+" "+entry.Path)
+		}
+		if expected := []string{"changed [1].price"}; !reflect.DeepEqual(paths, expected) {
+			t.Errorf("expected rows with the same name matched by position, %v, got %v", expected, paths)
+		}
+	})
+}
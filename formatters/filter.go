@@ -0,0 +1,1149 @@
+package formatters
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// Filter is a compiled row filter expression, e.g.
+//
+//	status == 'failed' && amount > 1000
+//	name =~ '^web-' || !(created_at < now() - 7d)
+//
+// Identifiers are column names (a.b for nested fields) and are read with the
+// typed FieldValue accessors, so numbers, dates and durations compare by value.
+// Supported operators: || && ! == != < <= > >= =~ !~ + - * / and parentheses,
+// with the keywords and, or, not, true, false and null, and inclusive ranges
+// written as x between 50 and 90. Durations are written as 30s, 15m, 12h, 7d
+// or 2w, and the functions now(), date(s), lower(s), upper(s), contains(s, sub)
+// and len(v) are available.
+type Filter struct {
+	Expression string
+	root       filterNode
+}
+
+// ParseFilter compiles a filter expression
+func ParseFilter(expression string) (*Filter, error) {
+	tokens, err := lexFilter(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expression, err)
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != filterTokenEOF {
+		err = fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", expression, err)
+	}
+	return &Filter{Expression: expression, root: root}, nil
+}
+
+// Match evaluates the filter against a row
+func (f *Filter) Match(row api.PrettyDataRow) (bool, error) {
+	value, err := f.root.eval(row)
+	if err != nil {
+		return false, fmt.Errorf("filter %q: %w", f.Expression, err)
+	}
+	return filterTruthy(value), nil
+}
+
+// Identifiers returns the top-level column names referenced by the filter
+func (f *Filter) Identifiers() []string {
+	var names []string
+	seen := map[string]bool{}
+	f.root.walk(func(node filterNode) {
+		if ident, ok := node.(filterIdent); ok && !seen[ident.path[0]] {
+			seen[ident.path[0]] = true
+			names = append(names, ident.path[0])
+		}
+	})
+	return names
+}
+
+// unknownIdentifier returns the first identifier that is not one of columns, if any
+func (f *Filter) unknownIdentifier(columns []api.PrettyField) (string, bool) {
+	for _, name := range f.Identifiers() {
+		if _, ok := findField(columns, name); !ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// FilterRows returns the rows matching the filter
+func FilterRows(rows []api.PrettyDataRow, filter *Filter) ([]api.PrettyDataRow, error) {
+	if filter == nil {
+		return rows, nil
+	}
+	filtered := make([]api.PrettyDataRow, 0, len(rows))
+	for _, row := range api.DataRows(rows) {
+		match, err := filter.Match(row)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered, nil
+}
+
+// ApplyFilters filters table rows with the filter: key of each table in the schema,
+// then with the expression, which applies to every table that has the columns it references
+func ApplyFilters(data *api.PrettyData, expression string) (*api.PrettyData, error) {
+	if data == nil || data.Schema == nil {
+		return data, nil
+	}
+
+	var global *Filter
+	if strings.TrimSpace(expression) != "" {
+		var err error
+		if global, err = ParseFilter(expression); err != nil {
+			return nil, err
+		}
+	}
+
+	result := *data
+	result.Tables = make(map[string][]api.PrettyDataRow, len(data.Tables))
+	for name, rows := range data.Tables {
+		result.Tables[name] = rows
+	}
+
+	var filtered []string
+	matched := false
+	var unknown string
+	var valid []api.PrettyField
+	for _, field := range data.Schema.Fields {
+		if field.Format != api.FormatTable {
+			continue
+		}
+		rows, ok := data.Tables[field.Name]
+		if !ok {
+			continue
+		}
+
+		changed := false
+		if field.TableOptions.Filter != "" {
+			filter, err := ParseFilter(field.TableOptions.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			if rows, err = FilterRows(rows, filter); err != nil {
+				return nil, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			changed = true
+		}
+
+		if global != nil {
+			columns := api.TableColumns(field, rows)
+			if name, ok := global.unknownIdentifier(columns); ok {
+				unknown = name
+				valid = append(valid, columns...)
+			} else {
+				var err error
+				if rows, err = FilterRows(rows, global); err != nil {
+					return nil, fmt.Errorf("%s: %w", field.Name, err)
+				}
+				matched = true
+				changed = true
+			}
+		}
+
+		if changed {
+			// Recompute group headers and aggregates for the remaining rows
+			rows = api.GroupRows(field, rows)
+			filtered = append(filtered, field.Name)
+		}
+		result.Tables[field.Name] = rows
+	}
+
+	if global != nil && !matched {
+		if unknown == "" {
+			return nil, fmt.Errorf("filter %q: no tables to filter", expression)
+		}
+		return nil, fmt.Errorf("filter %q: %w", expression, unknownFieldError(unknown, uniqueFields(valid)))
+	}
+
+	if len(filtered) > 0 {
+		result.Original = filteredOriginal(&result, filtered)
+	}
+	return &result, nil
+}
+
+// filteredOriginal rebuilds the original value with the remaining rows of the
+// filtered tables, keeping the shape and keys of the unfiltered JSON output
+func filteredOriginal(data *api.PrettyData, tables []string) interface{} {
+	if isTopLevelTable(data) {
+		return rawRows(data.Tables[data.Schema.Fields[0].Name])
+	}
+
+	raw := map[string]interface{}{}
+	if original, ok := genericValue(data.Original).(map[string]interface{}); ok {
+		for key, value := range original {
+			raw[key] = value
+		}
+	} else {
+		for name, value := range data.Values {
+			raw[name] = rawFieldValue(value)
+		}
+		for name, rows := range data.Tables {
+			raw[name] = rawRows(rows)
+		}
+	}
+	for _, name := range tables {
+		raw[name] = rawRows(data.Tables[name])
+	}
+	return raw
+}
+
+// uniqueFields removes fields with duplicate names, keeping the first
+func uniqueFields(fields []api.PrettyField) []api.PrettyField {
+	var unique []api.PrettyField
+	seen := map[string]bool{}
+	for _, field := range fields {
+		if !seen[field.Name] {
+			seen[field.Name] = true
+			unique = append(unique, field)
+		}
+	}
+	return unique
+}
+
+// Lexer
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenNumber
+	filterTokenDuration
+	filterTokenString
+	filterTokenIdentifier
+	filterTokenOperator
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+var filterOperators = []string{"||", "&&", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "+", "-", "*", "/", "(", ")", ","}
+
+var (
+	// filterDurationPattern matches the units of time.ParseDuration, and d and w for days and weeks
+	filterDurationPattern = regexp.MustCompile(`^(\d+(\.\d+)?(ns|us|µs|ms|s|m|h|d|w))+`)
+	filterDurationPart    = regexp.MustCompile(`\d+(\.\d+)?[a-zµ]+`)
+	filterNumberPattern   = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?`)
+)
+
+// isFilterIdentifierRune reports whether r can continue an identifier
+func isFilterIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// filterRuneAt decodes the rune at byte offset i of expression
+func filterRuneAt(expression string, i int) rune {
+	r, _ := utf8.DecodeRuneInString(expression[i:])
+	return r
+}
+
+func lexFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expression); {
+		c := filterRuneAt(expression, i)
+		switch {
+		case unicode.IsSpace(c):
+			i += utf8.RuneLen(c)
+
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(expression) && rune(expression[j]) != c; j++ {
+				// Only quotes and backslashes are escaped, so regex escapes like \d are kept
+				if expression[j] == '\\' && j+1 < len(expression) && (rune(expression[j+1]) == c || expression[j+1] == '\\') {
+					j++
+				}
+				sb.WriteByte(expression[j])
+			}
+			if j >= len(expression) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: sb.String(), pos: i})
+			i = j + 1
+
+		case unicode.IsDigit(c) || c == '.' && i+1 < len(expression) && unicode.IsDigit(rune(expression[i+1])):
+			// A duration ends at its unit, so 10m is ten minutes but 10min is an error
+			match := filterDurationPattern.FindString(expression[i:])
+			kind := filterTokenDuration
+			if match == "" || i+len(match) < len(expression) && isFilterIdentifierRune(filterRuneAt(expression, i+len(match))) {
+				match, kind = filterNumberPattern.FindString(expression[i:]), filterTokenNumber
+			}
+			end := i + len(match)
+			if end < len(expression) && isFilterIdentifierRune(filterRuneAt(expression, end)) {
+				j := end
+				for j < len(expression) && isFilterIdentifierRune(filterRuneAt(expression, j)) {
+					j += utf8.RuneLen(filterRuneAt(expression, j))
+				}
+				return nil, fmt.Errorf("invalid number or duration %q at position %d, durations use the units ns, us, ms, s, m, h, d and w", expression[i:j], i)
+			}
+			if kind == filterTokenNumber {
+				if _, err := strconv.ParseFloat(match, 64); err != nil {
+					return nil, fmt.Errorf("invalid number %q at position %d", match, i)
+				}
+			}
+			tokens = append(tokens, filterToken{kind: kind, text: match, pos: i})
+			i = end
+
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(expression) && isFilterIdentifierRune(filterRuneAt(expression, j)) {
+				j += utf8.RuneLen(filterRuneAt(expression, j))
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenIdentifier, text: expression[i:j], pos: i})
+			i = j
+
+		default:
+			matched := false
+			for _, op := range filterOperators {
+				if strings.HasPrefix(expression[i:], op) {
+					tokens = append(tokens, filterToken{kind: filterTokenOperator, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(expression)}), nil
+}
+
+// Parser
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.pos]
+	if token.kind != filterTokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+// accept consumes the next token if it is one of the operators or keywords
+func (p *filterParser) accept(ops ...string) (string, bool) {
+	token := p.peek()
+	if token.kind != filterTokenOperator && token.kind != filterTokenIdentifier {
+		return "", false
+	}
+	for _, op := range ops {
+		if token.text == op || (token.kind == filterTokenIdentifier && strings.EqualFold(token.text, op)) {
+			p.next()
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = filterBinary{op: "||", left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = filterBinary{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return filterUnary{op: "!", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("between"); ok {
+		return p.parseBetween(left)
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">", "=~", "!~")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if op == "=~" || op == "!~" {
+		// Compile literal patterns once, rather than for every row
+		if literal, ok := right.(filterLiteral); ok {
+			pattern, isString := literal.value.(string)
+			if !isString {
+				return nil, fmt.Errorf("%s expects a string pattern", op)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			return filterMatch{negate: op == "!~", left: left, re: re}, nil
+		}
+		return filterMatch{negate: op == "!~", left: left, right: right}, nil
+	}
+	return filterBinary{op: op, left: left, right: right}, nil
+}
+
+// parseBetween parses the bounds of an inclusive range, "x between 50 and 90"
+func (p *filterParser) parseBetween(operand filterNode) (filterNode, error) {
+	low, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("and", "&&"); !ok {
+		return nil, fmt.Errorf("expected and at position %d", p.peek().pos)
+	}
+	high, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return filterBinary{
+		op:    "&&",
+		left:  filterBinary{op: ">=", left: operand, right: low},
+		right: filterBinary{op: "<=", left: operand, right: high},
+	}, nil
+}
+
+func (p *filterParser) parseAdditive() (filterNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = filterBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseMultiplicative() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = filterBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if _, ok := p.accept("-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return filterUnary{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	token := p.next()
+	switch token.kind {
+	case filterTokenNumber:
+		n, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", token.text, token.pos)
+		}
+		return filterLiteral{value: n}, nil
+
+	case filterTokenDuration:
+		d, err := parseFilterDuration(token.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q at position %d", token.text, token.pos)
+		}
+		return filterLiteral{value: d}, nil
+
+	case filterTokenString:
+		return filterLiteral{value: token.text}, nil
+
+	case filterTokenIdentifier:
+		switch strings.ToLower(token.text) {
+		case "true":
+			return filterLiteral{value: true}, nil
+		case "false":
+			return filterLiteral{value: false}, nil
+		case "null", "nil":
+			return filterLiteral{value: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(token)
+		}
+		return filterIdent{path: strings.Split(token.text, ".")}, nil
+
+	case filterTokenOperator:
+		if token.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ) at position %d", p.peek().pos)
+			}
+			return node, nil
+		}
+	case filterTokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+}
+
+func (p *filterParser) parseCall(name filterToken) (filterNode, error) {
+	call := filterCall{name: strings.ToLower(name.text)}
+	if _, ok := filterFunctions[call.name]; !ok {
+		return nil, fmt.Errorf("unknown function %s() at position %d", name.text, name.pos)
+	}
+	if _, ok := p.accept(")"); ok {
+		return call, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if _, ok := p.accept(")"); ok {
+			return call, nil
+		}
+		if _, ok := p.accept(","); !ok {
+			return nil, fmt.Errorf("expected , or ) at position %d", p.peek().pos)
+		}
+	}
+}
+
+// parseFilterDuration parses Go durations, extended with d (days) and w (weeks)
+func parseFilterDuration(s string) (time.Duration, error) {
+	var total time.Duration
+	for _, part := range filterDurationPart.FindAllString(s, -1) {
+		unit := strings.TrimLeft(part, "0123456789.")
+		n, err := strconv.ParseFloat(strings.TrimSuffix(part, unit), 64)
+		if err != nil {
+			return 0, err
+		}
+		switch unit {
+		case "d":
+			total += time.Duration(n * float64(24*time.Hour))
+		case "w":
+			total += time.Duration(n * float64(7*24*time.Hour))
+		default:
+			d, err := time.ParseDuration(part)
+			if err != nil {
+				return 0, err
+			}
+			total += d
+		}
+	}
+	return total, nil
+}
+
+// Evaluation
+
+type filterNode interface {
+	eval(row api.PrettyDataRow) (interface{}, error)
+	walk(fn func(filterNode))
+}
+
+type filterLiteral struct {
+	value interface{}
+}
+
+func (n filterLiteral) eval(api.PrettyDataRow) (interface{}, error) { return n.value, nil }
+func (n filterLiteral) walk(fn func(filterNode))                    { fn(n) }
+
+type filterIdent struct {
+	path []string
+}
+
+func (n filterIdent) walk(fn func(filterNode)) { fn(n) }
+
+func (n filterIdent) eval(row api.PrettyDataRow) (interface{}, error) {
+	value, ok := row[n.path[0]]
+	if !ok {
+		for name, candidate := range row {
+			if strings.EqualFold(name, n.path[0]) {
+				value, ok = candidate, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	for _, name := range n.path[1:] {
+		if value, ok = nestedValue(value, name); !ok {
+			return nil, nil
+		}
+	}
+	return filterOperand(value), nil
+}
+
+type filterUnary struct {
+	op      string
+	operand filterNode
+}
+
+func (n filterUnary) walk(fn func(filterNode)) {
+	fn(n)
+	n.operand.walk(fn)
+}
+
+func (n filterUnary) eval(row api.PrettyDataRow) (interface{}, error) {
+	value, err := n.operand.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !filterTruthy(value), nil
+	}
+	switch v := value.(type) {
+	case float64:
+		return -v, nil
+	case time.Duration:
+		return -v, nil
+	}
+	return nil, fmt.Errorf("cannot negate %s", filterTypeName(value))
+}
+
+type filterBinary struct {
+	op          string
+	left, right filterNode
+}
+
+func (n filterBinary) walk(fn func(filterNode)) {
+	fn(n)
+	n.left.walk(fn)
+	n.right.walk(fn)
+}
+
+func (n filterBinary) eval(row api.PrettyDataRow) (interface{}, error) {
+	left, err := n.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short-circuit boolean logic
+	switch n.op {
+	case "&&":
+		if !filterTruthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(row)
+		return filterTruthy(right), err
+	case "||":
+		if filterTruthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(row)
+		return filterTruthy(right), err
+	}
+
+	right, err := n.right.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "+", "-", "*", "/":
+		return filterArithmetic(n.op, left, right)
+	case "==":
+		return filterEqual(left, right), nil
+	case "!=":
+		return !filterEqual(left, right), nil
+	}
+
+	cmp, ok := filterCompare(left, right)
+	if !ok {
+		return false, nil
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+type filterMatch struct {
+	negate bool
+	left   filterNode
+	right  filterNode
+	re     *regexp.Regexp
+}
+
+func (n filterMatch) walk(fn func(filterNode)) {
+	fn(n)
+	n.left.walk(fn)
+	if n.right != nil {
+		n.right.walk(fn)
+	}
+}
+
+func (n filterMatch) eval(row api.PrettyDataRow) (interface{}, error) {
+	left, err := n.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	re := n.re
+	if re == nil {
+		pattern, err := n.right.eval(row)
+		if err != nil {
+			return nil, err
+		}
+		if re, err = regexp.Compile(filterString(pattern)); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", filterString(pattern), err)
+		}
+	}
+	if left == nil {
+		return n.negate, nil
+	}
+	return re.MatchString(filterString(left)) != n.negate, nil
+}
+
+type filterCall struct {
+	name string
+	args []filterNode
+}
+
+func (n filterCall) walk(fn func(filterNode)) {
+	fn(n)
+	for _, arg := range n.args {
+		arg.walk(fn)
+	}
+}
+
+func (n filterCall) eval(row api.PrettyDataRow) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(row)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	fn := filterFunctions[n.name]
+	if len(args) != fn.args {
+		return nil, fmt.Errorf("%s() expects %d argument(s), got %d", n.name, fn.args, len(args))
+	}
+	return fn.call(args)
+}
+
+var filterFunctions = map[string]struct {
+	args int
+	call func(args []interface{}) (interface{}, error)
+}{
+	"now": {0, func([]interface{}) (interface{}, error) {
+		return time.Now(), nil
+	}},
+	"date": {1, func(args []interface{}) (interface{}, error) {
+		if t, ok := filterTime(args[0]); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("date(): cannot parse %q as a date", filterString(args[0]))
+	}},
+	"lower": {1, func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(filterString(args[0])), nil
+	}},
+	"upper": {1, func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(filterString(args[0])), nil
+	}},
+	"contains": {2, func(args []interface{}) (interface{}, error) {
+		return strings.Contains(filterString(args[0]), filterString(args[1])), nil
+	}},
+	"len": {1, func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return float64(0), nil
+		}
+		return float64(len([]rune(filterString(args[0])))), nil
+	}},
+}
+
+// filterOperand converts a FieldValue into a number, bool, time, duration or string
+// using the field type to decide how to read it
+func filterOperand(value api.FieldValue) interface{} {
+	raw := value.Value
+	if rv := reflect.ValueOf(raw); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		raw = rv.Elem().Interface()
+	}
+	if raw == nil {
+		if value.Text != nil {
+			return value.Text.String()
+		}
+		return nil
+	}
+	value.Value = raw
+
+	isDate := value.Field.Type == api.FieldTypeDate || value.Field.Format == api.FormatDate
+	isNumber := value.Field.Type == api.FieldTypeInt || value.Field.Type == api.FieldTypeFloat
+
+	switch v := raw.(type) {
+	case bool:
+		return v
+	case time.Time:
+		return v
+	case time.Duration:
+		return v
+	case string:
+		if isDate {
+			if t := value.Time(); t != nil {
+				return *t
+			}
+		}
+		if isNumber {
+			if f := value.Float(); f != nil {
+				return *f
+			}
+		}
+		if value.Field.Type == api.FieldTypeDuration {
+			if d, err := parseFilterDuration(v); err == nil {
+				return d
+			}
+		}
+		return v
+	}
+
+	if n, ok := filterNumber(raw); ok {
+		if isDate {
+			value.Value = n
+			if t := value.Time(); t != nil {
+				return *t
+			}
+		}
+		return n
+	}
+
+	if pretty, ok := raw.(api.Pretty); ok {
+		return pretty.Pretty().String()
+	}
+	return fmt.Sprintf("%v", raw)
+}
+
+// filterNumber converts any numeric kind to float64
+func filterNumber(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// filterTime converts times and date strings into a time
+func filterTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case string:
+		if parsed := (api.FieldValue{Value: t}).Time(); parsed != nil {
+			return *parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func filterString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	case time.Time:
+		return s.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func filterTruthy(v interface{}) bool {
+	switch b := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return b
+	case float64:
+		return b != 0
+	case string:
+		return b != ""
+	case time.Duration:
+		return b != 0
+	case time.Time:
+		return !b.IsZero()
+	}
+	return true
+}
+
+func filterTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case float64:
+		return "number"
+	case time.Time:
+		return "date"
+	case time.Duration:
+		return "duration"
+	case bool:
+		return "boolean"
+	}
+	return "string"
+}
+
+func filterArithmetic(op string, left, right interface{}) (interface{}, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	// Dates given as strings take part in date arithmetic
+	if d, ok := right.(time.Duration); ok {
+		if t, ok := filterTime(left); ok {
+			switch op {
+			case "+":
+				return t.Add(d), nil
+			case "-":
+				return t.Add(-d), nil
+			}
+		}
+	}
+	if d, ok := left.(time.Duration); ok && op == "+" {
+		if t, ok := filterTime(right); ok {
+			return t.Add(d), nil
+		}
+	}
+
+	switch l := left.(type) {
+	case float64:
+		if r, ok := filterNumeric(right); ok {
+			switch op {
+			case "+":
+				return l + r, nil
+			case "-":
+				return l - r, nil
+			case "*":
+				return l * r, nil
+			case "/":
+				if r == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return l / r, nil
+			}
+		}
+	case time.Duration:
+		if r, ok := right.(time.Duration); ok {
+			switch op {
+			case "+":
+				return l + r, nil
+			case "-":
+				return l - r, nil
+			}
+		}
+		if r, ok := right.(float64); ok {
+			switch op {
+			case "*":
+				return time.Duration(float64(l) * r), nil
+			case "/":
+				if r == 0 {
+					return nil, fmt.Errorf("division by zero")
+				}
+				return time.Duration(float64(l) / r), nil
+			}
+		}
+	case time.Time:
+		if r, ok := filterTime(right); ok && op == "-" {
+			return l.Sub(r), nil
+		}
+	case string:
+		if r, ok := filterNumeric(right); ok {
+			if n, err := strconv.ParseFloat(l, 64); err == nil {
+				return filterArithmetic(op, n, r)
+			}
+		}
+		if r, ok := right.(time.Time); ok && op == "-" {
+			if t, ok := filterTime(l); ok {
+				return t.Sub(r), nil
+			}
+		}
+		if r, ok := right.(string); ok && op == "+" {
+			return l + r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot apply %s to %s and %s", op, filterTypeName(left), filterTypeName(right))
+}
+
+// filterNumeric reads numbers and numeric strings
+func filterNumeric(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func filterEqual(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	if l, ok := left.(bool); ok {
+		r, ok := right.(bool)
+		if !ok {
+			r, ok = filterBool(right)
+		}
+		return ok && l == r
+	}
+	if r, ok := right.(bool); ok {
+		l, ok := filterBool(left)
+		return ok && l == r
+	}
+	cmp, ok := filterCompare(left, right)
+	return ok && cmp == 0
+}
+
+func filterBool(v interface{}) (bool, bool) {
+	if s, ok := v.(string); ok {
+		b, err := strconv.ParseBool(s)
+		return b, err == nil
+	}
+	return false, false
+}
+
+// filterCompare orders two values, converting strings to the type of the other side
+func filterCompare(left, right interface{}) (int, bool) {
+	if left == nil || right == nil {
+		return 0, false
+	}
+
+	_, leftTime := left.(time.Time)
+	_, rightTime := right.(time.Time)
+	if leftTime || rightTime {
+		l, lok := filterTime(left)
+		r, rok := filterTime(right)
+		if !lok || !rok {
+			return 0, false
+		}
+		return l.Compare(r), true
+	}
+
+	_, leftDuration := left.(time.Duration)
+	_, rightDuration := right.(time.Duration)
+	if leftDuration || rightDuration {
+		l, lok := filterDurationValue(left)
+		r, rok := filterDurationValue(right)
+		if !lok || !rok {
+			return 0, false
+		}
+		return cmp.Compare(l, r), true
+	}
+
+	if l, ok := left.(float64); ok {
+		if r, ok := filterNumeric(right); ok {
+			return cmp.Compare(l, r), true
+		}
+	}
+	if r, ok := right.(float64); ok {
+		if l, ok := filterNumeric(left); ok {
+			return cmp.Compare(l, r), true
+		}
+	}
+
+	return strings.Compare(filterString(left), filterString(right)), true
+}
+
+func filterDurationValue(v interface{}) (time.Duration, bool) {
+	switch d := v.(type) {
+	case time.Duration:
+		return d, true
+	case string:
+		parsed, err := parseFilterDuration(d)
+		return parsed, err == nil && d != ""
+	}
+	return 0, false
+}
@@ -0,0 +1,174 @@
+package formatters
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestFilterExpressions(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	row := api.PrettyDataRow{
+		"name":       {Value: "web-1", Field: api.PrettyField{Name: "name", Type: api.FieldTypeString}},
+		"status":     {Value: "failed", Field: api.PrettyField{Name: "status"}},
+		"amount":     {Value: 1500, Field: api.PrettyField{Name: "amount", Type: api.FieldTypeInt}},
+		"ratio":      {Value: "0.25", Field: api.PrettyField{Name: "ratio", Type: api.FieldTypeFloat}},
+		"active":     {Value: true, Field: api.PrettyField{Name: "active", Type: api.FieldTypeBoolean}},
+		"created_at": {Value: created, Field: api.PrettyField{Name: "created_at", Type: api.FieldTypeDate}},
+		"updated_at": {Value: "2024-03-01", Field: api.PrettyField{Name: "updated_at", Format: api.FormatDate}},
+		"owner":      {Value: nil},
+		"address":    {Value: map[string]interface{}{"city": "Cape Town"}},
+		"größe":      {Value: 2000000, Field: api.PrettyField{Name: "größe", Type: api.FieldTypeInt}},
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"status == 'failed'", true},
+		{`status != "failed"`, false},
+		{"amount > 1000", true},
+		{"amount >= 1500 && amount < 1501", true},
+		{"amount * 2 == 3000", true},
+		{"ratio < 0.5", true},
+		{"active", true},
+		{"not active or amount > 2000", false},
+		{"!(status == 'ok') && active == true", true},
+		{"name =~ '^web-\\d+$'", true},
+		{"name !~ 'db'", true},
+		{"created_at > now() - 7d", true},
+		{"created_at > now() - 1d", false},
+		{"now() - created_at > 24h", true},
+		{"updated_at < '2024-06-01'", true},
+		{"updated_at + 30d > date('2024-03-15')", true},
+		{"owner == null", true},
+		{"owner > 1", false},
+		{"address.city == 'Cape Town'", true},
+		{"lower(status) == 'FAILED' || contains(upper(name), 'WEB')", true},
+		{"len(name) == 5", true},
+		{"missing == null", true},
+		{"größe > 1e6 && größe < 2.5E6", true},
+		{"amount < .5e4", true},
+		{"now() - created_at < 1h30m + 2d", true},
+		{"now() - created_at > 100ms", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			filter, err := ParseFilter(test.expression)
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+			match, err := filter.Match(row)
+			if err != nil {
+				t.Fatalf("failed to evaluate: %v", err)
+			}
+			if match != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, match)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"status ==", "(amount > 1", "name =~ '['", "foo(1)", "amount > 'x' &", "now() - created_at > 10min", "amount > 1e", "amount > 10x"} {
+		if _, err := ParseFilter(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestApplyFilters(t *testing.T) {
+	type item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price"`
+	}
+	type order struct {
+		ID    string  `json:"id"`
+		Total float64 `json:"total"`
+		Note  string  `json:"note" pretty:"hide"`
+		Items []item  `json:"items" pretty:"table"`
+	}
+	items := []item{{Name: "a", Price: 1.5}, {Name: "b", Price: 20}, {Name: "c", Price: 30}}
+
+	t.Run("FormatOptions", func(t *testing.T) {
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{JSON: true, Filter: "price > 10"}, items)
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		var rows []map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &rows); err != nil {
+			t.Fatalf("expected a JSON list, got %q: %v", output, err)
+		}
+		if len(rows) != 2 || rows[0]["name"] != "b" {
+			t.Errorf("expected rows b and c, got %v", rows)
+		}
+
+		output, err = NewFormatManager().FormatWithOptions(FormatOptions{CSV: true, Filter: "name == 'c'"}, items)
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		if strings.Contains(output, "1.5") || !strings.Contains(output, "30") {
+			t.Errorf("expected only row c in CSV, got %q", output)
+		}
+	})
+
+	t.Run("Object", func(t *testing.T) {
+		// A filter only drops rows, the object keeps its shape and JSON keys
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{JSON: true, Filter: "price > 10"}, order{ID: "ORD-1", Total: 51.5, Note: "gift", Items: items})
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		var filtered map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &filtered); err != nil {
+			t.Fatalf("expected a JSON object, got %q: %v", output, err)
+		}
+		rows, _ := filtered["items"].([]interface{})
+		if filtered["id"] != "ORD-1" || filtered["total"] != 51.5 || filtered["note"] != "gift" || len(rows) != 2 {
+			t.Errorf("expected the order with items b and c, got %v", filtered)
+		}
+	})
+
+	t.Run("SchemaFilter", func(t *testing.T) {
+		data := &api.PrettyData{
+			Schema: &api.PrettyObject{Fields: []api.PrettyField{
+				{Name: "id"},
+				{Name: "items", Format: api.FormatTable, TableOptions: api.PrettyTable{Filter: "price < 25"}},
+				{Name: "events", Format: api.FormatTable},
+			}},
+			Values: map[string]api.FieldValue{"id": {Value: "ORD-1"}},
+			Tables: map[string][]api.PrettyDataRow{
+				"items": {
+					{"price": {Value: 10.0}},
+					{"price": {Value: 30.0}},
+				},
+				"events": {
+					{"message": {Value: "created"}},
+				},
+			},
+		}
+
+		filtered, err := ApplyFilters(data, "")
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		if len(filtered.Tables["items"]) != 1 || len(data.Tables["items"]) != 2 {
+			t.Errorf("expected the table filter to apply to a copy, got %d rows", len(filtered.Tables["items"]))
+		}
+
+		// Tables without the referenced columns are left as-is
+		filtered, err = ApplyFilters(data, "price > 5")
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		if len(filtered.Tables["items"]) != 1 || len(filtered.Tables["events"]) != 1 {
+			t.Errorf("expected both filters on items only, got %v", filtered.Tables)
+		}
+
+		_, err = ApplyFilters(data, "cost > 5")
+		if err == nil || !strings.Contains(err.Error(), "price, message") {
+			t.Errorf("expected an error listing the valid columns, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package formatters
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+type groupedItem struct {
+	Category string  `json:"category" pretty:"group_by"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price" pretty:"format=currency,aggregate=sum"`
+	Quantity int     `json:"quantity" pretty:"aggregate=count"`
+}
+
+func TestGroupRows(t *testing.T) {
+	items := []groupedItem{
+		{Category: "fruit", Name: "apple", Price: 1.5, Quantity: 3},
+		{Category: "dairy", Name: "milk", Price: 2, Quantity: 1},
+		{Category: "fruit", Name: "pear", Price: 2, Quantity: 2},
+	}
+
+	kinds := func(rows []api.PrettyDataRow) string {
+		var out []string
+		for _, row := range rows {
+			kind := row.Kind()
+			if kind == "" {
+				kind = row["name"].Value.(string)
+			}
+			out = append(out, kind)
+		}
+		return strings.Join(out, ",")
+	}
+
+	t.Run("Rows", func(t *testing.T) {
+		data, err := ToPrettyData(items)
+		if err != nil {
+			t.Fatalf("failed to convert: %v", err)
+		}
+		rows := data.Tables["data"]
+		if got := kinds(rows); got != "group,apple,pear,subtotal,group,milk,subtotal,total" {
+			t.Fatalf("unexpected row order %s", got)
+		}
+		if category := rows[0]["category"].Value; category != "fruit" {
+			t.Errorf("expected the group header to hold the category, got %v", category)
+		}
+		subtotal := rows[3]
+		if price := subtotal["price"].Formatted(); price != "$3.50" {
+			t.Errorf("expected the subtotal formatted as currency, got %q", price)
+		}
+		if count := subtotal["quantity"].Formatted(); count != "2" {
+			t.Errorf("expected a count of 2, got %q", count)
+		}
+		if label := subtotal["category"].Formatted(); label != "Subtotal" {
+			t.Errorf("expected the label in the first column, got %q", label)
+		}
+		if total := rows[7]["price"].Formatted(); total != "$5.50" {
+			t.Errorf("expected a total of $5.50, got %q", total)
+		}
+	})
+
+	t.Run("Formatters", func(t *testing.T) {
+		manager := NewFormatManager()
+
+		output, err := manager.FormatWithOptions(FormatOptions{Format: "pretty", NoColor: true}, items)
+		if err != nil {
+			t.Fatalf("pretty failed: %v", err)
+		}
+		if !strings.Contains(output, "Subtotal") || !strings.Contains(output, "$5.50") {
+			t.Errorf("expected aggregate rows in pretty output, got:\n%s", output)
+		}
+
+		output, err = manager.FormatWithOptions(FormatOptions{Format: "markdown"}, items)
+		if err != nil {
+			t.Fatalf("markdown failed: %v", err)
+		}
+		if !strings.Contains(output, "**Total**") {
+			t.Errorf("expected a bold total row in markdown, got:\n%s", output)
+		}
+
+		output, err = manager.FormatWithOptions(FormatOptions{Format: "html"}, items)
+		if err != nil {
+			t.Fatalf("html failed: %v", err)
+		}
+		if !strings.Contains(output, "<tfoot") || !strings.Contains(output, "group-row") {
+			t.Errorf("expected styled group and total rows in HTML, got:\n%s", output)
+		}
+
+		output, err = manager.FormatWithOptions(FormatOptions{Format: "json"}, items)
+		if err != nil {
+			t.Fatalf("json failed: %v", err)
+		}
+		var rows []map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &rows); err != nil || len(rows) != 3 {
+			t.Errorf("expected JSON to contain only the data rows, got %s", output)
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		data, err := ToPrettyData(items)
+		if err != nil {
+			t.Fatalf("failed to convert: %v", err)
+		}
+		filtered, err := ApplyFilters(data, "name != 'pear'")
+		if err != nil {
+			t.Fatalf("filter failed: %v", err)
+		}
+		rows := filtered.Tables["data"]
+		if got := kinds(rows); got != "group,apple,subtotal,group,milk,subtotal,total" {
+			t.Fatalf("expected groups recomputed after filtering, got %s", got)
+		}
+		if total := rows[len(rows)-1]["price"].Formatted(); total != "$3.50" {
+			t.Errorf("expected the total recomputed to $3.50, got %q", total)
+		}
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"html"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/flanksource/clicky/api"
 	"github.com/flanksource/clicky/api/tailwind"
 )
@@ -12,6 +14,18 @@ import (
 // HTMLFormatter handles HTML formatting
 type HTMLFormatter struct {
 	IncludeCSS bool
+	// UseCDN loads the Tailwind CDN script instead of embedding the CSS of the classes used,
+	// which needs network access when the page is opened
+	UseCDN bool
+	// Interactive embeds a script making tables sortable, searchable and their columns
+	// hideable, and tree nodes collapsible
+	Interactive bool
+	// Theme colours headings, table headers and borders, and charts, defaulting to the
+	// theme selected with api.SetTheme
+	Theme *api.Theme
+	// Locale formats numbers, currencies and dates of fields that do not set their own
+	// locale, defaulting to the locale set with api.SetLocale
+	Locale string
 }
 
 // NewHTMLFormatter creates a new HTML formatter
@@ -26,19 +40,81 @@ func (f *HTMLFormatter) ToPrettyData(data interface{}) (*api.PrettyData, error)
 	return ToPrettyData(data)
 }
 
-// getCSS returns Tailwind CSS CDN and custom styling
-func (f *HTMLFormatter) getCSS() string {
+// document wraps content in a self-contained page with a <style> block generated from
+// the Tailwind classes it uses, or with the Tailwind CDN script when UseCDN is set
+func (f *HTMLFormatter) document(content string) string {
+	body := "<body class=\"bg-gray-100 min-h-screen p-6\">\n" +
+		"    <div class=\"max-w-7xl mx-auto space-y-8\">\n" +
+		content +
+		"    </div>\n</body>\n</html>"
+
+	css := "    <script src=\"https://cdn.tailwindcss.com\"></script>\n"
+	if !f.UseCDN {
+		css = "    <style>\n" + tailwind.Stylesheet(body) + "    </style>\n"
+	}
+	if theme, ok := f.theme(); ok {
+		css += "    <style>\n" + themeStylesheet(theme) + "    </style>\n"
+	}
 	return `<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>Clicky Output</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100 min-h-screen p-6">
-    <div class="max-w-7xl mx-auto space-y-8">
-`
+` + css + "</head>\n" + body
+}
+
+// theme returns the theme of the formatter or the selected theme, and whether there is one
+func (f *HTMLFormatter) theme() (api.Theme, bool) {
+	if f.Theme != nil {
+		return *f.Theme, true
+	}
+	return api.SelectedTheme()
+}
+
+// chartTheme returns the theme colouring charts
+func (f *HTMLFormatter) chartTheme() api.Theme {
+	if theme, ok := f.theme(); ok {
+		return theme
+	}
+	return api.DefaultTheme()
+}
+
+// themeStylesheet returns CSS variables with the colours of a theme, and rules colouring
+// section headings and table headers with its primary or header colour, and table
+// borders with its border colour
+func themeStylesheet(theme api.Theme) string {
+	var b strings.Builder
+	b.WriteString("      :root {\n")
+	for _, color := range []struct {
+		name  string
+		color lipgloss.Color
+	}{
+		{"primary", theme.Primary},
+		{"secondary", theme.Secondary},
+		{"success", theme.Success},
+		{"warning", theme.Warning},
+		{"error", theme.Error},
+		{"info", theme.Info},
+		{"muted", theme.Muted},
+		{"table-header", theme.HeaderColor()},
+		{"table-border", theme.BorderColor()},
+	} {
+		if hex := api.ColorHex(color.color); hex != "" {
+			b.WriteString(fmt.Sprintf("        --clicky-%s: %s;\n", color.name, hex))
+		}
+	}
+	b.WriteString("      }\n")
+	if api.ColorHex(theme.Primary) != "" {
+		b.WriteString("      h1, h2, h3 { color: var(--clicky-primary); }\n")
+	}
+	if api.ColorHex(theme.HeaderColor()) != "" {
+		b.WriteString("      thead th, thead th span { color: var(--clicky-table-header); }\n")
+	}
+	if api.ColorHex(theme.BorderColor()) != "" {
+		b.WriteString("      table, thead, tbody, tfoot, tr, th, td { border-color: var(--clicky-table-border); }\n")
+	}
+	return b.String()
 }
 
 // Format formats PrettyData into HTML output
@@ -49,14 +125,7 @@ func (f *HTMLFormatter) Format(in interface{}) (string, error) {
 		htmlContent := text.HTML()
 
 		if f.IncludeCSS {
-			var result strings.Builder
-			result.WriteString(f.getCSS())
-			result.WriteString("        <div class=\"bg-white rounded-lg shadow p-6\">\n")
-			result.WriteString("            ")
-			result.WriteString(htmlContent)
-			result.WriteString("\n        </div>\n")
-			result.WriteString("    </div>\n</body>\n</html>")
-			return result.String(), nil
+			return f.document("        <div class=\"bg-white rounded-lg shadow p-6\">\n            " + htmlContent + "\n        </div>\n"), nil
 		}
 		return htmlContent, nil
 	}
@@ -66,20 +135,17 @@ func (f *HTMLFormatter) Format(in interface{}) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
 	}
+	data = data.WithLocale(f.Locale)
 
 	if data == nil || data.Schema == nil {
 		return "", nil
 	}
-	if data == nil || data.Schema == nil {
-		return "", nil
+	if data, err = ApplyStyleRules(data); err != nil {
+		return "", err
 	}
 
 	var result strings.Builder
 
-	if f.IncludeCSS {
-		result.WriteString(f.getCSS())
-	}
-
 	// Summary first - add non-table fields as a summary card
 	result.WriteString("        <div class=\"bg-white rounded-lg shadow\">\n")
 	result.WriteString("            <div class=\"px-6 py-4 border-b border-gray-200\">\n")
@@ -107,7 +173,9 @@ func (f *HTMLFormatter) Format(in interface{}) (string, error) {
 
 		// Apply label styling
 		var labelHTML string
-		if field.LabelStyle != "" {
+		if field.Label != "" {
+			labelHTML = fmt.Sprintf("<span class=\"%s\">%s</span>", strings.TrimSpace("text-sm font-medium text-gray-500 "+field.LabelStyle), field.LabelText().HTML())
+		} else if field.LabelStyle != "" {
 			labelHTML = f.applyTailwindStyleToHTML(prettyFieldName, field.LabelStyle)
 		} else {
 			labelHTML = fmt.Sprintf("<span class=\"text-sm font-medium text-gray-500\">%s</span>", html.EscapeString(prettyFieldName))
@@ -138,6 +206,9 @@ func (f *HTMLFormatter) Format(in interface{}) (string, error) {
 				// Format as table with Tailwind styling
 				tableHTML := f.formatTableDataHTML(tableData, field)
 				result.WriteString(tableHTML)
+				if chart, ok := newTableChart(field, tableData, f.chartTheme()); ok {
+					result.WriteString(fmt.Sprintf("            <div class=\"px-6 py-4 border-t border-gray-200\">%s</div>\n", chart.SVG()))
+				}
 				result.WriteString("        </div>\n")
 			}
 		} else if field.Format == api.FormatTree {
@@ -162,10 +233,13 @@ func (f *HTMLFormatter) Format(in interface{}) (string, error) {
 		}
 	}
 
-	if f.IncludeCSS {
-		result.WriteString("    </div>\n</body>\n</html>")
+	if f.Interactive {
+		result.WriteString(htmlInteractiveScript)
 	}
 
+	if f.IncludeCSS {
+		return f.document(result.String()), nil
+	}
 	return result.String(), nil
 }
 
@@ -220,6 +294,13 @@ func (f *HTMLFormatter) formatFieldValueHTML(fieldValue api.FieldValue) string {
 
 // formatFieldValueHTMLWithStyle formats a FieldValue with field styling for HTML output
 func (f *HTMLFormatter) formatFieldValueHTMLWithStyle(fieldValue api.FieldValue, field api.PrettyField) string {
+	// Values with a link template are wrapped in an anchor
+	if url := fieldValue.Link; url != "" {
+		fieldValue.Link = ""
+		return fmt.Sprintf("<a href=\"%s\" class=\"text-blue-600 hover:underline\">%s</a>",
+			html.EscapeString(url), f.formatFieldValueHTMLWithStyle(fieldValue, field))
+	}
+
 	// Check if value implements Pretty interface first
 	if fieldValue.Value != nil {
 		if pretty, ok := fieldValue.Value.(api.Pretty); ok {
@@ -228,6 +309,15 @@ func (f *HTMLFormatter) formatFieldValueHTMLWithStyle(fieldValue api.FieldValue,
 		}
 	}
 
+	// Inline charts are drawn as SVG
+	chartField := field
+	if chartField.Format == "" {
+		chartField = fieldValue.Field
+	}
+	if chart, ok := newMiniChart(fieldValue.Value, chartField); ok {
+		return chart.SVG()
+	}
+
 	// Check if this is an image field
 	if field.Format == "image" || f.isImageURL(fieldValue.Formatted()) {
 		return f.formatImageHTML(fieldValue, field)
@@ -240,9 +330,9 @@ func (f *HTMLFormatter) formatFieldValueHTMLWithStyle(fieldValue api.FieldValue,
 
 	formatted := fieldValue.Formatted()
 
-	// Apply field style if specified (highest priority)
-	if field.Style != "" {
-		return f.applyTailwindStyleToHTML(formatted, field.Style)
+	// Apply field style if specified (highest priority), with the classes of matching style rules
+	if style := strings.TrimSpace(field.Style + " " + fieldValue.Style); style != "" {
+		return f.applyTailwindStyleToHTML(formatted, style)
 	}
 
 	// Apply color styling using FieldValue.Color()
@@ -296,29 +386,50 @@ func (f *HTMLFormatter) formatTableDataHTML(rows []api.PrettyDataRow, field api.
 	}
 
 	var result strings.Builder
+	columns := api.TableColumns(field, rows)
+
+	if f.Interactive {
+		result.WriteString("            <div data-clicky-table>\n")
+		result.WriteString(htmlTableToolbar(columns))
+	}
 	result.WriteString("            <div class=\"overflow-x-auto\">\n")
 	result.WriteString("                <table class=\"min-w-full table-auto\">\n")
 
 	// Write headers
 	result.WriteString("                    <thead class=\"bg-gray-50\">\n")
 	result.WriteString("                        <tr>\n")
-	for _, tableField := range field.TableOptions.Fields {
+	for _, tableField := range columns {
 		var headerHTML string
 		if field.TableOptions.HeaderStyle != "" {
 			headerHTML = f.applyTailwindStyleToHTML(tableField.Name, field.TableOptions.HeaderStyle)
 		} else {
 			headerHTML = fmt.Sprintf("<span class=\"text-xs font-medium text-gray-500 uppercase tracking-wider\">%s</span>", html.EscapeString(tableField.Name))
 		}
+		if f.Interactive {
+			result.WriteString(fmt.Sprintf("                            <th class=\"px-6 py-3 text-left cursor-pointer select-none\" data-sort-type=\"%s\">%s<span class=\"text-xs text-gray-500\" data-sort-indicator></span></th>\n",
+				htmlSortType(tableField, rows), headerHTML))
+			continue
+		}
 		result.WriteString(fmt.Sprintf("                            <th class=\"px-6 py-3 text-left\">%s</th>\n", headerHTML))
 	}
 	result.WriteString("                        </tr>\n")
 	result.WriteString("                    </thead>\n")
 
-	// Write data rows
+	// Write data rows, with the grand total in the table footer
+	var totals []api.PrettyDataRow
 	result.WriteString("                    <tbody class=\"bg-white divide-y divide-gray-200\">\n")
 	for _, row := range rows {
+		switch row.Kind() {
+		case api.RowKindTotal:
+			totals = append(totals, row)
+			continue
+		case api.RowKindGroup, api.RowKindSubtotal:
+			result.WriteString(f.formatSummaryRowHTML(row, columns))
+			continue
+		}
+
 		result.WriteString("                        <tr class=\"hover:bg-gray-50\">\n")
-		for _, tableField := range field.TableOptions.Fields {
+		for _, tableField := range columns {
 			fieldValue, exists := row[tableField.Name]
 			var cellContent string
 			if exists {
@@ -335,14 +446,52 @@ func (f *HTMLFormatter) formatTableDataHTML(rows []api.PrettyDataRow, field api.
 			} else {
 				cellContent = ""
 			}
-			result.WriteString(fmt.Sprintf("                            <td class=\"px-6 py-4 whitespace-nowrap text-sm text-gray-900\">%s</td>\n", cellContent))
+			var sortAttribute string
+			if f.Interactive && exists {
+				key, _ := htmlSortKey(fieldValue.Value, tableField)
+				sortAttribute = fmt.Sprintf(" data-sort=\"%s\"", html.EscapeString(key))
+			}
+			result.WriteString(fmt.Sprintf("                            <td class=\"px-6 py-4 whitespace-nowrap text-sm text-gray-900\"%s>%s</td>\n", sortAttribute, cellContent))
 		}
 		result.WriteString("                        </tr>\n")
 	}
 	result.WriteString("                    </tbody>\n")
+
+	if len(totals) > 0 {
+		result.WriteString("                    <tfoot class=\"border-t-2 border-gray-300\">\n")
+		for _, row := range totals {
+			result.WriteString(f.formatSummaryRowHTML(row, columns))
+		}
+		result.WriteString("                    </tfoot>\n")
+	}
 	result.WriteString("                </table>\n")
 	result.WriteString("            </div>\n")
+	if f.Interactive {
+		result.WriteString("            </div>\n")
+	}
+
+	return result.String()
+}
+
+// htmlRowKindClasses styles group headers and aggregate rows
+var htmlRowKindClasses = map[string]string{
+	api.RowKindGroup:    "bg-gray-100 font-semibold text-gray-700",
+	api.RowKindSubtotal: "bg-gray-50 font-semibold text-gray-900",
+	api.RowKindTotal:    "bg-gray-100 font-bold text-gray-900",
+}
 
+// formatSummaryRowHTML formats a group header or aggregate row
+func (f *HTMLFormatter) formatSummaryRowHTML(row api.PrettyDataRow, columns []api.PrettyField) string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("                        <tr class=\"%s %s-row\" data-kind=\"%s\">\n", htmlRowKindClasses[row.Kind()], row.Kind(), row.Kind()))
+	for _, column := range columns {
+		var cellContent string
+		if fieldValue, exists := row[column.Name]; exists {
+			cellContent = f.formatFieldValueHTML(fieldValue)
+		}
+		result.WriteString(fmt.Sprintf("                            <td class=\"px-6 py-3 whitespace-nowrap text-sm\">%s</td>\n", cellContent))
+	}
+	result.WriteString("                        </tr>\n")
 	return result.String()
 }
 
@@ -380,6 +529,12 @@ func (f *HTMLFormatter) formatTreeNodeHTML(node api.TreeNode, depth int) string
 	if depth == 0 {
 		// Root node - start the tree
 		result.WriteString(`<div class="tree-view">`)
+		if f.Interactive && len(children) > 0 {
+			result.WriteString(`<div class="flex gap-2 mb-2 text-xs">`)
+			result.WriteString(`<button type="button" class="border border-gray-300 rounded px-2 py-1 text-gray-600" data-tree-expand="true">Expand all</button>`)
+			result.WriteString(`<button type="button" class="border border-gray-300 rounded px-2 py-1 text-gray-600" data-tree-expand="false">Collapse all</button>`)
+			result.WriteString(`</div>`)
+		}
 		result.WriteString(`<div class="tree-node font-semibold text-lg mb-2">`)
 		result.WriteString(node.Pretty().HTML())
 		result.WriteString(`</div>`)
@@ -397,13 +552,14 @@ func (f *HTMLFormatter) formatTreeNodeHTML(node api.TreeNode, depth int) string
 	} else {
 		// Child node
 		result.WriteString(`<li class="flex items-start">`)
-		result.WriteString(`<span class="text-gray-400 mr-2">`)
-		if len(children) > 0 {
-			result.WriteString(`▸`) // or use a different tree connector symbol
-		} else {
-			result.WriteString(`•`) // leaf node indicator
+		switch {
+		case f.Interactive && len(children) > 0:
+			result.WriteString(`<span class="text-gray-400 mr-2 cursor-pointer select-none" role="button" aria-expanded="true" data-tree-toggle>▾</span>`)
+		case len(children) > 0:
+			result.WriteString(`<span class="text-gray-400 mr-2">▸</span>`) // or use a different tree connector symbol
+		default:
+			result.WriteString(`<span class="text-gray-400 mr-2">•</span>`) // leaf node indicator
 		}
-		result.WriteString(`</span>`)
 		result.WriteString(`<div class="flex-1">`)
 		result.WriteString(`<div class="tree-node">`)
 		result.WriteString(node.Pretty().HTML())
@@ -0,0 +1,183 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestHTMLStylesheet(t *testing.T) {
+	report := struct {
+		Name  string `json:"name" pretty:"style=text-blue-700 font-bold"`
+		Count int    `json:"count"`
+	}{Name: "web", Count: 3}
+
+	output, err := NewHTMLFormatter().Format(report)
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	if strings.Contains(output, "cdn.tailwindcss.com") {
+		t.Errorf("expected no network access by default")
+	}
+	for _, rule := range []string{"<style>", ".bg-gray-100{background-color:#f3f4f6}", ".text-blue-700{color:#1d4ed8}", `.md\:grid-cols-2`} {
+		if !strings.Contains(output, rule) {
+			t.Errorf("expected %s in the embedded CSS, got:\n%s", rule, output)
+		}
+	}
+
+	manager := NewFormatManager()
+	output, err = manager.FormatWithOptions(FormatOptions{Format: "html", TailwindCDN: true}, report)
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	if !strings.Contains(output, `<script src="https://cdn.tailwindcss.com"></script>`) || strings.Contains(output, "<style>") {
+		t.Errorf("expected the Tailwind CDN with --tailwind-cdn, got:\n%s", output)
+	}
+
+	output, err = manager.Diff(report, report, "", FormatOptions{Format: "html", TailwindCDN: true})
+	if err != nil || !strings.Contains(output, "cdn.tailwindcss.com") {
+		t.Errorf("expected the Tailwind CDN in HTML diffs, got %v:\n%s", err, output)
+	}
+
+	output, err = manager.FormatWithOptions(FormatOptions{Format: "html"}, report)
+	if err != nil || strings.Contains(output, "cdn.tailwindcss.com") {
+		t.Errorf("expected --tailwind-cdn to apply to its own call only, got %v:\n%s", err, output)
+	}
+}
+
+func TestHTMLTheme(t *testing.T) {
+	report := struct {
+		Name string `json:"name"`
+	}{Name: "web"}
+	stylesheet := themeStylesheet(api.LightTheme())
+
+	manager := NewFormatManager()
+	output, err := manager.FormatWithOptions(FormatOptions{Format: "html", Theme: "light"}, report)
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	if !strings.Contains(output, stylesheet) {
+		t.Errorf("expected the colours of the light theme, got:\n%s", output)
+	}
+
+	t.Setenv(ThemeEnv, "")
+	output, err = manager.FormatWithOptions(FormatOptions{Format: "html"}, report)
+	if err != nil || strings.Contains(output, stylesheet) {
+		t.Errorf("expected --theme to apply to its own call only, got %v:\n%s", err, output)
+	}
+	if _, ok := api.SelectedTheme(); ok {
+		t.Errorf("expected no theme selected for the process")
+	}
+
+	t.Setenv(ThemeEnv, "light")
+	output, err = manager.FormatWithOptions(FormatOptions{Format: "html"}, report)
+	if err != nil || !strings.Contains(output, stylesheet) {
+		t.Errorf("expected the theme of $%s, got %v:\n%s", ThemeEnv, err, output)
+	}
+}
+
+type interactiveOrder struct {
+	ID     string    `json:"id"`
+	Amount float64   `json:"amount" pretty:"currency"`
+	Placed time.Time `json:"placed"`
+}
+
+type interactiveReport struct {
+	Orders []interactiveOrder  `json:"orders" pretty:"table"`
+	Files  *api.SimpleTreeNode `json:"files" pretty:"tree"`
+}
+
+func TestHTMLInteractive(t *testing.T) {
+	placed := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	report := interactiveReport{
+		Orders: []interactiveOrder{{ID: "a-1", Amount: 1500, Placed: placed}, {ID: "a-2", Amount: 30, Placed: placed}},
+		Files: &api.SimpleTreeNode{Label: "src", Children: []api.TreeNode{
+			&api.SimpleTreeNode{Label: "cmd", Children: []api.TreeNode{&api.SimpleTreeNode{Label: "main.go"}}},
+		}},
+	}
+
+	output, err := NewFormatManager().FormatWithOptions(FormatOptions{Format: "html"}, report)
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	if strings.Contains(output, "<script>") || strings.Contains(output, "data-sort") {
+		t.Errorf("expected static HTML without --interactive")
+	}
+
+	output, err = NewFormatManager().FormatWithOptions(FormatOptions{Format: "html", Interactive: true}, report)
+	if err != nil {
+		t.Fatalf("html failed: %v", err)
+	}
+	for _, expected := range []string{
+		"<script>", "data-clicky-table", "data-table-search", `data-column-toggle="1"`,
+		`data-sort-type="number"`, `data-sort="1500"`, `data-sort="a-2"`,
+		fmt.Sprintf(`data-sort="%d"`, placed.UnixMilli()),
+		`aria-expanded="true" data-tree-toggle>▾</span>`, `data-tree-expand="false"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %s in interactive HTML", expected)
+		}
+	}
+	if strings.Contains(output, "src=") {
+		t.Errorf("expected the script inline, without external resources")
+	}
+}
+
+func TestHTMLTableColumns(t *testing.T) {
+	rows := []api.PrettyDataRow{{
+		"id":     {Value: "a-1", Field: api.PrettyField{Name: "id"}},
+		"status": {Value: "ok", Field: api.PrettyField{Name: "status"}},
+		"amount": {Value: 10, Field: api.PrettyField{Name: "amount"}},
+	}}
+	header := func(name string) string {
+		return fmt.Sprintf(`tracking-wider">%s</span>`, name)
+	}
+
+	tests := []struct {
+		name     string
+		field    api.PrettyField
+		expected []string
+		hidden   []string
+	}{
+		{
+			name: "table options",
+			field: api.PrettyField{Name: "orders", Format: api.FormatTable, TableOptions: api.PrettyTable{
+				Fields: []api.PrettyField{{Name: "status"}, {Name: "id"}},
+			}},
+			expected: []string{"status", "id"},
+			hidden:   []string{"amount"},
+		},
+		{
+			name:     "fields",
+			field:    api.PrettyField{Name: "orders", Format: api.FormatTable, Fields: []api.PrettyField{{Name: "amount"}, {Name: "id"}}},
+			expected: []string{"amount", "id"},
+			hidden:   []string{"status"},
+		},
+		{
+			name:     "row keys",
+			field:    api.PrettyField{Name: "orders", Format: api.FormatTable},
+			expected: []string{"amount", "id", "status"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := NewHTMLFormatter().formatTableDataHTML(rows, test.field)
+			last := -1
+			for _, name := range test.expected {
+				index := strings.Index(output, header(name))
+				if index <= last {
+					t.Errorf("expected header %s after the previous header, in order %v:\n%s", name, test.expected, output)
+				}
+				last = index
+			}
+			for _, name := range test.hidden {
+				if strings.Contains(output, header(name)) {
+					t.Errorf("expected no %s column", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// htmlInteractiveScript sorts, searches and hides the columns of tables marked with
+// data-clicky-table, and collapses the nodes of trees. It has no dependencies so
+// interactive reports stay a single file that works offline.
+const htmlInteractiveScript = `<script>
+(function () {
+  function each(list, fn) { Array.prototype.forEach.call(list, fn); }
+
+  function sortKey(cell, numeric) {
+    var value = cell ? cell.getAttribute("data-sort") : "";
+    if (value === null) { value = cell.textContent; }
+    if (numeric) {
+      var n = parseFloat(value);
+      return isNaN(n) ? -Infinity : n;
+    }
+    return value.toLowerCase();
+  }
+
+  each(document.querySelectorAll("[data-clicky-table]"), function (root) {
+    var table = root.querySelector("table");
+    var body = table.tBodies[0];
+    var headers = table.tHead.rows[0].cells;
+    var rows = Array.prototype.slice.call(body.rows);
+    var search = root.querySelector("[data-table-search]");
+    var count = root.querySelector("[data-table-count]");
+    var sorted = { column: -1, dir: 0 };
+
+    function isData(row) { return !row.hasAttribute("data-kind"); }
+    var total = rows.filter(isData).length;
+
+    function render() {
+      var query = search.value.trim().toLowerCase();
+      var order = rows;
+      if (sorted.dir !== 0) {
+        // Group headers and subtotals no longer apply once rows are reordered
+        var numeric = headers[sorted.column].getAttribute("data-sort-type") === "number";
+        order = rows.filter(isData).sort(function (a, b) {
+          var x = sortKey(a.cells[sorted.column], numeric), y = sortKey(b.cells[sorted.column], numeric);
+          return (x < y ? -1 : x > y ? 1 : 0) * sorted.dir;
+        });
+      }
+      var shown = 0;
+      each(rows, function (row) { row.style.display = "none"; });
+      each(order, function (row) {
+        var match = query === "" ? (isData(row) || sorted.dir === 0) :
+          isData(row) && row.textContent.toLowerCase().indexOf(query) >= 0;
+        row.style.display = match ? "" : "none";
+        if (match && isData(row)) { shown++; }
+        body.appendChild(row);
+      });
+      count.textContent = shown === total ? total + " rows" : shown + " of " + total + " rows";
+      each(headers, function (header, i) {
+        var indicator = header.querySelector("[data-sort-indicator]");
+        indicator.textContent = i === sorted.column && sorted.dir !== 0 ? (sorted.dir > 0 ? " ▲" : " ▼") : "";
+        header.setAttribute("aria-sort", i === sorted.column && sorted.dir !== 0 ? (sorted.dir > 0 ? "ascending" : "descending") : "none");
+      });
+    }
+
+    each(headers, function (header, i) {
+      header.addEventListener("click", function () {
+        // Ascending, then descending, then the original order
+        sorted.dir = sorted.column === i ? (sorted.dir === 1 ? -1 : sorted.dir === -1 ? 0 : 1) : 1;
+        sorted.column = i;
+        render();
+      });
+    });
+    search.addEventListener("input", render);
+
+    each(root.querySelectorAll("[data-column-toggle]"), function (toggle) {
+      toggle.addEventListener("change", function () {
+        var column = parseInt(toggle.getAttribute("data-column-toggle"), 10);
+        each(table.rows, function (row) {
+          if (row.cells[column]) { row.cells[column].style.display = toggle.checked ? "" : "none"; }
+        });
+      });
+    });
+    render();
+  });
+
+  function setOpen(toggle, open) {
+    var list = toggle.parentNode.querySelector("ul");
+    if (!list) { return; }
+    list.style.display = open ? "" : "none";
+    toggle.textContent = open ? "▾" : "▸";
+    toggle.setAttribute("aria-expanded", open ? "true" : "false");
+  }
+  each(document.querySelectorAll("[data-tree-toggle]"), function (toggle) {
+    toggle.addEventListener("click", function () {
+      setOpen(toggle, toggle.getAttribute("aria-expanded") !== "true");
+    });
+  });
+  each(document.querySelectorAll("[data-tree-expand]"), function (button) {
+    button.addEventListener("click", function () {
+      var tree = button.parentNode.parentNode;
+      each(tree.querySelectorAll("[data-tree-toggle]"), function (toggle) {
+        setOpen(toggle, button.getAttribute("data-tree-expand") === "true");
+      });
+    });
+  });
+})();
+</script>
+`
+
+// htmlTableToolbar is the search box, row count and column picker of an interactive table
+func htmlTableToolbar(columns []api.PrettyField) string {
+	var result strings.Builder
+	result.WriteString("            <div class=\"flex flex-wrap items-center gap-4 px-6 py-3 border-b border-gray-200\">\n")
+	result.WriteString("                <input type=\"search\" placeholder=\"Search\" aria-label=\"Search rows\" class=\"border border-gray-300 rounded px-2 py-1 text-sm\" data-table-search>\n")
+	result.WriteString("                <span class=\"text-xs text-gray-500\" data-table-count></span>\n")
+	result.WriteString("                <details class=\"text-sm ml-auto\">\n")
+	result.WriteString("                    <summary class=\"cursor-pointer select-none text-gray-600\">Columns</summary>\n")
+	result.WriteString("                    <div class=\"flex flex-wrap gap-x-4 gap-y-1 mt-1\">\n")
+	for i, column := range columns {
+		result.WriteString(fmt.Sprintf("                        <label class=\"inline-flex items-center gap-1 text-gray-700\"><input type=\"checkbox\" checked data-column-toggle=\"%d\">%s</label>\n",
+			i, html.EscapeString(column.Name)))
+	}
+	result.WriteString("                    </div>\n")
+	result.WriteString("                </details>\n")
+	result.WriteString("            </div>\n")
+	return result.String()
+}
+
+// htmlSortKey returns the raw value a cell sorts by, and whether it is a number. Times
+// sort by their Unix milliseconds and booleans as 0 and 1.
+func htmlSortKey(value interface{}, field api.PrettyField) (string, bool) {
+	val := reflect.ValueOf(value)
+	for val.IsValid() && val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return "", false
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return "", false
+	}
+	value = val.Interface()
+
+	if t, ok := value.(time.Time); ok {
+		return strconv.FormatInt(t.UnixMilli(), 10), true
+	}
+	if field.Format == api.FormatDate || field.Type == "date" {
+		if t, ok := filterTime(value); ok {
+			return strconv.FormatInt(t.UnixMilli(), 10), true
+		}
+	}
+	if b, ok := value.(bool); ok {
+		if b {
+			return "1", true
+		}
+		return "0", true
+	}
+	if n, ok := filterNumber(value); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	}
+	return fmt.Sprintf("%v", value), false
+}
+
+// htmlSortType returns number when every value of a column in the data rows is numeric
+func htmlSortType(column api.PrettyField, rows []api.PrettyDataRow) string {
+	numeric := false
+	for _, row := range rows {
+		value, exists := row[column.Name]
+		if row.Kind() != "" || !exists || value.Value == nil {
+			continue
+		}
+		if _, isNumber := htmlSortKey(value.Value, column); !isNumber {
+			return "text"
+		}
+		numeric = true
+	}
+	if numeric {
+		return "number"
+	}
+	return "text"
+}
@@ -0,0 +1,93 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// linkTemplates caches the parsed link templates of fields
+type linkTemplates map[string]*template.Template
+
+// linkRows returns the rows with the links of columns that have a link template,
+// copying the rows only when a column links
+func (l linkTemplates) linkRows(field api.PrettyField, rows []api.PrettyDataRow) ([]api.PrettyDataRow, error) {
+	columns := api.TableColumns(field, rows)
+	var linked []api.PrettyDataRow
+	for i, row := range rows {
+		if row.Kind() != "" {
+			continue
+		}
+
+		var linkedRow api.PrettyDataRow
+		for _, column := range columns {
+			value, ok := row[column.Name]
+			if !ok {
+				continue
+			}
+			url, err := l.link(column, row, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", field.Name, column.Name, err)
+			}
+			if url == "" {
+				continue
+			}
+			if linkedRow == nil {
+				linkedRow = make(api.PrettyDataRow, len(row))
+				for name, v := range row {
+					linkedRow[name] = v
+				}
+			}
+			value.Link = url
+			linkedRow[column.Name] = value
+		}
+		if linkedRow == nil {
+			continue
+		}
+
+		if linked == nil {
+			linked = append([]api.PrettyDataRow(nil), rows...)
+		}
+		linked[i] = linkedRow
+	}
+	if linked == nil {
+		return rows, nil
+	}
+	return linked, nil
+}
+
+// link returns the URL of a value from the link template of its field. The template
+// dot holds the raw values of the row, or of the top-level fields, with value being
+// the field itself. Values missing from the row leave the value without a link.
+func (l linkTemplates) link(field api.PrettyField, row api.PrettyDataRow, value api.FieldValue) (string, error) {
+	text := field.Link
+	if text == "" {
+		text = value.Field.Link
+	}
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, ok := l[text]
+	if !ok {
+		var err error
+		if tmpl, err = template.New("link").Option("missingkey=error").Parse(text); err != nil {
+			return "", fmt.Errorf("invalid link template %q: %w", text, err)
+		}
+		l[text] = tmpl
+	}
+
+	scope := make(map[string]interface{}, len(row)+1)
+	for name, v := range row {
+		scope[name] = v.Value
+	}
+	scope["value"] = value.Value
+
+	var url strings.Builder
+	if err := tmpl.Execute(&url, scope); err != nil || strings.Contains(url.String(), "<no value>") {
+		return "", nil
+	}
+	return strings.TrimSpace(url.String()), nil
+}
@@ -0,0 +1,78 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// issuesReport has a table whose key column links to an issue tracker, and a summary
+// field linking to its own value
+func issuesReport(t *testing.T) *api.PrettyData {
+	data := map[string]interface{}{
+		"project": "PROJ",
+		"issues": []map[string]interface{}{
+			{"id": "PROJ-1", "title": "Crash on start"},
+			{"id": "PROJ-2", "title": "Slow queries"},
+		},
+	}
+	schema := &api.PrettyObject{
+		Fields: []api.PrettyField{
+			{Name: "project", Link: "https://jira/projects/{{.value}}"},
+			{Name: "issues", Format: api.FormatTable, TableOptions: api.PrettyTable{
+				Fields: []api.PrettyField{
+					{Name: "id", Link: "https://jira/browse/{{.id}}"},
+					{Name: "title", Link: "https://jira/search?q={{.missing}}"},
+				},
+			}},
+		},
+	}
+
+	prettyData, err := api.NewStructParser().ParseDataWithSchema(data, schema)
+	if err != nil {
+		t.Fatalf("failed to parse data: %v", err)
+	}
+	return prettyData
+}
+
+func TestLinkTemplates(t *testing.T) {
+	output, err := NewMarkdownFormatter().FormatPrettyData(issuesReport(t))
+	if err != nil {
+		t.Fatalf("markdown failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"[PROJ](https://jira/projects/PROJ)",
+		"[PROJ-1](https://jira/browse/PROJ-1)",
+		"[PROJ-2](https://jira/browse/PROJ-2)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "jira/search") {
+		t.Errorf("expected no link from a template with a missing key:\n%s", output)
+	}
+}
+
+func TestLinkTemplateErrors(t *testing.T) {
+	data := issuesReport(t)
+	data.Schema.Fields[0].Link = "https://jira/{{.value"
+
+	if _, err := ApplyStyleRules(data); err == nil {
+		t.Errorf("expected an error for an invalid link template")
+	}
+}
+
+func TestStripAnsiHyperlinks(t *testing.T) {
+	t.Setenv(api.HyperlinkEnv, "1")
+
+	link := api.Hyperlink("PROJ-1", "https://jira/browse/PROJ-1")
+	if link == "PROJ-1" {
+		t.Fatalf("expected a hyperlink when forced on")
+	}
+	if got := stripAnsi(link); got != "PROJ-1" {
+		t.Errorf("expected the hyperlink to measure as its text, got %q", got)
+	}
+}
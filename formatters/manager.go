@@ -2,6 +2,7 @@ package formatters
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -14,9 +15,14 @@ type FormatManager struct {
 	yamlFormatter     *YAMLFormatter
 	csvFormatter      *CSVFormatter
 	markdownFormatter *MarkdownFormatter
+	asciidocFormatter *AsciiDocFormatter
+	rstFormatter      *RSTFormatter
 	htmlFormatter     *HTMLFormatter
 	prettyFormatter   *PrettyFormatter
 	treeFormatter     *TreeFormatter
+	xlsxFormatter     *XLSXFormatter
+	pdfFormatter      *PDFFormatter
+	ndjsonFormatter   *NDJSONFormatter
 }
 
 // NewFormatManager creates a new format manager with all formatters initialized
@@ -26,9 +32,14 @@ func NewFormatManager() *FormatManager {
 		yamlFormatter:     NewYAMLFormatter(),
 		csvFormatter:      NewCSVFormatter(),
 		markdownFormatter: NewMarkdownFormatter(),
+		asciidocFormatter: NewAsciiDocFormatter(),
+		rstFormatter:      NewRSTFormatter(),
 		htmlFormatter:     NewHTMLFormatter(),
 		prettyFormatter:   NewPrettyFormatter(),
 		treeFormatter:     NewTreeFormatter(api.DefaultTheme(), false, nil),
+		xlsxFormatter:     NewXLSXFormatter(),
+		pdfFormatter:      NewPDFFormatter(),
+		ndjsonFormatter:   NewNDJSONFormatter(),
 	}
 }
 
@@ -58,6 +69,14 @@ func (f FormatManager) JSON(data interface{}) (string, error) {
 	return f.jsonFormatter.Format(data)
 }
 
+// NDJSON formats data as newline delimited JSON, one object per table row
+func (f FormatManager) NDJSON(data interface{}) (string, error) {
+	if f.ndjsonFormatter == nil {
+		f.ndjsonFormatter = NewNDJSONFormatter()
+	}
+	return f.ndjsonFormatter.Format(data)
+}
+
 // YAML implements api.FormatManager.
 func (f FormatManager) YAML(data interface{}) (string, error) {
 	if f.yamlFormatter == nil {
@@ -82,6 +101,22 @@ func (f FormatManager) Markdown(data interface{}) (string, error) {
 	return f.markdownFormatter.Format(data)
 }
 
+// AsciiDoc formats data as AsciiDoc
+func (f FormatManager) AsciiDoc(data interface{}) (string, error) {
+	if f.asciidocFormatter == nil {
+		f.asciidocFormatter = NewAsciiDocFormatter()
+	}
+	return f.asciidocFormatter.Format(data)
+}
+
+// RST formats data as reStructuredText
+func (f FormatManager) RST(data interface{}) (string, error) {
+	if f.rstFormatter == nil {
+		f.rstFormatter = NewRSTFormatter()
+	}
+	return f.rstFormatter.Format(data)
+}
+
 // HTML implements api.FormatManager.
 func (f FormatManager) HTML(data interface{}) (string, error) {
 	if f.htmlFormatter == nil {
@@ -90,12 +125,73 @@ func (f FormatManager) HTML(data interface{}) (string, error) {
 	return f.htmlFormatter.Format(data)
 }
 
+// html returns a copy of the HTML formatter with the HTML options applied
+func (f FormatManager) html(options FormatOptions) *HTMLFormatter {
+	html := NewHTMLFormatter()
+	if f.htmlFormatter != nil {
+		formatter := *f.htmlFormatter
+		html = &formatter
+	}
+	html.UseCDN = options.TailwindCDN
+	html.Interactive = options.Interactive
+	html.Locale = options.Locale
+	if theme, ok := options.SelectedTheme(); ok {
+		html.Theme = &theme
+	}
+	return html
+}
+
+// XLSX formats data as an Excel workbook, returned as a binary string
+func (f FormatManager) XLSX(data interface{}) (string, error) {
+	if f.xlsxFormatter == nil {
+		f.xlsxFormatter = NewXLSXFormatter()
+	}
+	return f.xlsxFormatter.Format(data)
+}
+
+// PDF formats data as a PDF document, returned as a binary string
+func (f FormatManager) PDF(data interface{}) (string, error) {
+	if f.pdfFormatter == nil {
+		f.pdfFormatter = NewPDFFormatter()
+	}
+	prettyData, err := f.ToPrettyData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+	return f.pdfFormatter.Format(prettyData)
+}
+
+// pretty returns a copy of the pretty formatter with the options applied
+func (f FormatManager) pretty(options FormatOptions) *PrettyFormatter {
+	pretty := NewPrettyFormatter()
+	if f.prettyFormatter != nil {
+		formatter := *f.prettyFormatter
+		pretty = &formatter
+	}
+	pretty.NoColor = options.NoColor
+	pretty.TableStyle = options.TableStyle
+	pretty.Theme = options.themed(pretty.Theme)
+	return pretty
+}
+
 // Tree formats data as a tree structure
 func (f FormatManager) Tree(data interface{}) (string, error) {
-	if f.treeFormatter == nil {
-		f.treeFormatter = NewTreeFormatter(api.DefaultTheme(), false, nil)
+	return f.tree(FormatOptions{}).Format(data)
+}
+
+// tree returns the tree formatter, drawn with the glyphs and colours of the selected theme
+func (f FormatManager) tree(options FormatOptions) *TreeFormatter {
+	tree := f.treeFormatter
+	if tree == nil {
+		tree = NewTreeFormatter(api.DefaultTheme(), false, nil)
+	}
+	noColor := tree.NoColor || options.NoColor
+	if theme, ok := options.selectedTheme(); ok {
+		return NewTreeFormatter(theme, noColor, nil)
 	}
-	return f.treeFormatter.Format(data)
+	formatter := *tree
+	formatter.NoColor = noColor
+	return &formatter
 }
 
 // Format implements a generic format method that delegates to specific formatters
@@ -103,18 +199,28 @@ func (f FormatManager) Format(format string, data interface{}) (string, error) {
 	switch format {
 	case "json":
 		return f.JSON(data)
+	case "ndjson", "jsonl":
+		return f.NDJSON(data)
 	case "yaml", "yml":
 		return f.YAML(data)
 	case "csv":
 		return f.CSV(data)
 	case "markdown", "md":
 		return f.Markdown(data)
+	case "asciidoc", "adoc":
+		return f.AsciiDoc(data)
+	case "rst":
+		return f.RST(data)
 	case "html":
 		return f.HTML(data)
 	case "pretty":
 		return f.Pretty(data)
 	case "tree":
 		return f.Tree(data)
+	case "xlsx", "excel":
+		return f.XLSX(data)
+	case "pdf":
+		return f.PDF(data)
 	default:
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
@@ -128,6 +234,19 @@ func (f FormatManager) FormatWithOptions(options FormatOptions, data interface{}
 	}
 
 	logger.Tracef("Formatting with %s", options.Format)
+
+	// Filters and projections are applied to PrettyData, so every format sees the same selection
+	if options.reshapes() {
+		formatHint := "table"
+		if strings.ToLower(options.Format) == "tree" {
+			formatHint = "tree"
+		}
+		prettyData, err := f.ToPrettyDataWithFormatHint(data, formatHint)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+		}
+		return f.FormatWithSchema(prettyData, options)
+	}
 	// If schema is provided, delegate to external handler
 	// (the calling code should handle ParseDataWithSchema and call FormatWithSchema directly)
 
@@ -136,6 +255,9 @@ func (f FormatManager) FormatWithOptions(options FormatOptions, data interface{}
 	case "json":
 		return f.JSON(data)
 
+	case "ndjson", "jsonl":
+		return f.NDJSON(data)
+
 	case "yaml", "yml":
 		return f.YAML(data)
 
@@ -153,63 +275,117 @@ func (f FormatManager) FormatWithOptions(options FormatOptions, data interface{}
 			// Fallback to direct formatting if PrettyData conversion fails
 			return f.markdownFormatter.Format(data)
 		}
-		return f.markdownFormatter.FormatPrettyData(prettyData)
+		return f.markdownFormatter.FormatPrettyData(options.localize(prettyData))
+
+	case "asciidoc", "adoc":
+		if f.asciidocFormatter == nil {
+			f.asciidocFormatter = NewAsciiDocFormatter()
+		}
+		prettyData, err := f.ToPrettyData(data)
+		if err != nil {
+			return f.asciidocFormatter.Format(data)
+		}
+		return f.asciidocFormatter.FormatPrettyData(options.localize(prettyData))
+
+	case "rst":
+		if f.rstFormatter == nil {
+			f.rstFormatter = NewRSTFormatter()
+		}
+		prettyData, err := f.ToPrettyData(data)
+		if err != nil {
+			return f.rstFormatter.Format(data)
+		}
+		return f.rstFormatter.FormatPrettyData(options.localize(prettyData))
 
 	case "html":
-		return f.HTML(data)
+		return f.html(options).Format(data)
 
-	case "table":
-		if f.prettyFormatter == nil {
-			f.prettyFormatter = NewPrettyFormatter()
+	case "xlsx", "excel":
+		if f.xlsxFormatter == nil {
+			f.xlsxFormatter = NewXLSXFormatter()
+		}
+		prettyData, err := f.ToPrettyData(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+		}
+		return f.xlsxFormatter.FormatPrettyData(options.localize(prettyData))
+
+	case "pdf":
+		pdfFormatter := NewPDFFormatter()
+		if options.PDFBackend != "" {
+			pdfFormatter.Backend = options.PDFBackend
+		}
+		prettyData, err := f.ToPrettyDataWithFormatHint(data, "table")
+		if err != nil {
+			return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
 		}
-		f.prettyFormatter.NoColor = options.NoColor
+		return pdfFormatter.Format(options.localize(prettyData))
+
+	case "template":
+		if options.Template == "" {
+			return "", fmt.Errorf("template format requires --template")
+		}
+		templateFormatter := NewTemplateFormatter(options.Template)
+		templateFormatter.NoColor = options.NoColor
+		prettyData, err := f.ToPrettyDataWithFormatHint(data, "table")
+		if err != nil {
+			return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+		}
+		return templateFormatter.FormatPrettyData(options.localize(prettyData))
+
+	case "table":
+		pretty := f.pretty(options)
 		// Force table formatting by setting format hint
 		prettyData, err := f.ToPrettyDataWithFormatHint(data, "table")
 		if err != nil {
 			// Fallback to direct formatting if PrettyData conversion fails
-			return f.prettyFormatter.Format(data)
+			return pretty.Format(data)
 		}
-		return f.prettyFormatter.FormatPrettyData(prettyData)
+		return pretty.FormatPrettyData(options.localize(prettyData))
 
 	case "tree":
-		if f.prettyFormatter == nil {
-			f.prettyFormatter = NewPrettyFormatter()
-		}
-		f.prettyFormatter.NoColor = options.NoColor
+		pretty := f.pretty(options)
 		// Force tree formatting by setting format hint
 		prettyData, err := f.ToPrettyDataWithFormatHint(data, "tree")
 		if err != nil {
 			logger.Debugf("Failed to convert to PrettyData for tree format: %v", err)
 			// Fallback to direct formatting if PrettyData conversion fails
-			return f.prettyFormatter.Format(data)
+			return pretty.Format(data)
 		}
-		return f.prettyFormatter.FormatPrettyData(prettyData)
+		return pretty.FormatPrettyData(options.localize(prettyData))
 
 	case "pretty":
-		if f.prettyFormatter == nil {
-			f.prettyFormatter = NewPrettyFormatter()
-		}
-		f.prettyFormatter.NoColor = options.NoColor
+		pretty := f.pretty(options)
 		// Convert to PrettyData first to handle pretty tags, default slices to table
 		prettyData, err := f.ToPrettyDataWithFormatHint(data, "table")
 		if err != nil {
 			// Fallback to direct formatting if PrettyData conversion fails
-			return f.prettyFormatter.Format(data)
+			return pretty.Format(data)
 		}
-		return f.prettyFormatter.FormatPrettyData(prettyData)
+		return pretty.FormatPrettyData(options.localize(prettyData))
 
 	default:
 		// Default to pretty format
-		if f.prettyFormatter == nil {
-			f.prettyFormatter = NewPrettyFormatter()
-		}
-		f.prettyFormatter.NoColor = options.NoColor
-		return f.prettyFormatter.Format(data)
+		return f.pretty(options).Format(data)
 	}
 }
 
 // FormatToFile formats data and writes to a file if output is specified
 func (f FormatManager) FormatToFile(options FormatOptions, data interface{}) error {
+	if err := options.ResolveFormat(); err != nil {
+		return err
+	}
+
+	// Stream NDJSON rows instead of building the whole output in memory
+	if format := strings.ToLower(options.Format); (format == "ndjson" || format == "jsonl") && !options.reshapes() {
+		return f.streamToFile(options, func(w io.Writer) error {
+			if f.ndjsonFormatter == nil {
+				f.ndjsonFormatter = NewNDJSONFormatter()
+			}
+			return f.ndjsonFormatter.WriteValue(w, data)
+		})
+	}
+
 	// Format the data
 	output, err := f.FormatWithOptions(options, data)
 	if err != nil {
@@ -237,23 +413,51 @@ func (f FormatManager) FormatToFile(options FormatOptions, data interface{}) err
 	return nil
 }
 
-// Excel exports data to Excel format (CSV for now)
-func (f FormatManager) Excel(data interface{}, _ string) error {
-	// For now, we'll just generate CSV which can be opened in Excel
-	// Full Excel support would require a library like excelize
-	_, err := f.CSV(data)
+// streamToFile runs write against the output file, or stdout if no output is specified
+func (f FormatManager) streamToFile(options FormatOptions, write func(w io.Writer) error) error {
+	if options.Output == "" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(options.Output)
 	if err != nil {
-		return fmt.Errorf("failed to generate Excel-compatible CSV: %w", err)
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if options.Verbose {
+		fmt.Fprintf(os.Stderr, "Output written to: %s\n", options.Output)
 	}
-	// Note: The actual file writing would be handled by the caller
 	return nil
 }
 
-// Pdf exports data to PDF format
+// Excel exports data to an Excel workbook at filename
+func (f FormatManager) Excel(data interface{}, filename string) error {
+	output, err := f.XLSX(data)
+	if err != nil {
+		return fmt.Errorf("failed to generate Excel workbook: %w", err)
+	}
+	if err := os.WriteFile(filename, []byte(output), 0o644); err != nil {
+		return fmt.Errorf("failed to write Excel workbook: %w", err)
+	}
+	return nil
+}
+
+// Pdf exports data to a PDF document at filename
 func (f FormatManager) Pdf(data interface{}, filename string) error {
-	// PDF generation would require a library like gofpdf
-	// For now, we'll return an error indicating it's not implemented
-	return fmt.Errorf("PDF export is not yet implemented")
+	output, err := f.PDF(data)
+	if err != nil {
+		return fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	if err := os.WriteFile(filename, []byte(output), 0o644); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
 }
 
 func (f FormatManager) ParseSchema(data interface{}) (*api.PrettyObject, error) {
@@ -267,22 +471,40 @@ func (f FormatManager) ParseSchema(data interface{}) (*api.PrettyObject, error)
 
 // FormatWithSchema handles schema-aware formatting using provided PrettyData
 func (f FormatManager) FormatWithSchema(prettyData *api.PrettyData, options FormatOptions) (string, error) {
+	prettyData, err := options.reshape(prettyData)
+	if err != nil {
+		return "", err
+	}
+	projected := options.reshapes()
+
 	// Handle different output formats for schema-aware data
 	switch strings.ToLower(options.Format) {
 	case "json":
-		// Convert PrettyData back to map for JSON output
-		output := f.prettyDataToMap(prettyData)
 		if f.jsonFormatter == nil {
 			f.jsonFormatter = NewJSONFormatter()
 		}
+		// The projected value keeps the shape of the selection, e.g. a list for $.items
+		if projected {
+			return f.jsonFormatter.FormatValue(prettyData.Original)
+		}
+		// Convert PrettyData back to map for JSON output
+		output := f.prettyDataToMap(prettyData)
 		// Use FormatValue directly to avoid ToPrettyData conversion
 		return f.jsonFormatter.FormatValue(output)
+	case "ndjson", "jsonl":
+		if f.ndjsonFormatter == nil {
+			f.ndjsonFormatter = NewNDJSONFormatter()
+		}
+		return f.ndjsonFormatter.FormatPrettyData(prettyData)
 	case "yaml", "yml":
-		// Convert PrettyData back to map for YAML output
-		output := f.prettyDataToMap(prettyData)
 		if f.yamlFormatter == nil {
 			f.yamlFormatter = NewYAMLFormatter()
 		}
+		if projected {
+			return f.yamlFormatter.FormatValue(prettyData.Original)
+		}
+		// Convert PrettyData back to map for YAML output
+		output := f.prettyDataToMap(prettyData)
 		return f.yamlFormatter.FormatValue(output)
 	case "csv":
 		if f.csvFormatter == nil {
@@ -295,18 +517,113 @@ func (f FormatManager) FormatWithSchema(prettyData *api.PrettyData, options Form
 		}
 		f.markdownFormatter.NoColor = options.NoColor
 		return f.markdownFormatter.FormatPrettyData(prettyData)
+	case "asciidoc", "adoc":
+		if f.asciidocFormatter == nil {
+			f.asciidocFormatter = NewAsciiDocFormatter()
+		}
+		return f.asciidocFormatter.FormatPrettyData(prettyData)
+	case "rst":
+		if f.rstFormatter == nil {
+			f.rstFormatter = NewRSTFormatter()
+		}
+		return f.rstFormatter.FormatPrettyData(prettyData)
 	case "html":
-		if f.htmlFormatter == nil {
-			f.htmlFormatter = NewHTMLFormatter()
+		return f.html(options).Format(prettyData)
+	case "xlsx", "excel":
+		if f.xlsxFormatter == nil {
+			f.xlsxFormatter = NewXLSXFormatter()
+		}
+		return f.xlsxFormatter.FormatPrettyData(prettyData)
+	case "pdf":
+		pdfFormatter := NewPDFFormatter()
+		if options.PDFBackend != "" {
+			pdfFormatter.Backend = options.PDFBackend
 		}
-		return f.htmlFormatter.Format(prettyData)
+		return pdfFormatter.Format(prettyData)
+	case "template":
+		// --template takes precedence over the template: key of the schema
+		path := options.Template
+		if path == "" && prettyData.Schema != nil {
+			path = prettyData.Schema.Template
+		}
+		if path == "" {
+			return "", fmt.Errorf("template format requires --template or a template: key in the schema")
+		}
+		templateFormatter := NewTemplateFormatter(path)
+		templateFormatter.NoColor = options.NoColor
+		return templateFormatter.FormatPrettyData(prettyData)
 	default:
 		// Default to pretty format
-		if f.prettyFormatter == nil {
-			f.prettyFormatter = NewPrettyFormatter()
+		return f.pretty(options).FormatPrettyData(prettyData)
+	}
+}
+
+// Diff compares two data sets, parsing them with options.Schema when set, and formats
+// the differences. Table rows are matched by key, see DiffPrettyData.
+func (f FormatManager) Diff(oldData, newData interface{}, key string, options FormatOptions) (string, error) {
+	parse := func(data interface{}) (*api.PrettyData, error) {
+		if prettyData, ok := data.(*api.PrettyData); ok {
+			return options.reshape(prettyData)
+		}
+		var prettyData *api.PrettyData
+		var err error
+		if options.Schema != nil {
+			prettyData, err = api.NewStructParser().ParseDataWithSchema(data, options.Schema)
+		} else {
+			prettyData, err = ToPrettyData(data)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return options.reshape(prettyData)
+	}
+
+	oldPrettyData, err := parse(oldData)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse old data: %w", err)
+	}
+	newPrettyData, err := parse(newData)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse new data: %w", err)
+	}
+
+	diff, err := DiffPrettyData(oldPrettyData, newPrettyData, key)
+	if err != nil {
+		return "", err
+	}
+	diff.Theme = f.pretty(options).Theme
+	return f.FormatDiff(diff, options)
+}
+
+// FormatDiff formats differences as coloured pretty, HTML or Markdown reports,
+// or as an RFC 6902 JSON patch for json and yaml
+func (f FormatManager) FormatDiff(diff *Diff, options FormatOptions) (string, error) {
+	switch strings.ToLower(options.Format) {
+	case "json":
+		if f.jsonFormatter == nil {
+			f.jsonFormatter = NewJSONFormatter()
 		}
-		f.prettyFormatter.NoColor = options.NoColor
-		return f.prettyFormatter.FormatPrettyData(prettyData)
+		return f.jsonFormatter.FormatValue(diff.Patch())
+	case "yaml", "yml":
+		if f.yamlFormatter == nil {
+			f.yamlFormatter = NewYAMLFormatter()
+		}
+		return f.yamlFormatter.FormatValue(genericValue(diff.Patch()))
+	case "markdown", "md":
+		return diff.Markdown(), nil
+	case "html":
+		html := f.html(options)
+		if !html.IncludeCSS {
+			return diff.HTML(), nil
+		}
+		return html.document("        <div class=\"bg-white rounded-lg shadow p-6\">\n" + diff.HTML() + "\n        </div>\n"), nil
+	case "", "pretty":
+		if options.NoColor {
+			return diff.Pretty().String(), nil
+		}
+		return diff.Pretty().ANSI(), nil
+	default:
+		return "", fmt.Errorf("unsupported diff format: %s", options.Format)
 	}
 }
 
@@ -321,6 +638,7 @@ func (f FormatManager) prettyDataToMap(data *api.PrettyData) map[string]interfac
 
 	// Add table data as arrays
 	for name, rows := range data.Tables {
+		rows = api.DataRows(rows)
 		tableData := make([]map[string]interface{}, len(rows))
 		for i, row := range rows {
 			rowData := make(map[string]interface{})
@@ -3,6 +3,8 @@ package formatters
 import (
 	"strings"
 	"testing"
+
+	"github.com/flanksource/clicky/api"
 )
 
 type TestStruct struct {
@@ -120,6 +122,40 @@ func TestFormatManager(t *testing.T) {
 	})
 }
 
+func TestFormatLocale(t *testing.T) {
+	total := api.PrettyField{Name: "total", Format: api.FormatCurrency}
+	value, err := total.Parse(1234.5)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	data := &api.PrettyData{
+		Schema: &api.PrettyObject{Fields: []api.PrettyField{total}},
+		Values: map[string]api.FieldValue{"total": value},
+	}
+	manager := NewFormatManager()
+
+	for _, format := range []string{"markdown", "html"} {
+		t.Run(format, func(t *testing.T) {
+			output, err := manager.FormatWithOptions(FormatOptions{Format: format, Locale: "de_DE.UTF-8"}, data)
+			if err != nil || !strings.Contains(output, "1.234,50 €") {
+				t.Errorf("expected the total in the locale, got %v:\n%s", err, output)
+			}
+
+			output, err = manager.FormatWithOptions(FormatOptions{Format: format}, data)
+			if err != nil || !strings.Contains(output, "$1,234.50") {
+				t.Errorf("expected --locale to apply to its own call only, got %v:\n%s", err, output)
+			}
+		})
+	}
+
+	if locale := api.CurrentLocale(); locale.Tag != "en-US" {
+		t.Errorf("expected the default locale unchanged, got %s", locale.Tag)
+	}
+	if _, err := manager.FormatWithOptions(FormatOptions{Format: "markdown", Locale: "xx-YY"}, data); err == nil {
+		t.Errorf("expected an error for an unknown locale")
+	}
+}
+
 func TestParsePrettyTag(t *testing.T) {
 	tests := []struct {
 		name       string
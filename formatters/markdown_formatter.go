@@ -43,6 +43,11 @@ func (f *MarkdownFormatter) Format(data interface{}) (string, error) {
 
 // FormatPrettyData formats PrettyData as Markdown
 func (f *MarkdownFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return "", err
+	}
+
 	var sections []string
 	var summaryFields []api.PrettyField
 	var tableFields []api.PrettyField
@@ -105,11 +110,11 @@ func (f *MarkdownFormatter) formatSummaryFieldsData(fields []api.PrettyField, va
 		// Get field name
 		fieldName := field.Name
 		if field.Label != "" {
-			fieldName = field.Label
+			fieldName = field.LabelText().Markdown()
 		}
 
 		// Check if this is an image field
-		if f.isImageField(fieldValue, field) {
+		if isImageField(fieldValue, field) {
 			imageMarkdown := f.formatImageMarkdown(fieldValue, field)
 			if imageMarkdown != "" {
 				result.WriteString(fmt.Sprintf("**%s**: %s\n\n", fieldName, imageMarkdown))
@@ -126,7 +131,7 @@ func (f *MarkdownFormatter) formatSummaryFieldsData(fields []api.PrettyField, va
 }
 
 // isImageField checks if a field value represents an image
-func (f *MarkdownFormatter) isImageField(fieldValue api.FieldValue, field api.PrettyField) bool {
+func isImageField(fieldValue api.FieldValue, field api.PrettyField) bool {
 	// Check if field has image format hint
 	if field.Format == "image" {
 		return true
@@ -134,14 +139,14 @@ func (f *MarkdownFormatter) isImageField(fieldValue api.FieldValue, field api.Pr
 
 	// Check if the value is a string that looks like an image URL or path
 	if strValue, ok := fieldValue.Value.(string); ok {
-		return f.isImageURL(strValue)
+		return isImageURL(strValue)
 	}
 
 	return false
 }
 
 // isImageURL checks if a string represents an image URL or path
-func (f *MarkdownFormatter) isImageURL(s string) bool {
+func isImageURL(s string) bool {
 	if s == "" {
 		return false
 	}
@@ -216,10 +221,10 @@ func (f *MarkdownFormatter) formatTableData(tableData []api.PrettyDataRow, _ api
 		return "*No data*", nil
 	}
 
-	// Get field headers from the first row
+	// Get field headers from the first data row
 	var headers []string
-	for key := range tableData[0] {
-		headers = append(headers, key)
+	for _, column := range api.TableColumns(api.PrettyField{}, tableData) {
+		headers = append(headers, column.Name)
 	}
 	sort.Strings(headers) // Consistent ordering
 
@@ -247,7 +252,7 @@ func (f *MarkdownFormatter) formatTableData(tableData []api.PrettyDataRow, _ api
 			var cellContent string
 			if exists {
 				// Check if this is an image field
-				if f.isImageField(fieldValue, api.PrettyField{Name: header}) {
+				if isImageField(fieldValue, api.PrettyField{Name: header}) {
 					imageMarkdown := f.formatImageMarkdown(fieldValue, api.PrettyField{Name: header})
 					if imageMarkdown != "" {
 						cellContent = imageMarkdown
@@ -260,6 +265,10 @@ func (f *MarkdownFormatter) formatTableData(tableData []api.PrettyDataRow, _ api
 				}
 				// Escape pipe characters in cell content
 				cellContent = strings.ReplaceAll(cellContent, "|", "\\|")
+				// Group headers and aggregate rows are bold
+				if row.Kind() != "" && cellContent != "" {
+					cellContent = "**" + cellContent + "**"
+				}
 			}
 			result.WriteString(fmt.Sprintf("%s | ", cellContent))
 		}
@@ -280,7 +289,7 @@ func (f *MarkdownFormatter) formatTreeData(field api.PrettyField, fieldValue api
 	// Fallback to regular markdown formatting of the value
 	fieldName := field.Name
 	if field.Label != "" {
-		fieldName = field.Label
+		fieldName = field.LabelText().Markdown()
 	}
 
 	return fmt.Sprintf("**%s**: %s", fieldName, fieldValue.Markdown())
@@ -0,0 +1,53 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestMarkupFields(t *testing.T) {
+	data := map[string]interface{}{
+		"result": "[bold]failed[/] in 3s",
+		"jobs": []map[string]interface{}{
+			{"name": "build", "owner": "ci"},
+			{"name": "deploy"},
+		},
+	}
+	schema := &api.PrettyObject{
+		Fields: []api.PrettyField{
+			{Name: "result", Format: api.FormatMarkup, Label: "[italic]Result[/]"},
+			{Name: "reviewer", Default: "[italic]nobody[/]"},
+			{Name: "jobs", Format: api.FormatTable, TableOptions: api.PrettyTable{
+				Fields: []api.PrettyField{
+					{Name: "name"},
+					{Name: "owner", Default: "[bold]unassigned[/]"},
+				},
+			}},
+		},
+	}
+
+	prettyData, err := api.NewStructParser().ParseDataWithSchema(data, schema)
+	if err != nil {
+		t.Fatalf("failed to parse data: %v", err)
+	}
+	output, err := NewMarkdownFormatter().FormatPrettyData(prettyData)
+	if err != nil {
+		t.Fatalf("markdown failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"**failed** in 3s",
+		"*Result*",
+		"*nobody*",
+		"**unassigned**",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "[bold]") {
+		t.Errorf("expected no markup tags in:\n%s", output)
+	}
+}
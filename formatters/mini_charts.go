@@ -0,0 +1,299 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/flanksource/clicky/api"
+	"github.com/flanksource/clicky/api/tailwind"
+	"github.com/flanksource/clicky/formatters/pdf"
+)
+
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	barEighths  = []rune(" ▏▎▍▌▋▊▉█")
+	// brailleDots are the dot bits of the left and right braille columns, top row first
+	brailleDots = [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+)
+
+// miniChart is an inline sparkline, bar or gauge of a numeric field.
+//
+// Sparklines scale a series between its smallest and largest value, bars of a series
+// scale from zero, and scalar bars and gauges scale from 0 to 100 unless the min and
+// max format options are set.
+type miniChart struct {
+	kind     string
+	values   []float64
+	min, max float64
+	width    int
+	// color is a Tailwind class; gauges without one are coloured by the warn and crit thresholds
+	color      string
+	warn, crit float64
+	braille    bool
+}
+
+// newMiniChart reads the values and format options of a sparkline, bar or gauge field,
+// returning false for other fields and for values without numbers
+func newMiniChart(value interface{}, field api.PrettyField) (miniChart, bool) {
+	switch field.Format {
+	case api.FormatSparkline, api.FormatBar, api.FormatGauge:
+	default:
+		return miniChart{}, false
+	}
+	values := miniChartValues(reflect.ValueOf(value))
+	if len(values) == 0 {
+		return miniChart{}, false
+	}
+
+	options := field.FormatOptions
+	chart := miniChart{kind: field.Format, braille: options["chars"] == "braille"}
+	if width, err := strconv.Atoi(options["width"]); err == nil && width > 0 {
+		chart.width = width
+	}
+	if field.Format == api.FormatGauge {
+		values = values[len(values)-1:]
+	}
+	chart.values = values
+	if chart.series() && chart.width > 0 && len(values) > chart.width {
+		values = values[len(values)-chart.width:]
+		chart.values = values
+	}
+
+	switch {
+	case field.Format == api.FormatSparkline:
+		chart.min, chart.max = values[0], values[0]
+		for _, v := range values {
+			chart.min, chart.max = math.Min(chart.min, v), math.Max(chart.max, v)
+		}
+	case chart.series():
+		for _, v := range values {
+			chart.max = math.Max(chart.max, v)
+		}
+	default:
+		chart.max = 100
+	}
+	if n, err := strconv.ParseFloat(options["min"], 64); err == nil {
+		chart.min = n
+	}
+	if n, err := strconv.ParseFloat(options["max"], 64); err == nil {
+		chart.max = n
+	}
+
+	chart.warn, chart.crit = 0.7, 0.9
+	if n, err := strconv.ParseFloat(options["warn"], 64); err == nil {
+		chart.warn = chart.ratio(n)
+	}
+	if n, err := strconv.ParseFloat(options["crit"], 64); err == nil {
+		chart.crit = chart.ratio(n)
+	}
+
+	chart.color = options["color"]
+	if chart.color == "" {
+		for _, class := range strings.Fields(field.Style) {
+			if strings.HasPrefix(class, "text-") && tailwind.IsTailwindColor(class) {
+				chart.color = class
+				break
+			}
+		}
+	}
+	return chart, true
+}
+
+// miniChartValues collects the numbers of a scalar, slice or array
+func miniChartValues(val reflect.Value) []float64 {
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return nil
+	}
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		var values []float64
+		for i := 0; i < val.Len(); i++ {
+			values = append(values, miniChartValues(val.Index(i))...)
+		}
+		return values
+	}
+	if n, ok := filterNumber(val.Interface()); ok {
+		return []float64{n}
+	}
+	if s, ok := val.Interface().(string); ok {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return []float64{n}
+		}
+	}
+	return nil
+}
+
+// series reports whether the chart draws one column per value rather than a single horizontal bar
+func (c miniChart) series() bool {
+	return c.kind == api.FormatSparkline || (c.kind == api.FormatBar && len(c.values) > 1)
+}
+
+// ratio scales a value between min and max, clamped to 0..1
+func (c miniChart) ratio(v float64) float64 {
+	if c.max <= c.min {
+		if c.kind == api.FormatSparkline {
+			return 0.5
+		}
+		return 0
+	}
+	return math.Max(0, math.Min(1, (v-c.min)/(c.max-c.min)))
+}
+
+// class returns the Tailwind color class of the chart
+func (c miniChart) class() string {
+	if c.color != "" {
+		return c.color
+	}
+	if c.kind != api.FormatGauge {
+		return "text-blue-500"
+	}
+	switch r := c.ratio(c.values[0]); {
+	case r >= c.crit:
+		return "text-red-600"
+	case r >= c.warn:
+		return "text-yellow-500"
+	}
+	return "text-green-600"
+}
+
+// hex returns the CSS color of the chart
+func (c miniChart) hex() string {
+	if color := tailwind.Color(c.class()); strings.HasPrefix(color, "#") {
+		return color
+	}
+	return "currentColor"
+}
+
+// label is the value shown after scalar bars and gauges
+func (c miniChart) label() string {
+	if c.series() {
+		return ""
+	}
+	if c.kind == api.FormatGauge {
+		return fmt.Sprintf("%.0f%%", c.ratio(c.values[0])*100)
+	}
+	return strconv.FormatFloat(c.values[0], 'f', -1, 64)
+}
+
+// glyphs draws the chart with block or braille characters
+func (c miniChart) glyphs() string {
+	if c.series() && c.braille {
+		var b strings.Builder
+		for i := 0; i < len(c.values); i += 2 {
+			char := rune(0x2800)
+			for column := 0; column < 2 && i+column < len(c.values); column++ {
+				level := int(math.Round(c.ratio(c.values[i+column]) * 3))
+				char |= brailleDots[column][3-level]
+			}
+			b.WriteRune(char)
+		}
+		return b.String()
+	}
+
+	if c.series() {
+		var b strings.Builder
+		for _, v := range c.values {
+			b.WriteRune(sparkBlocks[int(math.Round(c.ratio(v)*float64(len(sparkBlocks)-1)))])
+		}
+		return b.String()
+	}
+
+	width := c.width
+	if width == 0 {
+		width = 10
+		if c.kind == api.FormatBar {
+			width = 20
+		}
+	}
+	if c.kind == api.FormatGauge {
+		filled := int(math.Round(c.ratio(c.values[0]) * float64(width)))
+		return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	}
+	eighths := int(math.Round(c.ratio(c.values[0]) * float64(width*8)))
+	bar := strings.Repeat("█", eighths/8)
+	if eighths%8 > 0 {
+		bar += string(barEighths[eighths%8])
+	}
+	return bar + strings.Repeat(" ", width-len([]rune(bar)))
+}
+
+// ANSI draws the chart for the terminal, coloured with its Tailwind class
+func (c miniChart) ANSI() string {
+	chart := lipgloss.NewStyle().Foreground(lipgloss.Color(tailwind.Color(c.class()))).Render(c.glyphs())
+	if label := c.label(); label != "" {
+		return chart + " " + label
+	}
+	return chart
+}
+
+// SVG draws the chart as an inline SVG, followed by the label of scalar charts
+func (c miniChart) SVG() string {
+	const height = 16.0
+	color := c.hex()
+	var shapes strings.Builder
+	width := 100.0
+
+	switch {
+	case c.series() && c.kind == api.FormatSparkline:
+		step := 4.0
+		width = math.Max(step*float64(len(c.values)-1), step) + 2
+		points := make([]string, len(c.values))
+		for i, v := range c.values {
+			points[i] = fmt.Sprintf("%.1f,%.1f", 1+step*float64(i), 1+(height-2)*(1-c.ratio(v)))
+		}
+		if len(points) == 1 {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", 1+step, 1+(height-2)*(1-c.ratio(c.values[0]))))
+		}
+		fmt.Fprintf(&shapes, `<polyline points="%s" fill="none" stroke="%s" stroke-width="1.5" stroke-linejoin="round"/>`, strings.Join(points, " "), color)
+	case c.series():
+		step := 5.0
+		width = step * float64(len(c.values))
+		for i, v := range c.values {
+			h := math.Max(height*c.ratio(v), 1)
+			fmt.Fprintf(&shapes, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, step*float64(i), height-h, step-1, h, color)
+		}
+	default:
+		if c.width > 0 {
+			width = float64(c.width) * 5
+		}
+		fmt.Fprintf(&shapes, `<rect x="0" y="4" width="%.1f" height="8" rx="2" fill="#e5e7eb"/>`, width)
+		fmt.Fprintf(&shapes, `<rect x="0" y="4" width="%.1f" height="8" rx="2" fill="%s"/>`, width*c.ratio(c.values[0]), color)
+	}
+
+	numbers := make([]string, len(c.values))
+	for i, v := range c.values {
+		numbers[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	svg := fmt.Sprintf(`<svg class="mini-chart" xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.1f %.0f" role="img" aria-label="%s" style="display:inline-block;vertical-align:middle">%s</svg>`,
+		width, height, width, height, html.EscapeString(c.kind+" "+strings.Join(numbers, ", ")), shapes.String())
+	if label := c.label(); label != "" {
+		return fmt.Sprintf(`<span class="inline-flex items-center gap-2">%s<span class="text-sm text-gray-700">%s</span></span>`, svg, html.EscapeString(label))
+	}
+	return svg
+}
+
+// PDF returns the chart as a PDF table cell drawn with vector lines
+func (c miniChart) PDF() pdf.MiniChart {
+	ratios := make([]float64, len(c.values))
+	for i, v := range c.values {
+		ratios[i] = c.ratio(v)
+	}
+	color := c.hex()
+	if color == "currentColor" {
+		color = "#3b82f6"
+	}
+	return pdf.MiniChart{
+		Ratios:     ratios,
+		Horizontal: !c.series(),
+		Color:      api.Color{Hex: color},
+		Label:      c.label(),
+	}
+}
@@ -0,0 +1,99 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+	"github.com/flanksource/clicky/formatters/pdf"
+)
+
+type capacityReport struct {
+	Name string    `json:"name"`
+	Load []float64 `json:"load" pretty:"sparkline"`
+	Disk float64   `json:"disk" pretty:"gauge"`
+}
+
+func TestMiniCharts(t *testing.T) {
+	chart := func(tag string, value interface{}) miniChart {
+		c, ok := newMiniChart(value, api.ParsePrettyTagWithName("value", tag))
+		if !ok {
+			t.Fatalf("%s: expected a chart for %v", tag, value)
+		}
+		return c
+	}
+
+	t.Run("Glyphs", func(t *testing.T) {
+		tests := []struct {
+			tag      string
+			value    interface{}
+			expected string
+		}{
+			{"sparkline", []int{1, 2, 3, 4, 5, 6, 7, 8}, "▁▂▃▄▅▆▇█"},
+			{"sparkline,width=3", []interface{}{1.0, 9.0, 2.0, 3.0}, "█▁▂"},
+			{"sparkline,chars=braille", []float64{0, 3}, "⡈"},
+			{"bar,width=10", 50, "█████     "},
+			{"bar,width=10", 55, "█████▌    "},
+			{"bar", []float64{0, 4, 8}, "▁▅█"},
+			{"gauge", 95, "██████████"},
+			{"gauge,width=4,max=200", []int{10, 100}, "██░░"},
+		}
+		for _, test := range tests {
+			if got := chart(test.tag, test.value).glyphs(); got != test.expected {
+				t.Errorf("%s %v: expected %q, got %q", test.tag, test.value, test.expected, got)
+			}
+		}
+
+		if _, ok := newMiniChart("n/a", api.PrettyField{Format: api.FormatBar}); ok {
+			t.Errorf("expected no chart for a value without numbers")
+		}
+	})
+
+	t.Run("Colors", func(t *testing.T) {
+		tests := []struct {
+			tag      string
+			value    float64
+			expected string
+		}{
+			{"gauge", 50, "text-green-600"},
+			{"gauge", 80, "text-yellow-500"},
+			{"gauge", 95, "text-red-600"},
+			{"gauge,max=200,warn=100", 150, "text-yellow-500"},
+			{"gauge,color=text-purple-500", 95, "text-purple-500"},
+			{"bar,style=text-orange-600 font-bold", 10, "text-orange-600"},
+		}
+		for _, test := range tests {
+			if got := chart(test.tag, test.value).class(); got != test.expected {
+				t.Errorf("%s %v: expected %q, got %q", test.tag, test.value, test.expected, got)
+			}
+		}
+		if label := chart("gauge,max=200", 50.0).label(); label != "25%" {
+			t.Errorf("expected the gauge percentage, got %q", label)
+		}
+	})
+
+	t.Run("Formatters", func(t *testing.T) {
+		report := capacityReport{Name: "web", Load: []float64{1, 3, 2, 8}, Disk: 72}
+
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{Format: "pretty", NoColor: true}, report)
+		if err != nil {
+			t.Fatalf("pretty failed: %v", err)
+		}
+		if !strings.Contains(output, "▁▃▂█") || !strings.Contains(output, "72%") {
+			t.Errorf("expected block characters in pretty output, got:\n%s", output)
+		}
+
+		output, err = NewFormatManager().FormatWithOptions(FormatOptions{Format: "html"}, report)
+		if err != nil {
+			t.Fatalf("html failed: %v", err)
+		}
+		if !strings.Contains(output, `<svg class="mini-chart"`) || !strings.Contains(output, "<polyline") {
+			t.Errorf("expected inline SVG in HTML output, got:\n%s", output)
+		}
+
+		cell, ok := pdfCell(api.FieldValue{Value: 72.0}, api.PrettyField{Format: api.FormatGauge}).(pdf.MiniChart)
+		if !ok || !cell.Horizontal || cell.Ratios[0] != 0.72 || cell.Label != "72%" {
+			t.Errorf("expected a horizontal PDF gauge, got %+v", cell)
+		}
+	})
+}
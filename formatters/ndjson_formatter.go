@@ -0,0 +1,201 @@
+package formatters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// NDJSONFormatter handles newline delimited JSON (JSON Lines) formatting.
+// Each table row is written as a separate JSON object as soon as it is encoded,
+// so large slices can be streamed without building the whole output in memory.
+type NDJSONFormatter struct {
+	// TableKey is the discriminator field added to rows of named tables, empty disables it
+	TableKey string
+}
+
+// NewNDJSONFormatter creates a new NDJSON formatter
+func NewNDJSONFormatter() *NDJSONFormatter {
+	return &NDJSONFormatter{
+		TableKey: "_table",
+	}
+}
+
+// Format formats data as NDJSON
+func (f *NDJSONFormatter) Format(data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := f.WriteValue(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// FormatPrettyData formats PrettyData as NDJSON
+func (f *NDJSONFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteValue streams data to w as NDJSON. Slices and arrays are written one
+// element per line directly, without first converting them to PrettyData.
+func (f *NDJSONFormatter) WriteValue(w io.Writer, data interface{}) error {
+	val := reflect.ValueOf(data)
+	val, isNil := safeDerefPointer(val)
+	if isNil || !val.IsValid() {
+		return f.writeLine(w, nil, "")
+	}
+
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		return f.writeSlice(w, val)
+	}
+
+	prettyData, err := ToPrettyData(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+	return f.Write(w, prettyData)
+}
+
+// Write streams PrettyData to w as NDJSON. The non-table values are written as
+// the first line, followed by one line per row of each table in schema order.
+func (f *NDJSONFormatter) Write(w io.Writer, data *api.PrettyData) error {
+	if data == nil {
+		return f.writeLine(w, nil, "")
+	}
+
+	// A top-level slice has no table name, so stream its elements as-is
+	if data.Original != nil {
+		val, isNil := safeDerefPointer(reflect.ValueOf(data.Original))
+		if !isNil && (val.Kind() == reflect.Slice || val.Kind() == reflect.Array) {
+			return f.writeSlice(w, val)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if data.Schema == nil {
+		if err := f.writeLine(bw, data.Original, ""); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	summary := map[string]interface{}{}
+	var tables []api.PrettyField
+	for _, field := range data.Schema.Fields {
+		if field.Format == api.FormatTable {
+			if _, ok := data.Tables[field.Name]; ok {
+				tables = append(tables, field)
+			}
+			continue
+		}
+		if field.Format == api.FormatHide {
+			continue
+		}
+		if value, ok := data.Values[field.Name]; ok {
+			summary[field.Name] = rawFieldValue(value)
+		}
+	}
+
+	if len(summary) > 0 || len(tables) == 0 {
+		if err := f.writeLine(bw, summary, ""); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range tables {
+		for _, row := range api.DataRows(data.Tables[table.Name]) {
+			if err := f.writeLine(bw, rawRow(row), table.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeSlice writes each element of a slice on its own line
+func (f *NDJSONFormatter) writeSlice(w io.Writer, val reflect.Value) error {
+	bw := bufio.NewWriter(w)
+	for i := 0; i < val.Len(); i++ {
+		elem, isNil := safeDerefPointer(val.Index(i))
+		var value interface{}
+		if !isNil && elem.CanInterface() {
+			value = elem.Interface()
+		}
+		if err := f.writeLine(bw, value, ""); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// writeLine encodes a single JSON value followed by a newline, adding the
+// table discriminator as the first key of objects from named tables
+func (f *NDJSONFormatter) writeLine(w io.Writer, value interface{}, table string) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode row: %w", err)
+	}
+
+	if table != "" && f.TableKey != "" && len(b) > 1 && b[0] == '{' {
+		key, _ := json.Marshal(f.TableKey)
+		name, _ := json.Marshal(table)
+		var line bytes.Buffer
+		line.WriteByte('{')
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(name)
+		if !bytes.Equal(b, []byte("{}")) {
+			line.WriteByte(',')
+		}
+		line.Write(b[1:])
+		b = line.Bytes()
+	}
+
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// rawRow converts a table row to a map of raw values, dropping hidden columns
+func rawRow(row api.PrettyDataRow) map[string]interface{} {
+	out := make(map[string]interface{}, len(row))
+	for name, value := range row {
+		if value.Field.Format == api.FormatHide {
+			continue
+		}
+		out[name] = rawFieldValue(value)
+	}
+	return out
+}
+
+// rawFieldValue returns the raw value of a FieldValue, matching the JSON
+// formatter which serializes the original data rather than formatted text
+func rawFieldValue(value api.FieldValue) interface{} {
+	if value.Value != nil {
+		return value.Value
+	}
+	if len(value.NestedFields) > 0 {
+		nested := make(map[string]interface{}, len(value.NestedFields))
+		for key, nestedValue := range value.NestedFields {
+			nested[key] = rawFieldValue(nestedValue)
+		}
+		return nested
+	}
+	if value.Text != nil {
+		return strings.TrimSpace(value.Text.String())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+type ndjsonItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price" pretty:"currency"`
+}
+
+type ndjsonOrder struct {
+	ID    string       `json:"id"`
+	Items []ndjsonItem `json:"items" pretty:"table"`
+}
+
+func TestNDJSONFormatter(t *testing.T) {
+	t.Run("Slice", func(t *testing.T) {
+		items := []ndjsonItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2}}
+		var buf bytes.Buffer
+		if err := NewNDJSONFormatter().WriteValue(&buf, items); err != nil {
+			t.Fatalf("NDJSON write failed: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+		}
+		// Rows must match what the JSON formatter produces for the same element
+		expected, _ := json.Marshal(items[0])
+		if lines[0] != string(expected) {
+			t.Errorf("expected %s, got %s", expected, lines[0])
+		}
+	})
+
+	t.Run("TableDiscriminator", func(t *testing.T) {
+		order := ndjsonOrder{ID: "ORD-1", Items: []ndjsonItem{{Name: "a", Price: 1.5}}}
+		output, err := NewFormatManager().Format("ndjson", order)
+		if err != nil {
+			t.Fatalf("NDJSON format failed: %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected summary and one row, got %q", output)
+		}
+		if lines[0] != `{"id":"ORD-1"}` {
+			t.Errorf("expected summary line without a discriminator, got %s", lines[0])
+		}
+		if !strings.HasPrefix(lines[1], `{"_table":"items",`) {
+			t.Errorf("expected row to start with the table discriminator, got %s", lines[1])
+		}
+	})
+
+	t.Run("NoDiscriminator", func(t *testing.T) {
+		data := &api.PrettyData{
+			Schema: &api.PrettyObject{Fields: []api.PrettyField{{Name: "rows", Format: api.FormatTable}}},
+			Tables: map[string][]api.PrettyDataRow{
+				"rows": {{"n": api.FieldValue{Value: 1}}},
+			},
+		}
+		output, err := (&NDJSONFormatter{}).FormatPrettyData(data)
+		if err != nil {
+			t.Fatalf("NDJSON format failed: %v", err)
+		}
+		if strings.TrimSpace(output) != `{"n":1}` {
+			t.Errorf("expected plain row, got %q", output)
+		}
+	})
+
+	t.Run("HiddenColumns", func(t *testing.T) {
+		row := api.PrettyDataRow{
+			"n":      api.FieldValue{Value: 1},
+			"secret": api.FieldValue{Value: "x", Field: api.PrettyField{Name: "secret", Format: api.FormatHide}},
+		}
+		b, _ := json.Marshal(rawRow(row))
+		if string(b) != `{"n":1}` {
+			t.Errorf("expected hidden columns to be dropped, got %s", b)
+		}
+	})
+
+	t.Run("NestedFields", func(t *testing.T) {
+		value := api.FieldValue{NestedFields: map[string]api.FieldValue{
+			"city": {Value: "Cape Town"},
+		}}
+		b, _ := json.Marshal(rawFieldValue(value))
+		if string(b) != `{"city":"Cape Town"}` {
+			t.Errorf("expected nested fields to be serialized as an object, got %s", b)
+		}
+	})
+}
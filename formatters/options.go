@@ -2,6 +2,8 @@ package formatters
 
 import (
 	"flag"
+	"fmt"
+	"os"
 
 	"github.com/spf13/pflag"
 
@@ -9,18 +11,34 @@ import (
 	"github.com/flanksource/commons/logger"
 )
 
+// LocaleEnv is the environment variable holding the default --locale
+const LocaleEnv = "CLICKY_LOCALE"
+
+// ThemeEnv is the environment variable holding the default --theme
+const ThemeEnv = "CLICKY_THEME"
+
 type PrettyMixin interface {
 	Pretty() api.Text
 }
 
 // FormatOptions contains options for formatting operations
 type FormatOptions struct {
-	Format     string
-	NoColor    bool
-	Output     string
-	Verbose    bool
-	DumpSchema bool
-	Schema     *api.PrettyObject // Schema for schema-aware formatting
+	Format      string
+	NoColor     bool
+	Output      string
+	Verbose     bool
+	DumpSchema  bool
+	Schema      *api.PrettyObject // Schema for schema-aware formatting
+	PDFBackend  string            // PDF rendering backend: native (default) or playwright
+	Template    string            // Go template file used by the template format
+	Fields      string            // Comma separated field paths to keep, e.g. id,customer.name
+	Query       string            // JSONPath expression selecting the sub-tree to output
+	Filter      string            // Expression table rows must match, e.g. "amount > 1000"
+	Locale      string            // Locale for numbers, currencies and dates, e.g. de-DE, defaults to $CLICKY_LOCALE
+	TailwindCDN bool              // Load the Tailwind CDN in HTML output instead of embedding the CSS of the classes used
+	Interactive bool              // Make HTML tables sortable and searchable, and trees collapsible
+	TableStyle  string            // Border style of pretty tables, e.g. rounded, ascii, markdown or minimal
+	Theme       string            // Name or path of the theme, e.g. dark or ./ocean.yaml, defaults to $CLICKY_THEME
 
 	// Format-specific boolean flags (mutually exclusive)
 	JSON     bool
@@ -30,6 +48,10 @@ type FormatOptions struct {
 	Pretty   bool
 	HTML     bool
 	PDF      bool
+	XLSX     bool
+
+	// theme is the theme loaded by ResolveFormat
+	theme *api.Theme
 }
 
 func MergeOptions(opts ...FormatOptions) FormatOptions {
@@ -53,6 +75,36 @@ func MergeOptions(opts ...FormatOptions) FormatOptions {
 		if opt.Schema != nil {
 			merged.Schema = opt.Schema
 		}
+		if opt.PDFBackend != "" {
+			merged.PDFBackend = opt.PDFBackend
+		}
+		if opt.Template != "" {
+			merged.Template = opt.Template
+		}
+		if opt.Fields != "" {
+			merged.Fields = opt.Fields
+		}
+		if opt.Query != "" {
+			merged.Query = opt.Query
+		}
+		if opt.Filter != "" {
+			merged.Filter = opt.Filter
+		}
+		if opt.Locale != "" {
+			merged.Locale = opt.Locale
+		}
+		if opt.TailwindCDN {
+			merged.TailwindCDN = true
+		}
+		if opt.Interactive {
+			merged.Interactive = true
+		}
+		if opt.TableStyle != "" {
+			merged.TableStyle = opt.TableStyle
+		}
+		if opt.Theme != "" {
+			merged.Theme = opt.Theme
+		}
 		if opt.JSON {
 			merged.JSON = true
 			continue // Only one format can be set
@@ -81,17 +133,31 @@ func MergeOptions(opts ...FormatOptions) FormatOptions {
 			merged.PDF = true
 			continue // Only one format can be set
 		}
+		if opt.XLSX {
+			merged.XLSX = true
+			continue // Only one format can be set
+		}
 	}
 	return merged
 }
 
 // BindFlags adds formatting flags to the provided flag set
 func BindFlags(flags *flag.FlagSet, options *FormatOptions) {
-	flags.StringVar(&options.Format, "format", "", "Output format: pretty, json, yaml, csv, html, pdf, markdown")
+	flags.StringVar(&options.Format, "format", "", "Output format: pretty, json, ndjson, yaml, csv, html, pdf, xlsx, markdown, asciidoc, rst, template")
 	flags.StringVar(&options.Output, "output", "", "Output file pattern (optional, uses stdout if not specified)")
 	flags.BoolVar(&options.NoColor, "no-color", false, "Disable colored output")
 	flags.BoolVar(&options.Verbose, "verbose", false, "Enable verbose output")
 	flags.BoolVar(&options.DumpSchema, "dump-schema", false, "Dump the schema to stderr for debugging")
+	flags.StringVar(&options.PDFBackend, "pdf-backend", "", "PDF rendering backend: native (default) or playwright")
+	flags.StringVar(&options.Template, "template", "", "Go template file for the template format (html/template for .html files)")
+	flags.StringVar(&options.Fields, "fields", "", "Comma separated fields to output, use a.b for nested fields and table columns")
+	flags.StringVar(&options.Query, "query", "", "JSONPath expression selecting the data to output, e.g. $.items[*]")
+	flags.StringVar(&options.Filter, "filter", "", "Only output table rows matching the expression, e.g. \"status == 'failed' && amount > 1000\"")
+	flags.StringVar(&options.Locale, "locale", "", "Locale for numbers, currencies and dates, e.g. en-US, de-DE, en-IN (default $CLICKY_LOCALE)")
+	flags.BoolVar(&options.TailwindCDN, "tailwind-cdn", false, "Load the Tailwind CDN in HTML output instead of embedding the CSS, which needs network access to view")
+	flags.BoolVar(&options.Interactive, "interactive", false, "Make HTML tables sortable, searchable and their columns hideable, and trees collapsible")
+	flags.StringVar(&options.TableStyle, "table-style", "", "Border style of pretty tables: single, rounded, double, heavy, ascii, markdown, minimal or compact")
+	flags.StringVar(&options.Theme, "theme", "", "Theme name (default, dark, light, notty, auto) or path to a YAML or JSON theme file (default $CLICKY_THEME)")
 
 	// Format-specific flags (mutually exclusive)
 	flags.BoolVar(&options.JSON, "json", false, "Output in JSON format")
@@ -101,15 +167,26 @@ func BindFlags(flags *flag.FlagSet, options *FormatOptions) {
 	flags.BoolVar(&options.Pretty, "pretty", false, "Output in pretty format (default)")
 	flags.BoolVar(&options.HTML, "html", false, "Output in HTML format")
 	flags.BoolVar(&options.PDF, "pdf", false, "Output in PDF format")
+	flags.BoolVar(&options.XLSX, "xlsx", false, "Output in Excel (XLSX) format")
 }
 
 // BindPFlags adds formatting flags to the provided pflag set (for cobra)
 func BindPFlags(flags *pflag.FlagSet, options *FormatOptions) {
-	flags.StringVar(&options.Format, "format", "", "Output format: pretty, json, yaml, csv, html, pdf, markdown")
+	flags.StringVar(&options.Format, "format", "", "Output format: pretty, json, ndjson, yaml, csv, html, pdf, xlsx, markdown, asciidoc, rst, template")
 	flags.StringVar(&options.Output, "output", "", "Output file pattern (optional, uses stdout if not specified)")
 	flags.BoolVar(&options.NoColor, "no-color", false, "Disable colored output")
 	flags.BoolVar(&options.Verbose, "verbose", false, "Enable verbose output")
 	flags.BoolVar(&options.DumpSchema, "dump-schema", false, "Dump the schema to stderr for debugging")
+	flags.StringVar(&options.PDFBackend, "pdf-backend", "", "PDF rendering backend: native (default) or playwright")
+	flags.StringVar(&options.Template, "template", "", "Go template file for the template format (html/template for .html files)")
+	flags.StringVar(&options.Fields, "fields", "", "Comma separated fields to output, use a.b for nested fields and table columns")
+	flags.StringVar(&options.Query, "query", "", "JSONPath expression selecting the data to output, e.g. $.items[*]")
+	flags.StringVar(&options.Filter, "filter", "", "Only output table rows matching the expression, e.g. \"status == 'failed' && amount > 1000\"")
+	flags.StringVar(&options.Locale, "locale", "", "Locale for numbers, currencies and dates, e.g. en-US, de-DE, en-IN (default $CLICKY_LOCALE)")
+	flags.BoolVar(&options.TailwindCDN, "tailwind-cdn", false, "Load the Tailwind CDN in HTML output instead of embedding the CSS, which needs network access to view")
+	flags.BoolVar(&options.Interactive, "interactive", false, "Make HTML tables sortable, searchable and their columns hideable, and trees collapsible")
+	flags.StringVar(&options.TableStyle, "table-style", "", "Border style of pretty tables: single, rounded, double, heavy, ascii, markdown, minimal or compact")
+	flags.StringVar(&options.Theme, "theme", "", "Theme name (default, dark, light, notty, auto) or path to a YAML or JSON theme file (default $CLICKY_THEME)")
 
 	// Format-specific flags (mutually exclusive)
 	flags.BoolVar(&options.JSON, "json", false, "Output in JSON format")
@@ -119,6 +196,7 @@ func BindPFlags(flags *pflag.FlagSet, options *FormatOptions) {
 	flags.BoolVar(&options.Pretty, "pretty", false, "Output in pretty format (default)")
 	flags.BoolVar(&options.HTML, "html", false, "Output in HTML format")
 	flags.BoolVar(&options.PDF, "pdf", false, "Output in PDF format")
+	flags.BoolVar(&options.XLSX, "xlsx", false, "Output in Excel (XLSX) format")
 }
 
 // ResolveFormat resolves the output format from format-specific flags
@@ -141,8 +219,12 @@ func (options *FormatOptions) ResolveFormat() error {
 		selectedFormat = append(selectedFormat, "html")
 	} else if options.PDF {
 		selectedFormat = append(selectedFormat, "pdf")
+	} else if options.XLSX {
+		selectedFormat = append(selectedFormat, "xlsx")
 	} else if options.Pretty {
 		selectedFormat = append(selectedFormat, "pretty")
+	} else if options.Template != "" {
+		selectedFormat = append(selectedFormat, "template")
 	}
 
 	// If a format-specific flag was set, override the --format flag
@@ -154,5 +236,88 @@ func (options *FormatOptions) ResolveFormat() error {
 
 	logger.Tracef("Using format: %s", options.Format)
 
+	if err := ValidateTableStyle(options.TableStyle); err != nil {
+		return err
+	}
+
+	// The theme is kept on the options and applied by the formatters, see themed
+	options.theme = nil
+	if options.Theme == "" {
+		options.Theme = os.Getenv(ThemeEnv)
+	}
+	if options.Theme != "" {
+		theme, err := api.LoadTheme(options.Theme)
+		if err != nil {
+			return err
+		}
+		if err := ValidateTableStyle(theme.TableStyle); err != nil {
+			return fmt.Errorf("theme %s: %w", theme.Name, err)
+		}
+		options.theme = &theme
+	}
+
+	// The locale is applied to the fields of the data when formatting, see localize
+	if options.Locale == "" {
+		options.Locale = os.Getenv(LocaleEnv)
+	}
+	if options.Locale != "" {
+		locale, err := api.LookupLocale(options.Locale)
+		if err != nil {
+			return err
+		}
+		options.Locale = locale.Tag
+	}
+
 	return nil
 }
+
+// reshapes reports whether the options filter or project the data
+func (options FormatOptions) reshapes() bool {
+	return options.Filter != "" || options.Fields != "" || options.Query != ""
+}
+
+// reshape applies the table filters, --filter, --query and --fields to data, in that order,
+// and then the locale
+func (options FormatOptions) reshape(data *api.PrettyData) (*api.PrettyData, error) {
+	data, err := ApplyFilters(data, options.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = Project(data, options.Fields, options.Query); err != nil {
+		return nil, err
+	}
+	return options.localize(data), nil
+}
+
+// SelectedTheme returns the theme of --theme or $CLICKY_THEME loaded by ResolveFormat,
+// and whether one was selected
+func (options FormatOptions) SelectedTheme() (api.Theme, bool) {
+	if options.theme == nil {
+		return api.Theme{}, false
+	}
+	return *options.theme, true
+}
+
+// themed returns the theme of --theme or $CLICKY_THEME, then the theme selected with
+// api.SetTheme, or theme when neither is
+func (options FormatOptions) themed(theme api.Theme) api.Theme {
+	if selected, ok := options.selectedTheme(); ok {
+		return selected
+	}
+	return theme
+}
+
+// selectedTheme returns the theme of --theme or $CLICKY_THEME, then the theme selected
+// with api.SetTheme, and whether either is
+func (options FormatOptions) selectedTheme() (api.Theme, bool) {
+	if selected, ok := options.SelectedTheme(); ok {
+		return selected, true
+	}
+	return api.SelectedTheme()
+}
+
+// localize formats the values of data in the --locale, except for fields that set their own
+// locale, leaving the default locale of api.SetLocale to the CLI
+func (options FormatOptions) localize(data *api.PrettyData) *api.PrettyData {
+	return data.WithLocale(options.Locale)
+}
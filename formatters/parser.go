@@ -441,7 +441,13 @@ func ToPrettyData(data interface{}) (*api.PrettyData, error) {
 				}
 				rows = append(rows, row)
 			}
-			prettyData.Tables[field.Name] = rows
+			prettyData.Tables[field.Name] = api.GroupRows(field, rows)
+		} else if treeNode, ok := treeFieldValue(field, fieldVal); ok {
+			// Keep tree nodes as-is, dereferencing them loses the TreeNode methods of their pointer
+			prettyData.Values[field.Name] = api.FieldValue{
+				Value: treeNode,
+				Field: field,
+			}
 		} else {
 			// Regular field value - use processFieldValue to handle pointers
 			prettyData.Values[field.Name] = api.FieldValue{
@@ -454,6 +460,18 @@ func ToPrettyData(data interface{}) (*api.PrettyData, error) {
 	return prettyData, nil
 }
 
+// treeFieldValue returns the value of a tree field that implements api.TreeNode
+func treeFieldValue(field api.PrettyField, fieldVal reflect.Value) (api.TreeNode, bool) {
+	if field.Format != api.FormatTree || !fieldVal.CanInterface() {
+		return nil, false
+	}
+	if fieldVal.Kind() == reflect.Ptr && fieldVal.IsNil() {
+		return nil, false
+	}
+	treeNode, ok := fieldVal.Interface().(api.TreeNode)
+	return treeNode, ok
+}
+
 // hasTreeStructure checks if a slice contains items with tree-like fields
 func hasTreeStructure(val reflect.Value) bool {
 	if val.Len() == 0 {
@@ -547,21 +565,20 @@ func convertSliceToPrettyData(val reflect.Value) (*api.PrettyData, error) {
 	}
 
 	// Create PrettyData with a single table field
+	field := api.PrettyField{
+		Name:   "data",
+		Format: api.FormatTable,
+		Label:  "Data",
+		Fields: tableFields,
+	}
 
 	return &api.PrettyData{
 		Schema: &api.PrettyObject{
-			Fields: []api.PrettyField{
-				{
-					Name:   "data",
-					Format: api.FormatTable,
-					Label:  "Data",
-					Fields: tableFields,
-				},
-			},
+			Fields: []api.PrettyField{field},
 		},
 		Values: make(map[string]api.FieldValue),
 		Tables: map[string][]api.PrettyDataRow{
-			"data": rows,
+			"data": api.GroupRows(field, rows),
 		},
 		Original: originalData,
 	}, nil
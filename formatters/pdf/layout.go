@@ -1,11 +1,26 @@
 package pdf
 
 import (
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/line"
 	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/border"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
 
 	"github.com/flanksource/clicky/api"
 )
 
+// Tailwind's gray-100, gray-200 and gray-800, the colours of panels and badges without a style
+var (
+	panelTitleBackground = &props.Color{Red: 243, Green: 244, Blue: 246}
+	panelBorderColor     = &props.Color{Red: 229, Green: 231, Blue: 235}
+	badgeTextColor       = &props.Color{Red: 31, Green: 41, Blue: 55}
+)
+
 // GridItem represents an item in the grid layout
 type GridItem struct {
 	Widget  Widget
@@ -65,3 +80,125 @@ func (g GridLayout) drawRow(b *Builder, items []GridItem) {
 		}
 	}
 }
+
+// drawLayout draws a text with a layout: panels as bordered rows, columns as the columns
+// of a row, rules as lines and badges as filled cells
+func (t Text) drawLayout(b *Builder, apiText api.Text) {
+	switch apiText.Layout.Kind {
+	case api.LayoutRule:
+		class := layoutClass(apiText)
+		lineProps := props.Line{Thickness: 0.3, SizePercent: 100, OffsetPercent: 50, Color: panelBorderColor}
+		if class.Foreground != nil {
+			lineProps.Color = b.style.ConvertColor(*class.Foreground)
+		}
+		if apiText.Layout.Title == "" {
+			b.maroto.AddRow(4, col.New(12).Add(line.New(lineProps)))
+			return
+		}
+		labelProps := b.style.ConvertToTextProps(class)
+		labelProps.Style = fontstyle.Bold
+		b.maroto.AddRow(8,
+			col.New(3).Add(text.New(apiText.Layout.Title, *labelProps)),
+			col.New(9).Add(line.New(lineProps)))
+
+	case api.LayoutColumns:
+		sizes := gridSizes(apiText.Layout.Ratios, len(apiText.Children))
+		cols := make([]core.Col, len(apiText.Children))
+		for i, child := range apiText.Children {
+			cols[i] = t.layoutCol(b, child, sizes[i])
+		}
+		b.maroto.AddRows(row.New().Add(cols...))
+
+	case api.LayoutBadge:
+		size := minInt(len(apiText.String())/8+2, 12)
+		cols := []core.Col{t.layoutCol(b, apiText, size)}
+		if size < 12 {
+			cols = append(cols, col.New(12-size))
+		}
+		b.maroto.AddRow(7, cols...)
+
+	default:
+		b.maroto.AddRows(row.New().Add(t.layoutCol(b, apiText, 12)))
+	}
+	b.maroto.AddRows(row.New(2))
+}
+
+// layoutCol returns a grid column of size holding a panel, badge or the plain text of
+// other content
+func (t Text) layoutCol(b *Builder, apiText api.Text, size int) core.Col {
+	column := col.New(size)
+	class := layoutClass(apiText)
+	if apiText.Layout == nil {
+		return column.Add(text.New(apiText.String(), *b.style.ConvertToTextProps(class)))
+	}
+	body := api.Text{Content: apiText.Content, Children: apiText.Children}.String()
+
+	switch apiText.Layout.Kind {
+	case api.LayoutBadge:
+		if apiText.Style == "" && apiText.Class == (api.Class{}) {
+			class = api.ResolveStyles("bg-gray-100")
+		}
+		badgeProps := b.style.ConvertToTextProps(class)
+		badgeProps.Size, badgeProps.Style, badgeProps.Align, badgeProps.Top = 9, fontstyle.Bold, align.Center, 1.5
+		if badgeProps.Color == nil {
+			badgeProps.Color = badgeTextColor
+		}
+		if apiText.Href != "" {
+			url := apiText.Href
+			badgeProps.Hyperlink = &url
+		}
+		cell := &props.Cell{BackgroundColor: panelTitleBackground}
+		if class.Background != nil {
+			cell.BackgroundColor = b.style.ConvertBackgroundColor(*class.Background)
+		}
+		return column.Add(text.New(body, *badgeProps)).WithStyle(cell)
+
+	case api.LayoutPanel:
+		top := 2.0
+		if title := apiText.Layout.Title; title != "" {
+			titleProps := b.style.ConvertToTextProps(class)
+			titleProps.Style, titleProps.Top, titleProps.Left, titleProps.Right = fontstyle.Bold, 2, 3, 3
+			column.Add(text.New(title, *titleProps))
+			top = 3 + b.style.CalculateTextHeight(class)
+		}
+		bodyProps := b.style.ConvertToTextProps(api.Class{})
+		bodyProps.Top, bodyProps.Left, bodyProps.Right, bodyProps.Bottom = top, 3, 3, 2
+		return column.Add(text.New(body, *bodyProps)).WithStyle(&props.Cell{
+			BorderType:      border.Full,
+			BorderColor:     panelBorderColor,
+			BorderThickness: 0.3,
+		})
+	}
+	return column.Add(text.New(body, *b.style.ConvertToTextProps(class)))
+}
+
+// layoutClass returns the class of a layout, resolving its Tailwind style
+func layoutClass(apiText api.Text) api.Class {
+	if apiText.Style == "" {
+		return apiText.Class
+	}
+	return mergeClasses(apiText.Class, api.ResolveStyles(apiText.Style))
+}
+
+// gridSizes divides the 12 column grid between count columns relative to ratios
+func gridSizes(ratios []int, count int) []int {
+	ratio := func(i int) int {
+		if i < len(ratios) && ratios[i] > 0 {
+			return ratios[i]
+		}
+		return 1
+	}
+	total := 0
+	for i := 0; i < count; i++ {
+		total += ratio(i)
+	}
+
+	sizes := make([]int, count)
+	remaining := 12
+	for i := range sizes {
+		sizes[i] = maxInt(12*ratio(i)/total, 1)
+		remaining -= sizes[i]
+	}
+	sizes[count-1] = maxInt(sizes[count-1]+remaining, 1)
+	return sizes
+}
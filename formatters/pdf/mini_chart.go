@@ -0,0 +1,101 @@
+package pdf
+
+import (
+	"github.com/johnfercher/go-tree/node"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/linestyle"
+	"github.com/johnfercher/maroto/v2/pkg/consts/orientation"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/core/entity"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// MiniChart is a sparkline, bar or gauge drawn with vector lines inside a table cell
+type MiniChart struct {
+	// Ratios are the values scaled to 0..1
+	Ratios []float64 `json:"ratios,omitempty"`
+	// Horizontal draws the first ratio as a filled bar over a track, otherwise each ratio is a column
+	Horizontal bool      `json:"horizontal,omitempty"`
+	Color      api.Color `json:"color,omitempty"`
+	// Label is drawn right aligned after the chart
+	Label string `json:"label,omitempty"`
+}
+
+// Components returns the maroto components drawing the chart and its label
+func (m MiniChart) Components(b *Builder) []core.Component {
+	components := []core.Component{&miniChartComponent{chart: m, color: b.style.ConvertColor(m.Color)}}
+	if m.Label != "" {
+		components = append(components, text.New(m.Label, props.Text{Size: 8, Align: align.Right, Top: 2}))
+	}
+	return components
+}
+
+// miniChartComponent renders a MiniChart with the provider's lines
+type miniChartComponent struct {
+	chart  MiniChart
+	color  *props.Color
+	config *entity.Config
+}
+
+// GetStructure returns the structure of the chart
+func (c *miniChartComponent) GetStructure() *node.Node[core.Structure] {
+	return node.New(core.Structure{Type: "mini_chart"})
+}
+
+// SetConfig sets the config
+func (c *miniChartComponent) SetConfig(config *entity.Config) {
+	c.config = config
+}
+
+// GetHeight returns the height of the cell, the chart scales to fit it
+func (c *miniChartComponent) GetHeight(_ core.Provider, cell *entity.Cell) float64 {
+	return cell.Height
+}
+
+// Render draws the chart in the middle of the cell, leaving room for the label
+func (c *miniChartComponent) Render(provider core.Provider, cell *entity.Cell) {
+	if len(c.chart.Ratios) == 0 {
+		return
+	}
+	area := entity.Cell{X: cell.X + 1, Y: cell.Y + cell.Height*0.2, Width: cell.Width - 2, Height: cell.Height * 0.6}
+	if c.chart.Label != "" {
+		area.Width = cell.Width * 0.65
+	}
+
+	if c.chart.Horizontal {
+		track := &props.Line{
+			Color:         &props.Color{Red: 229, Green: 231, Blue: 235},
+			Style:         linestyle.Solid,
+			Thickness:     area.Height * 0.6,
+			Orientation:   orientation.Horizontal,
+			OffsetPercent: 50,
+			SizePercent:   100,
+		}
+		provider.AddLine(&area, track)
+		if ratio := c.chart.Ratios[0]; ratio > 0 {
+			fill := *track
+			fill.Color = c.color
+			filled := area
+			filled.Width = area.Width * ratio
+			provider.AddLine(&filled, &fill)
+		}
+		return
+	}
+
+	step := area.Width / float64(len(c.chart.Ratios))
+	for i, ratio := range c.chart.Ratios {
+		height := area.Height * max(ratio, 0.05)
+		column := entity.Cell{X: area.X + step*float64(i), Y: area.Y + area.Height - height, Width: step, Height: height}
+		provider.AddLine(&column, &props.Line{
+			Color:         c.color,
+			Style:         linestyle.Solid,
+			Thickness:     step * 0.7,
+			Orientation:   orientation.Vertical,
+			OffsetPercent: 50,
+			SizePercent:   100,
+		})
+	}
+}
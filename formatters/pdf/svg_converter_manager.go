@@ -25,6 +25,20 @@ func NewSVGConverterManager() *SVGConverterManager {
 	return manager
 }
 
+// NewLocalSVGConverterManager creates a converter manager with the locally installed
+// converters only, Inkscape and RSVG, leaving out Playwright, which may download a browser
+func NewLocalSVGConverterManager() *SVGConverterManager {
+	manager := &SVGConverterManager{
+		converters: []SVGConverter{},
+	}
+	for _, converter := range []SVGConverter{NewInkscapeConverter(), NewRSVGConverter()} {
+		if converter.IsAvailable() {
+			manager.converters = append(manager.converters, converter)
+		}
+	}
+	return manager
+}
+
 // autoDetectConverters discovers available converters on the system
 func (m *SVGConverterManager) autoDetectConverters() {
 	// Try converters in order of preference: Inkscape -> RSVG -> Playwright
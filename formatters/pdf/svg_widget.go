@@ -2,18 +2,26 @@ package pdf
 
 import (
 	"bytes"
+	"context"
+	"encoding/xml"
 	"fmt"
 	"image"
 	"image/png"
+	"io"
+	"math"
+	"os"
 	"strconv"
 	"strings"
 
+	"github.com/johnfercher/go-tree/node"
 	"github.com/johnfercher/maroto/v2/pkg/components/col"
 	marotoimages "github.com/johnfercher/maroto/v2/pkg/components/image"
 	"github.com/johnfercher/maroto/v2/pkg/components/text"
 	"github.com/johnfercher/maroto/v2/pkg/consts/align"
 	"github.com/johnfercher/maroto/v2/pkg/consts/extension"
 	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/core/entity"
 	"github.com/johnfercher/maroto/v2/pkg/props"
 	"github.com/srwiley/oksvg"
 	"github.com/srwiley/rasterx"
@@ -69,16 +77,226 @@ func (w SVGWidget) Draw(b *Builder) error {
 	return nil
 }
 
+// SVGContent renders SVG markup, such as a chart, as an image row. The markup is
+// converted with Inkscape or rsvg-convert when installed, otherwise the shapes are
+// rasterized with oksvg and the text elements are drawn over them as PDF text
+type SVGContent struct {
+	Content string `json:"content"`
+	// Height of the row in mm, defaulting to 80
+	Height float64 `json:"height,omitempty"`
+}
+
+// Draw implements the Widget interface
+func (s SVGContent) Draw(b *Builder) error {
+	height := s.Height
+	if height == 0 {
+		height = 80
+	}
+
+	if manager := NewLocalSVGConverterManager(); len(manager.GetAvailableConverters()) > 0 {
+		if pngBytes, err := s.convert(manager); err == nil {
+			b.maroto.AddRow(height, col.New(12).Add(marotoimages.NewFromBytes(pngBytes, extension.Png)))
+			return nil
+		}
+	}
+
+	pngBytes, err := rasterizeSVG([]byte(s.Content), oksvg.IgnoreErrorMode)
+	if err != nil {
+		return fmt.Errorf("failed to convert SVG to PNG: %w", err)
+	}
+	width, svgHeight, err := SVGWidget{}.extractSVGDimensions([]byte(s.Content))
+	if err != nil {
+		width, svgHeight = 100, 100
+	}
+	texts, err := parseSVGTexts(s.Content)
+	if err != nil {
+		return fmt.Errorf("failed to parse SVG: %w", err)
+	}
+	b.maroto.AddRow(height, col.New(12).Add(&svgContentComponent{
+		png:    pngBytes,
+		width:  width,
+		height: svgHeight,
+		texts:  texts,
+	}))
+	return nil
+}
+
+// convert converts the markup to a PNG with the converters of the manager
+func (s SVGContent) convert(manager *SVGConverterManager) ([]byte, error) {
+	file, err := os.CreateTemp("", "content_*.svg")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(s.Content)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write SVG: %w", err)
+	}
+
+	outputPath := strings.TrimSuffix(file.Name(), ".svg") + ".png"
+	defer os.Remove(outputPath)
+	options := &ConvertOptions{Format: "png", DPI: 288}
+	if err := manager.ConvertWithFallback(context.Background(), file.Name(), outputPath, options); err != nil {
+		return nil, err
+	}
+	if err := validatePNGFile(outputPath); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(outputPath)
+}
+
+// svgText is a text element of SVG markup, in the user units of the SVG
+type svgText struct {
+	Content string
+	X, Y    float64
+	// Anchor is the text-anchor: start, middle or end
+	Anchor   string
+	FontSize float64
+	Bold     bool
+	Fill     string
+}
+
+// parseSVGTexts returns the text elements of SVG markup, which oksvg does not draw
+func parseSVGTexts(content string) ([]svgText, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	var texts []svgText
+	var current *svgText
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return texts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "text" {
+				continue
+			}
+			current = &svgText{Anchor: "start", FontSize: 16}
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "x":
+					current.X, _ = strconv.ParseFloat(attr.Value, 64)
+				case "y":
+					current.Y, _ = strconv.ParseFloat(attr.Value, 64)
+				case "text-anchor":
+					current.Anchor = attr.Value
+				case "font-size":
+					if size, err := strconv.ParseFloat(strings.TrimSuffix(attr.Value, "px"), 64); err == nil {
+						current.FontSize = size
+					}
+				case "font-weight":
+					current.Bold = attr.Value == "bold"
+				case "fill":
+					current.Fill = attr.Value
+				}
+			}
+		case xml.CharData:
+			if current != nil {
+				current.Content += string(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "text" && current != nil {
+				if current.Content = strings.TrimSpace(current.Content); current.Content != "" {
+					texts = append(texts, *current)
+				}
+				current = nil
+			}
+		}
+	}
+}
+
+// svgContentComponent draws rasterized SVG shapes scaled to fit the cell, with the
+// text elements of the SVG as PDF text at the same positions
+type svgContentComponent struct {
+	png           []byte
+	width, height float64
+	texts         []svgText
+	config        *entity.Config
+}
+
+// GetStructure returns the structure of the SVG content
+func (c *svgContentComponent) GetStructure() *node.Node[core.Structure] {
+	return node.New(core.Structure{Type: "svg_content"})
+}
+
+// SetConfig sets the config
+func (c *svgContentComponent) SetConfig(config *entity.Config) {
+	c.config = config
+}
+
+// GetHeight returns the height of the cell, the content scales to fit it
+func (c *svgContentComponent) GetHeight(_ core.Provider, cell *entity.Cell) float64 {
+	return cell.Height
+}
+
+// Render draws the image centered in the cell and the text elements over it
+func (c *svgContentComponent) Render(provider core.Provider, cell *entity.Cell) {
+	// Fit the SVG in the cell, keeping its aspect ratio
+	scale := math.Min(cell.Width/c.width, cell.Height/c.height)
+	area := entity.Cell{Width: c.width * scale, Height: c.height * scale}
+	area.X = cell.X + (cell.Width-area.Width)/2
+	area.Y = cell.Y + (cell.Height-area.Height)/2
+	provider.AddImageFromBytes(c.png, &area, &props.Rect{Percent: 100}, extension.Png)
+
+	for _, t := range c.texts {
+		prop := props.Text{
+			// SVG font sizes are in user units, scaled to mm and then to points
+			Size:  t.FontSize * scale * 72 / 25.4,
+			Style: fontstyle.Normal,
+			Align: align.Left,
+		}
+		if t.Bold {
+			prop.Style = fontstyle.Bold
+		}
+		if strings.HasPrefix(t.Fill, "#") {
+			r, g, b := hexToRGB(t.Fill)
+			prop.Color = &props.Color{Red: r, Green: g, Blue: b}
+		}
+		if c.config != nil && c.config.DefaultFont != nil {
+			prop.MakeValid(c.config.DefaultFont)
+		}
+
+		// The y of SVG text is its baseline, which maroto places a font height below the cell top
+		fontHeight := provider.GetFontHeight(&props.Font{Family: prop.Family, Style: prop.Style, Size: prop.Size})
+		textCell := entity.Cell{
+			X:      area.X + t.X*scale,
+			Y:      area.Y + t.Y*scale - fontHeight,
+			Width:  area.Width,
+			Height: fontHeight,
+		}
+		switch t.Anchor {
+		case "middle":
+			textCell.X -= area.Width / 2
+			prop.Align = align.Center
+		case "end":
+			textCell.X -= area.Width
+			prop.Align = align.Right
+		}
+		provider.AddText(t.Content, &textCell, &prop)
+	}
+}
+
 // convertSVGToPNG converts SVG bytes to PNG bytes with aspect ratio preservation
 func (w SVGWidget) convertSVGToPNG(svgBytes []byte) ([]byte, error) {
+	return rasterizeSVG(svgBytes, oksvg.StrictErrorMode)
+}
+
+// rasterizeSVG renders SVG bytes to a PNG with oksvg, which skips elements it cannot
+// draw, such as text, unless the error mode is strict
+func rasterizeSVG(svgBytes []byte, mode oksvg.ErrorMode) ([]byte, error) {
 	// Parse SVG using oksvg
-	icon, err := oksvg.ReadIconStream(bytes.NewReader(svgBytes), oksvg.StrictErrorMode)
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(svgBytes), mode)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SVG: %w", err)
 	}
 
 	// Extract viewBox or use default dimensions
-	svgWidth, svgHeight, err := w.extractSVGDimensions(svgBytes)
+	svgWidth, svgHeight, err := SVGWidget{}.extractSVGDimensions(svgBytes)
 	if err != nil {
 		// Fall back to default square aspect ratio
 		svgWidth, svgHeight = 100, 100
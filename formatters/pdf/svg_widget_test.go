@@ -255,3 +255,30 @@ func TestSVGWidget_ManagerFallback(t *testing.T) {
 		}
 	})
 }
+
+func TestSVGContent_DrawsTextWithoutConverters(t *testing.T) {
+	// Without Inkscape or rsvg-convert on the PATH the shapes are rasterized and the text is drawn as PDF text
+	t.Setenv("PATH", t.TempDir())
+	assert.Empty(t, NewLocalSVGConverterManager().GetAvailableConverters())
+
+	svgContent := `<svg xmlns="http://www.w3.org/2000/svg" width="480" height="240" viewBox="0 0 480 240">` +
+		`<rect x="48" y="40" width="100" height="180" fill="#3b82f6"><title>tooltip</title></rect>` +
+		`<text x="240" y="22" text-anchor="middle" font-size="14" font-weight="bold" fill="#111827">Revenue</text>` +
+		`<text x="98" y="236" text-anchor="middle" font-size="10" fill="#374151">North &amp; South</text>` +
+		`</svg>`
+
+	texts, err := parseSVGTexts(svgContent)
+	require.NoError(t, err)
+	require.Len(t, texts, 2)
+	assert.Equal(t, svgText{Content: "Revenue", X: 240, Y: 22, Anchor: "middle", FontSize: 14, Bold: true, Fill: "#111827"}, texts[0])
+	assert.Equal(t, "North & South", texts[1].Content)
+
+	builder := NewBuilder()
+	require.NoError(t, builder.DrawWidget(SVGContent{Content: svgContent}))
+	doc, err := builder.GetMaroto().Generate()
+	require.NoError(t, err)
+	output := string(doc.GetBytes())
+	assert.Contains(t, output, "(Revenue) Tj")
+	assert.Contains(t, output, "(North & South) Tj")
+	assert.NotContains(t, output, "tooltip")
+}
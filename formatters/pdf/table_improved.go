@@ -16,15 +16,16 @@ import (
 
 // TableImproved widget for rendering tables in PDF with dynamic columns
 type TableImproved struct {
-	Headers           []string    `json:"headers,omitempty"`
-	Rows              [][]any     `json:"rows,omitempty"`
-	HeaderStyle       api.Class   `json:"header_style,omitempty"`
-	RowStyle          api.Class   `json:"row_style,omitempty"`
-	CellPadding       api.Padding `json:"cell_padding,omitempty"`
-	AlternateRowColor bool        `json:"alternate_row_color,omitempty"`
-	ShowBorders       bool        `json:"show_borders,omitempty"`
-	ColumnAlignments  []string    `json:"column_alignments,omitempty"` // left, center, right for each column
-	ColumnWidths      []int       `json:"column_widths,omitempty"`     // Custom column widths (sum should be 12)
+	Headers           []string      `json:"headers,omitempty"`
+	Rows              [][]any       `json:"rows,omitempty"`
+	HeaderStyle       api.Class     `json:"header_style,omitempty"`
+	RowStyle          api.Class     `json:"row_style,omitempty"`
+	CellPadding       api.Padding   `json:"cell_padding,omitempty"`
+	AlternateRowColor bool          `json:"alternate_row_color,omitempty"`
+	ShowBorders       bool          `json:"show_borders,omitempty"`
+	ColumnAlignments  []string      `json:"column_alignments,omitempty"` // left, center, right for each column
+	ColumnWidths      []int         `json:"column_widths,omitempty"`     // Custom column widths (sum should be 12)
+	CellStyles        [][]api.Class `json:"cell_styles,omitempty"`       // Per-cell styles merged over RowStyle, indexed [row][column]
 }
 
 // Draw implements the Widget interface
@@ -207,18 +208,49 @@ func (ti TableImproved) drawDataRows(b *Builder, colWidths []int, baseHeight flo
 		for colIndex := 0; colIndex < len(colWidths) && colIndex < len(dataRow); colIndex++ {
 			cellText := fmt.Sprintf("%v", dataRow[colIndex])
 
-			// Apply alignment
+			// Apply per-cell styling on top of the row style
 			textProps := *rowTextProps
+			cellStyle := ti.cellStyle(rowIndex, colIndex)
+			if cellStyle.Name != "" {
+				resolved := api.ResolveStyles(ti.RowStyle.Name, cellStyle.Name)
+				if resolved.Font == nil {
+					resolved.Font = &api.Font{}
+				}
+				if resolved.Font.Size == 0 {
+					resolved.Font.Size = rowStyle.Font.Size
+				}
+				textProps = *b.style.ConvertToTextProps(resolved)
+				textProps.Left = rowTextProps.Left
+				textProps.Top = rowTextProps.Top
+				cellStyle = resolved
+			}
+
+			// Apply alignment
 			if colIndex < len(ti.ColumnAlignments) {
 				textProps.Align = ti.parseAlignment(ti.ColumnAlignments[colIndex])
 			}
 
-			cellCol := col.New(colWidths[colIndex]).Add(
-				text.New(cellText, textProps),
-			)
+			// Links are clickable and blue unless the cell has its own colour
+			if link, ok := dataRow[colIndex].(Link); ok {
+				url := link.URL
+				textProps.Hyperlink = &url
+				if cellStyle.Foreground == nil {
+					textProps.Color = linkColor
+				}
+			}
+
+			components := []core.Component{text.New(cellText, textProps)}
+			if chart, ok := dataRow[colIndex].(MiniChart); ok {
+				components = chart.Components(b)
+			}
+			cellCol := col.New(colWidths[colIndex]).Add(components...)
 
-			// Add alternating background if enabled
-			if ti.AlternateRowColor && rowIndex%2 == 1 {
+			// Cell background takes precedence over alternating rows
+			if cellStyle.Background != nil {
+				cellCol = cellCol.WithStyle(&props.Cell{
+					BackgroundColor: b.style.ConvertBackgroundColor(*cellStyle.Background),
+				})
+			} else if ti.AlternateRowColor && rowIndex%2 == 1 {
 				cellCol = cellCol.WithStyle(&props.Cell{
 					BackgroundColor: altBgColor,
 				})
@@ -243,6 +275,14 @@ func (ti TableImproved) drawDataRows(b *Builder, colWidths []int, baseHeight flo
 	}
 }
 
+// cellStyle returns the style override for a cell, if any
+func (ti TableImproved) cellStyle(rowIndex, colIndex int) api.Class {
+	if rowIndex < len(ti.CellStyles) && colIndex < len(ti.CellStyles[rowIndex]) {
+		return ti.CellStyles[rowIndex][colIndex]
+	}
+	return api.Class{}
+}
+
 // drawHorizontalLine draws a horizontal line
 func (ti TableImproved) drawHorizontalLine(b *Builder, thickness float64, grayLevel, totalColumns int) {
 	b.maroto.AddRow(0.5, col.New(totalColumns).Add(line.New(props.Line{
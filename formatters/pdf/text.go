@@ -10,10 +10,25 @@ import (
 	"github.com/johnfercher/maroto/v2/pkg/components/text"
 	"github.com/johnfercher/maroto/v2/pkg/consts/align"
 	"github.com/johnfercher/maroto/v2/pkg/consts/breakline"
+	"github.com/johnfercher/maroto/v2/pkg/props"
 
 	"github.com/flanksource/clicky/api"
 )
 
+// Link is a table cell whose text opens URL when clicked
+type Link struct {
+	Text string `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// String returns the text of the link, which is what a cell displays
+func (l Link) String() string {
+	return l.Text
+}
+
+// linkColor is the colour of link text, Tailwind's blue-600
+var linkColor = &props.Color{Red: 37, Green: 99, Blue: 235}
+
 // Text widget for rendering text in PDF
 type Text struct {
 	Text       api.Text `json:"text,omitempty"`
@@ -41,6 +56,15 @@ func (t Text) Draw(b *Builder) error {
 
 // drawTextWithChildren recursively draws text and its children
 func (t Text) drawTextWithChildren(b *Builder, apiText api.Text) {
+	if apiText.Layout != nil {
+		t.drawLayout(b, apiText)
+		return
+	}
+	if isOutputRuns(apiText) {
+		t.drawOutputLines(b, apiText)
+		return
+	}
+
 	// Calculate height for this text
 	height := b.style.CalculateTextHeight(apiText.Class)
 
@@ -71,6 +95,15 @@ func (t Text) drawTextWithChildren(b *Builder, apiText api.Text) {
 			}
 		}
 
+		// Links open their URL when clicked
+		if apiText.Href != "" {
+			url := apiText.Href
+			textProps.Hyperlink = &url
+			if apiText.Class.Foreground == nil {
+				textProps.Color = linkColor
+			}
+		}
+
 		// Create text component
 		textComponent := text.New(apiText.Content, *textProps)
 
@@ -92,6 +125,52 @@ func (t Text) drawTextWithChildren(b *Builder, apiText api.Text) {
 	}
 }
 
+// isOutputRuns reports whether the text is styled runs of multi-line output, such as
+// terminal output parsed with api.ParseANSI, which draw as lines rather than a row per run
+func isOutputRuns(apiText api.Text) bool {
+	if apiText.Content != "" || len(apiText.Children) < 2 {
+		return false
+	}
+	multiline := false
+	for _, child := range apiText.Children {
+		if child.Layout != nil || len(child.Children) > 0 {
+			return false
+		}
+		multiline = multiline || strings.Contains(child.Content, "\n")
+	}
+	return multiline
+}
+
+// drawOutputLines draws each line of styled runs as a row. PDF text has a single style,
+// so a line takes the class of its longest run.
+func (t Text) drawOutputLines(b *Builder, apiText api.Text) {
+	var line strings.Builder
+	var class api.Class
+	longest := 0
+
+	flush := func() {
+		textProps := b.style.ConvertToTextProps(class)
+		b.maroto.AddRow(b.style.CalculateTextHeight(class), col.New(12).Add(text.New(line.String(), *textProps)))
+		line.Reset()
+		class, longest = api.Class{}, 0
+	}
+
+	for _, run := range apiText.Children {
+		for i, segment := range strings.Split(run.Content, "\n") {
+			if i > 0 {
+				flush()
+			}
+			line.WriteString(segment)
+			if length := len(strings.TrimSpace(segment)); length > longest {
+				class, longest = layoutClass(run), length
+			}
+		}
+	}
+	if line.Len() > 0 {
+		flush()
+	}
+}
+
 // parseMarkdown converts markdown syntax to formatted text
 // Note: This returns processed text that will be rendered with appropriate styles
 func (t Text) parseMarkdown(content string) string {
@@ -199,7 +278,14 @@ func (t Text) drawEnhancedText(b *Builder, apiText api.Text) {
 		textProps.BreakLineStrategy = breakline.DashStrategy
 	}
 
-	// TODO: Add hyperlink support if api.Text gets an Href field
+	// Links open their URL when clicked
+	if apiText.Href != "" {
+		url := apiText.Href
+		textProps.Hyperlink = &url
+		if apiText.Class.Foreground == nil {
+			textProps.Color = linkColor
+		}
+	}
 
 	// Calculate height
 	height := b.style.CalculateTextHeight(apiText.Class)
@@ -9,16 +9,47 @@ import (
 	"github.com/flanksource/clicky/api"
 )
 
-// PDFFormatter handles PDF formatting using HTML-to-PDF conversion via Playwright/Chromium
-type PDFFormatter struct{}
+const (
+	// PDFBackendNative lays out PDFs in pure Go using the formatters/pdf widgets
+	PDFBackendNative = "native"
+	// PDFBackendPlaywright renders HTML and prints it to PDF with a headless Chromium
+	PDFBackendPlaywright = "playwright"
+)
+
+// PDFFormatter handles PDF formatting, either natively or via HTML-to-PDF conversion with Playwright/Chromium
+type PDFFormatter struct {
+	// Backend selects the rendering backend: native (default) or playwright
+	Backend string
+}
 
-// NewPDFFormatter creates a new PDF formatter
+// NewPDFFormatter creates a new PDF formatter using the native backend
 func NewPDFFormatter() *PDFFormatter {
-	return &PDFFormatter{}
+	return &PDFFormatter{Backend: PDFBackendNative}
 }
 
-// Format formats PrettyData as PDF using Rod/Chromium
+// Format formats PrettyData as PDF using the configured backend
 func (f *PDFFormatter) Format(data *api.PrettyData) (string, error) {
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(f.Backend) {
+	case "", PDFBackendNative:
+		pdfBytes, err := f.formatNative(data)
+		if err != nil {
+			return "", err
+		}
+		return string(pdfBytes), nil
+	case PDFBackendPlaywright, "chromium":
+		return f.formatPlaywright(data)
+	default:
+		return "", fmt.Errorf("unknown PDF backend %q (valid backends: %s, %s)", f.Backend, PDFBackendNative, PDFBackendPlaywright)
+	}
+}
+
+// formatPlaywright formats PrettyData as PDF by rendering HTML in Chromium
+func (f *PDFFormatter) formatPlaywright(data *api.PrettyData) (string, error) {
 	// Generate HTML using the HTML formatter
 	htmlFormatter := NewHTMLFormatter()
 	htmlContent, err := htmlFormatter.Format(data)
@@ -70,16 +101,15 @@ func (f *PDFFormatter) optimizeHTMLForPDF(htmlContent string) string {
 
 // convertHTMLToPDFWithRod converts HTML content to PDF using Playwright/Chromium
 func (f *PDFFormatter) convertHTMLToPDFWithRod(htmlContent string) ([]byte, error) {
-	// Install playwright if needed
-	err := playwright.Install()
-	if err != nil {
-		return nil, fmt.Errorf("failed to install playwright: %w", err)
-	}
-
-	// Launch playwright
+	// Launch playwright, installing the driver and browsers only when they are missing
 	pw, err := playwright.Run()
 	if err != nil {
-		return nil, fmt.Errorf("failed to run playwright: %w", err)
+		if installErr := playwright.Install(); installErr != nil {
+			return nil, fmt.Errorf("failed to install playwright: %w", installErr)
+		}
+		if pw, err = playwright.Run(); err != nil {
+			return nil, fmt.Errorf("failed to run playwright: %w", err)
+		}
 	}
 	defer pw.Stop()
 
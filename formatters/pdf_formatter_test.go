@@ -0,0 +1,71 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestPDFFormatterNative(t *testing.T) {
+	columns := make([]api.PrettyField, 14)
+	row := api.PrettyDataRow{}
+	for i := range columns {
+		name := string(rune('a' + i))
+		columns[i] = api.PrettyField{Name: name}
+		row[name] = api.FieldValue{Value: i}
+	}
+
+	data := &api.PrettyData{
+		Schema: &api.PrettyObject{
+			Fields: []api.PrettyField{
+				{Name: "id", Style: "text-blue-600"},
+				{Name: "wide", Format: api.FormatTable, TableOptions: api.PrettyTable{Fields: columns, HeaderStyle: "bg-gray-200"}},
+				{Name: "empty", Format: api.FormatTable},
+				{Name: "tree", Format: api.FormatTree},
+			},
+		},
+		Values: map[string]api.FieldValue{
+			"id": {Value: "ORD-1"},
+		},
+		Tables: map[string][]api.PrettyDataRow{
+			"wide":  {row},
+			"empty": {},
+		},
+		Trees: map[string]api.PrettyTree{
+			"tree": {
+				Value:    api.FieldValue{Value: "root"},
+				Children: []api.PrettyTree{{Value: api.FieldValue{Value: "child"}}},
+			},
+		},
+	}
+
+	output, err := NewPDFFormatter().Format(data)
+	if err != nil {
+		t.Fatalf("native PDF format failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "%PDF") {
+		t.Errorf("native PDF formatter should produce a PDF document")
+	}
+}
+
+func TestPDFFormatterBackends(t *testing.T) {
+	data := &api.PrettyData{Schema: &api.PrettyObject{}}
+
+	t.Run("UnknownBackend", func(t *testing.T) {
+		_, err := (&PDFFormatter{Backend: "wkhtmltopdf"}).Format(data)
+		if err == nil || !strings.Contains(err.Error(), "native") {
+			t.Errorf("expected an error listing the valid backends, got %v", err)
+		}
+	})
+
+	t.Run("ManagerDefaultsToNative", func(t *testing.T) {
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{PDF: true}, []TestStruct{{Name: "John Doe", Age: 30}})
+		if err != nil {
+			t.Fatalf("PDF format failed: %v", err)
+		}
+		if !strings.HasPrefix(output, "%PDF") {
+			t.Errorf("--pdf should produce a PDF document without a browser")
+		}
+	})
+}
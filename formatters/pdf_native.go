@@ -0,0 +1,293 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+	"github.com/flanksource/clicky/formatters/pdf"
+)
+
+// pdfMaxColumns is the widest table the 12 column maroto grid can lay out
+const pdfMaxColumns = 12
+
+// formatNative lays out PrettyData with the pdf.Builder widgets, without a browser
+func (f *PDFFormatter) formatNative(data *api.PrettyData) ([]byte, error) {
+	builder := pdf.NewBuilder()
+
+	if data == nil || data.Schema == nil {
+		return builder.Output()
+	}
+
+	if err := f.drawSummary(builder, data); err != nil {
+		return nil, err
+	}
+
+	for _, field := range data.Schema.Fields {
+		var err error
+		switch field.Format {
+		case api.FormatTable:
+			rows, exists := data.GetTable(field.Name)
+			if !exists {
+				continue
+			}
+			err = f.drawTable(builder, field, rows)
+		case api.FormatTree:
+			err = f.drawTree(builder, field, data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", field.Name, err)
+		}
+	}
+
+	return builder.Output()
+}
+
+// drawSummary renders the non-table, non-tree fields as a two column label/value table
+func (f *PDFFormatter) drawSummary(builder *pdf.Builder, data *api.PrettyData) error {
+	var rows [][]any
+	var styles [][]api.Class
+	for _, field := range data.Schema.Fields {
+		if field.Format == api.FormatTable || field.Format == api.FormatTree || field.Format == api.FormatHide {
+			continue
+		}
+		fieldValue, exists := data.GetValue(field.Name)
+		if !exists {
+			continue
+		}
+
+		labelStyle := field.LabelStyle
+		if labelStyle == "" {
+			labelStyle = "font-bold text-gray-600"
+		}
+		rows = append(rows, []any{fieldLabel(field), pdfCell(fieldValue, field)})
+		styles = append(styles, []api.Class{{Name: labelStyle}, {Name: pdfFieldStyle(fieldValue, field)}})
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := f.drawHeading(builder, "Summary"); err != nil {
+		return err
+	}
+	return builder.DrawWidget(pdf.TableImproved{
+		Rows:         rows,
+		CellStyles:   styles,
+		ColumnWidths: []int{4, 8},
+	})
+}
+
+// drawTable renders table rows, splitting tables wider than the grid into several column groups,
+// followed by the table's chart
+func (f *PDFFormatter) drawTable(builder *pdf.Builder, field api.PrettyField, rows []api.PrettyDataRow) error {
+	if err := f.drawHeading(builder, tableTitle(field)); err != nil {
+		return err
+	}
+
+	columns := api.TableColumns(field, rows)
+	if len(rows) == 0 || len(columns) == 0 {
+		return builder.DrawWidget(pdf.Box{
+			Rectangle: api.Rectangle{Height: 10},
+			Labels: []pdf.Label{{
+				Text: api.Text{Content: "No data available", Class: api.ResolveStyles("text-sm text-gray-500")},
+			}},
+		})
+	}
+
+	for start := 0; start < len(columns); start += pdfMaxColumns {
+		end := start + pdfMaxColumns
+		if end > len(columns) {
+			end = len(columns)
+		}
+		group := columns[start:end]
+
+		table := pdf.TableImproved{
+			HeaderStyle:       api.Class{Name: field.TableOptions.HeaderStyle},
+			RowStyle:          api.Class{Name: field.TableOptions.RowStyle},
+			AlternateRowColor: true,
+		}
+		for _, column := range group {
+			table.Headers = append(table.Headers, fieldLabel(column))
+			table.ColumnAlignments = append(table.ColumnAlignments, pdfColumnAlignment(column))
+		}
+		for _, row := range rows {
+			cells := make([]any, len(group))
+			styles := make([]api.Class, len(group))
+			for i, column := range group {
+				value, exists := row[column.Name]
+				if !exists {
+					cells[i] = ""
+					continue
+				}
+				cells[i] = pdfCell(value, column)
+				styles[i] = api.Class{Name: strings.TrimSpace(pdfRowKindStyles[row.Kind()] + " " + pdfFieldStyle(value, column))}
+			}
+			table.Rows = append(table.Rows, cells)
+			table.CellStyles = append(table.CellStyles, styles)
+		}
+
+		if err := builder.DrawWidget(table); err != nil {
+			return err
+		}
+	}
+
+	if chart, ok := newTableChart(field, rows, api.DefaultTheme()); ok {
+		return builder.DrawWidget(pdf.SVGContent{Content: chart.SVG()})
+	}
+	return nil
+}
+
+// pdfRowKindStyles styles the cells of group headers and aggregate rows
+var pdfRowKindStyles = map[string]string{
+	api.RowKindGroup:    "font-bold bg-gray-100",
+	api.RowKindSubtotal: "font-bold",
+	api.RowKindTotal:    "font-bold bg-gray-200",
+}
+
+// drawTree renders a tree field as nested lists, one list per run of siblings
+func (f *PDFFormatter) drawTree(builder *pdf.Builder, field api.PrettyField, data *api.PrettyData) error {
+	var lines []pdfTreeLine
+	if fieldValue, exists := data.GetValue(field.Name); exists && fieldValue.Value != nil {
+		if node := ConvertToTreeNode(fieldValue.Value); node != nil {
+			lines = pdfTreeNodeLines(node, 0, lines)
+		}
+	} else if tree, exists := data.Trees[field.Name]; exists {
+		lines = pdfPrettyTreeLines(tree, 0, lines)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	if err := f.drawHeading(builder, fieldLabel(field)); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(lines); {
+		depth := lines[start].depth
+		end := start
+		var items []string
+		for end < len(lines) && lines[end].depth == depth {
+			items = append(items, lines[end].text)
+			end++
+		}
+
+		list := pdf.List{
+			Items:  items,
+			Indent: float64(5 + depth*5),
+		}
+		if depth == 0 {
+			list.ItemStyle = api.Class{Name: "font-bold"}
+			list.BulletStyle = "dash"
+		} else if depth%2 == 0 {
+			list.BulletStyle = "circle"
+		}
+		if err := builder.DrawWidget(list); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// drawHeading renders a section title
+func (f *PDFFormatter) drawHeading(builder *pdf.Builder, title string) error {
+	return builder.DrawWidget(pdf.Text{
+		Text: api.Text{
+			Content: title,
+			Class:   api.ResolveStyles("text-lg font-bold text-gray-900"),
+		},
+	})
+}
+
+type pdfTreeLine struct {
+	depth int
+	text  string
+}
+
+func pdfTreeNodeLines(node api.TreeNode, depth int, lines []pdfTreeLine) []pdfTreeLine {
+	if node == nil {
+		return lines
+	}
+	lines = append(lines, pdfTreeLine{depth: depth, text: node.Pretty().String()})
+	for _, child := range node.GetChildren() {
+		lines = pdfTreeNodeLines(child, depth+1, lines)
+	}
+	return lines
+}
+
+func pdfPrettyTreeLines(tree api.PrettyTree, depth int, lines []pdfTreeLine) []pdfTreeLine {
+	lines = append(lines, pdfTreeLine{depth: depth, text: tree.Value.Formatted()})
+	for _, child := range tree.Children {
+		lines = pdfPrettyTreeLines(child, depth+1, lines)
+	}
+	return lines
+}
+
+// fieldLabel returns the display label for a field, without the styles of its markup
+func fieldLabel(field api.PrettyField) string {
+	return field.LabelText().String()
+}
+
+// tableTitle returns the title of a table, defaulting to the label of its field
+func tableTitle(field api.PrettyField) string {
+	if field.TableOptions.Title != "" {
+		return field.TableOptions.Title
+	}
+	return fieldLabel(field)
+}
+
+// pdfCell returns a chart drawn with vector lines for sparkline, bar and gauge fields,
+// a clickable link for values with a link template, and the formatted text of other values
+func pdfCell(value api.FieldValue, field api.PrettyField) any {
+	if chart, ok := newMiniChart(value.Value, field); ok {
+		return chart.PDF()
+	}
+	if value.Link != "" {
+		return pdf.Link{Text: pdfCellText(value, field), URL: value.Link}
+	}
+	return pdfCellText(value, field)
+}
+
+// pdfCellText returns the formatted text for a value, applying the field format when the value has not been parsed yet
+func pdfCellText(value api.FieldValue, field api.PrettyField) string {
+	if value.Text == nil && value.Value == nil {
+		return ""
+	}
+	return parseFieldValue(value, field).Formatted()
+}
+
+// pdfFieldStyle returns the Tailwind classes for a value, including its color_options match
+func pdfFieldStyle(value api.FieldValue, field api.PrettyField) string {
+	styles := []string{field.Style}
+	if value.Field.Style != "" && value.Field.Style != field.Style {
+		styles = append(styles, value.Field.Style)
+	}
+	if field.Color != "" || len(field.ColorOptions) > 0 {
+		value.Field = field
+		switch color := value.Color(); {
+		case color == "" || strings.HasPrefix(color, "#"):
+		case strings.HasPrefix(color, "text-"):
+			styles = append(styles, color)
+		default:
+			styles = append(styles, "text-"+color+"-600")
+		}
+	}
+	if value.Style != "" {
+		styles = append(styles, value.Style)
+	}
+	return strings.TrimSpace(strings.Join(styles, " "))
+}
+
+// pdfColumnAlignment right aligns numeric columns
+func pdfColumnAlignment(field api.PrettyField) string {
+	switch field.Format {
+	case api.FormatCurrency, api.FormatFloat, "number", api.FormatBytes, api.FormatIBytes, api.FormatPercent, api.FormatSI, api.FormatDuration:
+		return "right"
+	}
+	switch field.Type {
+	case api.FieldTypeInt, api.FieldTypeFloat:
+		return "right"
+	}
+	return "left"
+}
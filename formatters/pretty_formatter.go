@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/flanksource/clicky/api"
 	"github.com/flanksource/commons/logger"
 )
@@ -17,13 +18,19 @@ import (
 type PrettyFormatter struct {
 	Theme   api.Theme
 	NoColor bool
-	parser  *api.StructParser
+	// Width is the width tables are fitted to, defaulting to the terminal width when
+	// writing to a terminal. A negative width never fits tables.
+	Width int
+	// TableStyle is the border style of tables, e.g. rounded or ascii, overriding the
+	// table_style of the schema and the theme
+	TableStyle string
+	parser     *api.StructParser
 }
 
-// NewPrettyFormatter creates a new formatter with adaptive theme
+// NewPrettyFormatter creates a new formatter with the selected theme, or an adaptive one
 func NewPrettyFormatter() *PrettyFormatter {
 	return &PrettyFormatter{
-		Theme:  api.AutoTheme(),
+		Theme:  api.CurrentTheme(),
 		parser: api.NewStructParser(),
 	}
 }
@@ -68,6 +75,10 @@ func (p *PrettyFormatter) FormatPrettyData(data *api.PrettyData) (string, error)
 	if data == nil {
 		return "", nil
 	}
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return "", err
+	}
 
 	var result []string
 
@@ -84,10 +95,7 @@ func (p *PrettyFormatter) FormatPrettyData(data *api.PrettyData) (string, error)
 
 		if fieldValue, ok := data.Values[field.Name]; ok {
 			// Use the field's label or name
-			label := field.Label
-			if label == "" {
-				label = api.PrettifyFieldName(field.Name)
-			}
+			label := p.markup(field.LabelText())
 
 			// Handle nested map fields - check Format, Type, or presence of NestedFields (for schema mismatches)
 			if (field.Format == "map" || field.Type == "map" || fieldValue.NestedFields != nil) && fieldValue.NestedFields != nil {
@@ -97,7 +105,7 @@ func (p *PrettyFormatter) FormatPrettyData(data *api.PrettyData) (string, error)
 				nestedLines := p.formatNestedFields(fieldValue, field, 1)
 				result = append(result, nestedLines...)
 			} else {
-				formatted := p.formatField(label, reflect.ValueOf(fieldValue.Value), field)
+				formatted := p.formatLabelled(label, p.hyperlink(p.styleRule(p.formatValue(reflect.ValueOf(fieldValue.Value), field), fieldValue.Style), fieldValue.Link))
 				result = append(result, formatted)
 			}
 		}
@@ -113,7 +121,13 @@ func (p *PrettyFormatter) FormatPrettyData(data *api.PrettyData) (string, error)
 					// Convert row map to struct-like map for table rendering
 					rowMap := make(map[string]interface{})
 					for k, v := range row {
-						rowMap[k] = v.Value
+						if (row.Kind() != "" && v.Text != nil) || v.Style != "" || v.Link != "" {
+							// Aggregates are already formatted with the column's format,
+							// and cells matched by style rules keep their style and link
+							rowMap[k] = v
+						} else {
+							rowMap[k] = v.Value
+						}
 					}
 					items = append(items, rowMap)
 				}
@@ -122,13 +136,16 @@ func (p *PrettyFormatter) FormatPrettyData(data *api.PrettyData) (string, error)
 				var tableStr string
 				var err error
 				if len(field.Fields) > 0 {
-					tableStr, err = p.renderTableFromData(items, field.Fields)
+					tableStr, err = p.renderTableFromData(items, field.Fields, field.TableOptions.TableStyle)
 				} else {
-					tableStr, err = p.renderTableFromMaps(items)
+					tableStr, err = p.renderTableFromMaps(items, field.TableOptions.TableStyle)
 				}
 				if err == nil {
 					result = append(result, tableStr)
 				}
+				if chart, ok := newTableChart(field, tableRows, p.Theme); ok {
+					result = append(result, "", chart.ASCII(!p.NoColor))
+				}
 			}
 		}
 	}
@@ -155,10 +172,7 @@ func (p *PrettyFormatter) formatNestedFields(fieldValue api.FieldValue, field ap
 			// Format each nested field using schema definitions
 			for _, nestedField := range field.Fields {
 				if nestedValue, ok := nestedMap[nestedField.Name]; ok {
-					label := nestedField.Label
-					if label == "" {
-						label = api.PrettifyFieldName(nestedField.Name)
-					}
+					label := p.markup(nestedField.LabelText())
 
 					// Add indentation with tabs
 					indentStr := strings.Repeat("\t", indent)
@@ -208,7 +222,7 @@ func (p *PrettyFormatter) formatNestedFields(fieldValue api.FieldValue, field ap
 }
 
 // renderTableFromData renders a table from map items using field definitions
-func (p *PrettyFormatter) renderTableFromData(items []interface{}, fieldDefs []api.PrettyField) (string, error) {
+func (p *PrettyFormatter) renderTableFromData(items []interface{}, fieldDefs []api.PrettyField, style string) (string, error) {
 	if len(items) == 0 {
 		return "", nil
 	}
@@ -229,13 +243,14 @@ func (p *PrettyFormatter) renderTableFromData(items []interface{}, fieldDefs []a
 	for i, header := range headers {
 		style := lipgloss.NewStyle().Bold(true)
 		if !p.NoColor {
-			style = style.Foreground(p.Theme.Primary)
+			style = style.Foreground(p.Theme.HeaderColor())
 		}
 		headerRow[i] = p.applyStyle(header, style)
 	}
 	rows = append(rows, headerRow)
 
 	// Data rows
+	kinds := []string{""}
 	for _, item := range items {
 		itemMap, ok := item.(map[string]interface{})
 		if !ok {
@@ -247,32 +262,42 @@ func (p *PrettyFormatter) renderTableFromData(items []interface{}, fieldDefs []a
 			if val, ok := itemMap[header]; ok {
 				// Use the field definition for proper formatting
 				fieldDef := fieldMap[header]
-				row[i] = p.formatValue(reflect.ValueOf(val), fieldDef)
+				row[i] = p.formatTableCell(val, fieldDef)
 			} else {
 				row[i] = ""
 			}
 		}
-		rows = append(rows, row)
+		kind, _ := itemMap[api.RowKindKey].(string)
+		rows = append(rows, p.styleRowKind(row, kind))
+		kinds = append(kinds, kind)
 	}
 
-	return p.formatTableRows(rows), nil
+	return p.formatTableRows(rows, fieldDefs, style, kinds...), nil
 }
 
 // renderTableFromMaps renders a table from map items
-func (p *PrettyFormatter) renderTableFromMaps(items []interface{}) (string, error) {
+func (p *PrettyFormatter) renderTableFromMaps(items []interface{}, style string) (string, error) {
 	if len(items) == 0 {
 		return "", nil
 	}
 
-	// Get headers from first item
+	// Get headers from first item, skipping group headers and aggregate rows
 	firstItem, ok := items[0].(map[string]interface{})
 	if !ok {
 		return p.renderTable(items)
 	}
+	for _, item := range items {
+		if itemMap, ok := item.(map[string]interface{}); ok && itemMap[api.RowKindKey] == nil {
+			firstItem = itemMap
+			break
+		}
+	}
 
 	var headers []string
 	for key := range firstItem {
-		headers = append(headers, key)
+		if key != api.RowKindKey {
+			headers = append(headers, key)
+		}
 	}
 	sort.Strings(headers)
 
@@ -284,13 +309,14 @@ func (p *PrettyFormatter) renderTableFromMaps(items []interface{}) (string, erro
 	for i, header := range headers {
 		style := lipgloss.NewStyle().Bold(true)
 		if !p.NoColor {
-			style = style.Foreground(p.Theme.Primary)
+			style = style.Foreground(p.Theme.HeaderColor())
 		}
 		headerRow[i] = p.applyStyle(header, style)
 	}
 	rows = append(rows, headerRow)
 
 	// Data rows
+	kinds := []string{""}
 	for _, item := range items {
 		itemMap, ok := item.(map[string]interface{})
 		if !ok {
@@ -308,15 +334,74 @@ func (p *PrettyFormatter) renderTableFromMaps(items []interface{}) (string, erro
 				} else if strings.Contains(header, "amount") || strings.Contains(header, "price") {
 					field.Format = "currency"
 				}
-				row[i] = p.formatValue(reflect.ValueOf(val), field)
+				row[i] = p.formatTableCell(val, field)
 			} else {
 				row[i] = ""
 			}
 		}
-		rows = append(rows, row)
+		kind, _ := itemMap[api.RowKindKey].(string)
+		rows = append(rows, p.styleRowKind(row, kind))
+		kinds = append(kinds, kind)
 	}
 
-	return p.formatTableRows(rows), nil
+	return p.formatTableRows(rows, nil, style, kinds...), nil
+}
+
+// formatTableCell formats a table cell, using the text of already formatted aggregate values
+func (p *PrettyFormatter) formatTableCell(val interface{}, field api.PrettyField) string {
+	fieldValue, ok := val.(api.FieldValue)
+	if !ok {
+		return p.formatValue(reflect.ValueOf(val), field)
+	}
+	text := fieldValue.Formatted()
+	if fieldValue.Text == nil {
+		text = p.formatValue(reflect.ValueOf(fieldValue.Value), field)
+	} else if field.Format == api.FormatMarkup || fieldValue.Value == nil {
+		// Markup and defaults keep their styles
+		text = p.markup(*fieldValue.Text)
+	}
+	return p.hyperlink(p.styleRule(text, fieldValue.Style), fieldValue.Link)
+}
+
+// styleRule applies the Tailwind classes of matching style rules to formatted text
+func (p *PrettyFormatter) styleRule(text, style string) string {
+	if style == "" || p.NoColor {
+		return text
+	}
+	return api.Text{Content: stripAnsi(text), Style: style}.ANSI()
+}
+
+// markup renders text parsed from inline markup, without styles when colors are disabled
+func (p *PrettyFormatter) markup(text api.Text) string {
+	if p.NoColor {
+		return text.String()
+	}
+	return text.ANSI()
+}
+
+// hyperlink links formatted text to a URL in terminals that support OSC 8 hyperlinks
+func (p *PrettyFormatter) hyperlink(text, url string) string {
+	if url == "" || p.NoColor {
+		return text
+	}
+	return api.Hyperlink(text, url)
+}
+
+// styleRowKind renders group headers and aggregate rows in bold, with group headers in the primary color
+func (p *PrettyFormatter) styleRowKind(row []string, kind string) []string {
+	if kind == "" {
+		return row
+	}
+	style := lipgloss.NewStyle().Bold(true)
+	if kind == api.RowKindGroup && !p.NoColor {
+		style = style.Foreground(p.Theme.Primary)
+	}
+	for i, cell := range row {
+		if cell != "" {
+			row[i] = p.applyStyle(stripAnsi(cell), style)
+		}
+	}
+	return row
 }
 
 // parseStruct processes a struct and its tags
@@ -379,13 +464,16 @@ func (p *PrettyFormatter) parseStruct(val reflect.Value) (string, error) {
 
 // formatField formats a single field
 func (p *PrettyFormatter) formatField(name string, val reflect.Value, field api.PrettyField) string {
+	return p.formatLabelled(name, p.formatValue(val, field))
+}
+
+// formatLabelled formats an already formatted value after its label
+func (p *PrettyFormatter) formatLabelled(name, valueStr string) string {
 	labelStyle := lipgloss.NewStyle().Bold(true)
 	if !p.NoColor {
 		labelStyle = labelStyle.Foreground(p.Theme.Primary)
 	}
 
-	valueStr := p.formatValue(val, field)
-
 	return fmt.Sprintf("%s: %s",
 		labelStyle.Render(name),
 		valueStr)
@@ -398,7 +486,10 @@ func (p *PrettyFormatter) formatValue(val reflect.Value, field api.PrettyField)
 
 // formatValueWithVisited formats a value with circular reference detection
 func (p *PrettyFormatter) formatValueWithVisited(val reflect.Value, field api.PrettyField, visited map[uintptr]bool) string {
-	// Check for custom render function first
+	// Check for custom render function first, formats such as sparkline name a registered one
+	if field.RenderFunc == nil {
+		field.RenderFunc = api.RenderFuncRegistry[field.Format]
+	}
 	if field.RenderFunc != nil {
 		var value interface{}
 		if val.IsValid() {
@@ -408,6 +499,9 @@ func (p *PrettyFormatter) formatValueWithVisited(val reflect.Value, field api.Pr
 	}
 
 	if !val.IsValid() || (val.Kind() == reflect.Ptr && val.IsNil()) {
+		if defaultValue, ok := field.DefaultValue(); ok {
+			return p.markup(*defaultValue.Text)
+		}
 		return p.applyStyle("null", lipgloss.NewStyle().Foreground(p.Theme.Muted))
 	}
 
@@ -417,13 +511,17 @@ func (p *PrettyFormatter) formatValueWithVisited(val reflect.Value, field api.Pr
 
 	switch field.Format {
 	case "currency":
-		return p.formatCurrency(val)
+		return p.formatCurrency(val, field)
 	case "date":
-		return p.formatDate(val, field.FormatOptions["format"])
+		return p.formatDate(val, field)
 	case "float":
-		return p.formatFloat(val, field.FormatOptions["digits"])
+		return p.formatFloat(val, field)
+	case api.FormatBytes, api.FormatIBytes, api.FormatPercent, api.FormatSI, api.FormatRelative, api.FormatDuration:
+		return p.formatUnits(val, field)
 	case "color":
 		return p.formatWithColor(val, field.ColorOptions)
+	case api.FormatMarkup:
+		return p.markup(api.Markup(fmt.Sprintf("%v", val.Interface())))
 	case api.FormatTree:
 		return p.formatAsTree(val, field)
 	default:
@@ -431,27 +529,46 @@ func (p *PrettyFormatter) formatValueWithVisited(val reflect.Value, field api.Pr
 	}
 }
 
-// formatCurrency formats a value as currency
-func (p *PrettyFormatter) formatCurrency(val reflect.Value) string {
+// formatUnits formats a value with the human units of the field's format
+func (p *PrettyFormatter) formatUnits(val reflect.Value, field api.PrettyField) string {
+	if !val.CanInterface() {
+		return p.formatDefault(val)
+	}
+	value, err := field.Parse(val.Interface())
+	if err != nil || value.Text == nil {
+		return p.formatDefault(val)
+	}
+	style := lipgloss.NewStyle()
+	if !p.NoColor {
+		style = style.Foreground(p.Theme.Info)
+	}
+	return p.applyStyle(value.Text.Content, style)
+}
+
+// formatCurrency formats a value as currency in the field's locale
+func (p *PrettyFormatter) formatCurrency(val reflect.Value, field api.PrettyField) string {
 	style := lipgloss.NewStyle()
 	if !p.NoColor {
 		style = style.Foreground(p.Theme.Success)
 	}
 
+	var amount float64
 	switch val.Kind() {
 	case reflect.Float32, reflect.Float64:
-		return p.applyStyle(fmt.Sprintf("$%.2f", val.Float()), style)
+		amount = val.Float()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return p.applyStyle(fmt.Sprintf("$%.2f", float64(val.Int())), style)
+		amount = float64(val.Int())
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return p.applyStyle(fmt.Sprintf("$%.2f", float64(val.Uint())), style)
+		amount = float64(val.Uint())
 	default:
 		return p.formatDefault(val)
 	}
+	return p.applyStyle(field.Locale().FormatCurrency(amount, field.FormatOptions["currency"], field.FormatOptions["symbol"]), style)
 }
 
-// formatDate formats a value as date
-func (p *PrettyFormatter) formatDate(val reflect.Value, format string) string {
+// formatDate formats a value as date in the field's locale
+func (p *PrettyFormatter) formatDate(val reflect.Value, field api.PrettyField) string {
+	format := field.FormatOptions["format"]
 	style := lipgloss.NewStyle()
 	if !p.NoColor {
 		style = style.Foreground(p.Theme.Info)
@@ -483,16 +600,16 @@ func (p *PrettyFormatter) formatDate(val reflect.Value, format string) string {
 		}
 	}
 
-	if format == "" {
+	if format == "" || format == "epoch" {
 		format = "2006-01-02 15:04:05"
 	}
-	return p.applyStyle(t.Format(format), style)
+	return p.applyStyle(field.Locale().FormatDate(t, format), style)
 }
 
-// formatFloat formats a float with specified precision
-func (p *PrettyFormatter) formatFloat(val reflect.Value, digits string) string {
+// formatFloat formats a float with the digits format option in the field's locale
+func (p *PrettyFormatter) formatFloat(val reflect.Value, field api.PrettyField) string {
 	precision := 2
-	if digits != "" {
+	if digits := field.FormatOptions["digits"]; digits != "" {
 		if p, err := strconv.Atoi(digits); err == nil {
 			precision = p
 		}
@@ -505,8 +622,7 @@ func (p *PrettyFormatter) formatFloat(val reflect.Value, digits string) string {
 
 	switch val.Kind() {
 	case reflect.Float32, reflect.Float64:
-		format := fmt.Sprintf("%%.%df", precision)
-		return p.applyStyle(fmt.Sprintf(format, val.Float()), style)
+		return p.applyStyle(field.Locale().FormatNumber(val.Float(), precision), style)
 	default:
 		return p.formatDefault(val)
 	}
@@ -822,7 +938,7 @@ func (p *PrettyFormatter) renderTable(items []interface{}) (string, error) {
 	for i, header := range headers {
 		style := lipgloss.NewStyle().Bold(true)
 		if !p.NoColor {
-			style = style.Foreground(p.Theme.Primary)
+			style = style.Foreground(p.Theme.HeaderColor())
 		}
 		headerRow[i] = p.applyStyle(header, style)
 	}
@@ -837,7 +953,7 @@ func (p *PrettyFormatter) renderTable(items []interface{}) (string, error) {
 		rows = append(rows, row)
 	}
 
-	return p.formatTableRows(rows), nil
+	return p.formatTableRows(rows, nil, ""), nil
 }
 
 // getTableHeaders extracts headers from a struct
@@ -919,75 +1035,106 @@ func (p *PrettyFormatter) getTableRow(item interface{}, headers []string) ([]str
 	return row, nil
 }
 
-// formatTableRows formats table rows with proper alignment
-func (p *PrettyFormatter) formatTableRows(rows [][]string) string {
+// formatTableRows formats table rows with proper alignment and the border of the table
+// style. The optional row kinds add a separator before group headers and aggregate rows.
+func (p *PrettyFormatter) formatTableRows(rows [][]string, columns []api.PrettyField, style string, kinds ...string) string {
 	if len(rows) == 0 {
 		return ""
 	}
+	border := p.tableBorder(style)
+	rows = append([][]string{border.header(rows[0])}, rows[1:]...)
 
-	// Calculate column widths
+	// Calculate column widths in terminal cells, then fit them to the terminal
 	colWidths := make([]int, len(rows[0]))
 	for _, row := range rows {
 		for i, cell := range row {
-			// Strip ANSI codes for width calculation
-			cellWidth := len(stripAnsi(cell))
-			if cellWidth > colWidths[i] {
-				colWidths[i] = cellWidth
-			}
+			colWidths[i] = max(colWidths[i], displayWidth(cell))
 		}
 	}
+	colWidths = fitColumns(colWidths, columns, p.tableWidth(), border.overhead(len(colWidths)))
+	wraps := make([]bool, len(colWidths))
+	for i := range wraps {
+		wraps[i] = i < len(columns) && columns[i].Wrap
+	}
 
 	// Create table style
 	borderStyle := lipgloss.NewStyle()
 	if !p.NoColor {
-		borderStyle = borderStyle.Foreground(p.Theme.Muted)
+		borderStyle = borderStyle.Foreground(p.Theme.BorderColor())
 	}
 
-	var result strings.Builder
+	var lines []string
 
 	// Top border
-	result.WriteString(p.createTableBorder(colWidths, "┌", "┬", "┐", "─", borderStyle))
-	result.WriteString("\n")
+	if border.outer {
+		lines = append(lines, p.createTableBorder(colWidths, border.TopLeft, border.MiddleTop, border.TopRight, border.Top, borderStyle))
+	}
 
-	// Header row
-	if len(rows) > 0 {
-		result.WriteString(p.formatTableRow(rows[0], colWidths, borderStyle))
-		result.WriteString("\n")
-
-		// Header separator
-		if len(rows) > 1 {
-			result.WriteString(p.createTableBorder(colWidths, "├", "┼", "┤", "─", borderStyle))
-			result.WriteString("\n")
-		}
+	// Header row and separator
+	lines = append(lines, p.formatTableRow(rows[0], colWidths, wraps, border, borderStyle))
+	if len(rows) > 1 && border.bordered() {
+		lines = append(lines, p.createTableBorder(colWidths, border.MiddleLeft, border.Middle, border.MiddleRight, border.Top, borderStyle))
 	}
 
 	// Data rows
 	for i := 1; i < len(rows); i++ {
-		result.WriteString(p.formatTableRow(rows[i], colWidths, borderStyle))
-		result.WriteString("\n")
+		if border.groups && i > 1 && i < len(kinds) && kinds[i] != "" {
+			lines = append(lines, p.createTableBorder(colWidths, border.MiddleLeft, border.Middle, border.MiddleRight, border.Top, borderStyle))
+		}
+		lines = append(lines, p.formatTableRow(rows[i], colWidths, wraps, border, borderStyle))
 	}
 
 	// Bottom border
-	result.WriteString(p.createTableBorder(colWidths, "└", "┴", "┘", "─", borderStyle))
+	if border.outer {
+		lines = append(lines, p.createTableBorder(colWidths, border.BottomLeft, border.MiddleBottom, border.BottomRight, border.Bottom, borderStyle))
+	}
 
-	return result.String()
+	return strings.Join(lines, "\n")
 }
 
-// formatTableRow formats a single table row
-func (p *PrettyFormatter) formatTableRow(row []string, colWidths []int, borderStyle lipgloss.Style) string {
-	var result strings.Builder
-
-	result.WriteString(p.applyStyle("│", borderStyle))
+// formatTableRow formats a single table row, on as many lines as its tallest cell
+func (p *PrettyFormatter) formatTableRow(row []string, colWidths []int, wraps []bool, border tableBorder, borderStyle lipgloss.Style) string {
+	cells := make([][]string, len(row))
+	height := 1
 	for i, cell := range row {
-		padding := colWidths[i] - len(stripAnsi(cell))
-		result.WriteString(" ")
-		result.WriteString(cell)
-		result.WriteString(strings.Repeat(" ", padding))
-		result.WriteString(" ")
-		result.WriteString(p.applyStyle("│", borderStyle))
+		cells[i] = cellLines(cell, colWidths[i], wraps[i])
+		height = max(height, len(cells[i]))
 	}
 
-	return result.String()
+	lines := make([]string, height)
+	for line := range lines {
+		var result strings.Builder
+		for i, cell := range cells {
+			text := ""
+			if line < len(cell) {
+				text = cell[line]
+			}
+			padding := strings.Repeat(" ", max(colWidths[i]-ansi.StringWidth(text), 0))
+
+			if !border.bordered() {
+				// Columns without borders are separated by gaps, without trailing spaces
+				if i > 0 {
+					result.WriteString(strings.Repeat(" ", border.gap))
+				}
+				result.WriteString(text)
+				if i < len(cells)-1 {
+					result.WriteString(padding)
+				}
+				continue
+			}
+			result.WriteString(p.applyStyle(border.Left, borderStyle))
+			result.WriteString(" ")
+			result.WriteString(text)
+			result.WriteString(padding)
+			result.WriteString(" ")
+		}
+		if border.bordered() {
+			result.WriteString(p.applyStyle(border.Right, borderStyle))
+		}
+		lines[line] = strings.TrimRight(result.String(), " ")
+	}
+
+	return strings.Join(lines, "\n")
 }
 
 // createTableBorder creates a table border line
@@ -1006,27 +1153,10 @@ func (p *PrettyFormatter) createTableBorder(colWidths []int, left, mid, right, f
 	return result.String()
 }
 
-// stripAnsi removes ANSI escape codes for width calculation
+// stripAnsi removes ANSI escape codes for width calculation, including OSC sequences
+// such as hyperlinks
 func stripAnsi(s string) string {
-	// Simple ANSI stripping - in production you might want a more robust solution
-	var result strings.Builder
-	inEscape := false
-
-	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-			continue
-		}
-		if inEscape {
-			if r == 'm' {
-				inEscape = false
-			}
-			continue
-		}
-		result.WriteRune(r)
-	}
-
-	return result.String()
+	return api.StripANSI(s)
 }
 
 // formatAsTree formats a value as a tree structure
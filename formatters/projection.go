@@ -0,0 +1,654 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// Project narrows data to the sub-tree selected by the JSONPath query, then
+// restricts it to the comma separated field paths (e.g. "id,customer.name,items.price").
+// Paths match field names or labels, and the returned PrettyData's Original is
+// replaced by the projected value so serializing formats only see the selection.
+func Project(data *api.PrettyData, fields, query string) (*api.PrettyData, error) {
+	if data == nil || data.Schema == nil {
+		return data, nil
+	}
+
+	if strings.TrimSpace(query) != "" {
+		queried, err := queryPrettyData(data, query)
+		if err != nil {
+			return nil, err
+		}
+		data = queried
+	}
+
+	paths := parseFieldPaths(fields)
+	if len(paths) == 0 {
+		return data, nil
+	}
+	return projectFields(data, paths)
+}
+
+// parseFieldPaths splits "a,b,c.d" into [[a] [b] [c d]]
+func parseFieldPaths(fields string) [][]string {
+	var paths [][]string
+	for _, path := range strings.Split(fields, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(path, "."))
+	}
+	return paths
+}
+
+// projectFields keeps only the selected fields, columns and nested fields
+func projectFields(data *api.PrettyData, paths [][]string) (*api.PrettyData, error) {
+	schemaFields := data.Schema.Fields
+
+	// A top-level slice is a single "data" table, select its columns directly
+	if isTopLevelTable(data) {
+		table := schemaFields[0]
+		if _, ok := findField(schemaFields, paths[0][0]); !ok {
+			for i, path := range paths {
+				paths[i] = append([]string{table.Name}, path...)
+			}
+			projected, err := projectFields(&api.PrettyData{
+				Schema: data.Schema,
+				Values: data.Values,
+				Tables: data.Tables,
+				Trees:  data.Trees,
+			}, paths)
+			if err != nil {
+				return nil, err
+			}
+			projected.Original = projected.Original.(map[string]interface{})[table.Name]
+			return projected, nil
+		}
+	}
+
+	result := &api.PrettyData{
+		Schema: &api.PrettyObject{Template: data.Schema.Template},
+		Values: make(map[string]api.FieldValue),
+		Tables: make(map[string][]api.PrettyDataRow),
+		Trees:  make(map[string]api.PrettyTree),
+	}
+	original := map[string]interface{}{}
+
+	for _, group := range groupFieldPaths(paths) {
+		field, ok := findField(schemaFields, group.name)
+		if !ok {
+			return nil, unknownFieldError(group.name, schemaFields)
+		}
+
+		switch {
+		case field.Format == api.FormatTable:
+			rows, _ := data.GetTable(field.Name)
+			if len(group.children) > 0 {
+				var err error
+				field, rows, err = projectTable(field, rows, group.children)
+				if err != nil {
+					return nil, err
+				}
+			}
+			result.Tables[field.Name] = rows
+			original[field.Name] = rawRows(rows)
+
+		case field.Format == api.FormatTree:
+			if len(group.children) > 0 {
+				return nil, fmt.Errorf("cannot select %s.%s: tree fields cannot be projected", field.Name, strings.Join(group.children[0], "."))
+			}
+			if tree, ok := data.Trees[field.Name]; ok {
+				result.Trees[field.Name] = tree
+			}
+			if value, ok := data.Values[field.Name]; ok {
+				result.Values[field.Name] = value
+				original[field.Name] = rawFieldValue(value)
+			}
+
+		default:
+			value, exists := data.GetValue(field.Name)
+			if len(group.children) > 0 {
+				var err error
+				field, value, err = projectValue(field, value, group.children)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if exists {
+				result.Values[field.Name] = value
+				original[field.Name] = rawFieldValue(value)
+			}
+		}
+
+		result.Schema.Fields = append(result.Schema.Fields, field)
+	}
+
+	result.Original = original
+	return result, nil
+}
+
+// projectTable keeps the selected columns of a table
+func projectTable(field api.PrettyField, rows []api.PrettyDataRow, paths [][]string) (api.PrettyField, []api.PrettyDataRow, error) {
+	columns := api.TableColumns(field, rows)
+
+	var selected []api.PrettyField
+	for _, group := range groupFieldPaths(paths) {
+		column, ok := findField(columns, group.name)
+		if !ok {
+			return field, nil, unknownFieldError(field.Name+"."+group.name, columns)
+		}
+		if len(group.children) > 0 {
+			// Narrow nested column fields using the first row to discover them,
+			// the values are projected per row below
+			var sample api.FieldValue
+			if data := api.DataRows(rows); len(data) > 0 {
+				sample = data[0][column.Name]
+			}
+			projected, _, err := projectValue(column, sample, group.children)
+			if err != nil {
+				return field, nil, err
+			}
+			column = projected
+		}
+		selected = append(selected, column)
+	}
+
+	projectedRows := make([]api.PrettyDataRow, len(rows))
+	for i, row := range rows {
+		projectedRow := make(api.PrettyDataRow, len(selected))
+		if kind, ok := row[api.RowKindKey]; ok {
+			projectedRow[api.RowKindKey] = kind
+		}
+		for _, column := range selected {
+			value, ok := row[column.Name]
+			if !ok {
+				continue
+			}
+			if len(column.Fields) > 0 {
+				value = selectNestedFields(value, column)
+			}
+			projectedRow[column.Name] = value
+		}
+		projectedRows[i] = projectedRow
+	}
+
+	field.Fields = selected
+	if len(field.TableOptions.Fields) > 0 {
+		field.TableOptions.Fields = selected
+	}
+	return field, projectedRows, nil
+}
+
+// projectValue keeps the selected nested fields of a struct or map value
+func projectValue(field api.PrettyField, value api.FieldValue, paths [][]string) (api.PrettyField, api.FieldValue, error) {
+	children := nestedFields(field, value)
+
+	var selected []api.PrettyField
+	for _, group := range groupFieldPaths(paths) {
+		child, ok := findField(children, group.name)
+		if !ok {
+			return field, value, unknownFieldError(field.Name+"."+group.name, children)
+		}
+		if len(group.children) > 0 {
+			childValue, _ := nestedValue(value, child.Name)
+			projected, _, err := projectValue(child, childValue, group.children)
+			if err != nil {
+				return field, value, err
+			}
+			child = projected
+		}
+		selected = append(selected, child)
+	}
+
+	field.Fields = selected
+	return field, selectNestedFields(value, field), nil
+}
+
+// selectNestedFields rebuilds a value from the nested fields of field
+func selectNestedFields(value api.FieldValue, field api.PrettyField) api.FieldValue {
+	if value.Value == nil && len(value.NestedFields) == 0 {
+		return value
+	}
+
+	raw := map[string]interface{}{}
+	nested := map[string]api.FieldValue{}
+	for _, child := range field.Fields {
+		childValue, ok := nestedValue(value, child.Name)
+		if !ok {
+			continue
+		}
+		if len(child.Fields) > 0 {
+			childValue = selectNestedFields(childValue, child)
+		}
+		childValue.Field = child
+		nested[child.Name] = childValue
+		raw[child.Name] = rawFieldValue(childValue)
+	}
+
+	return api.FieldValue{Field: field, Value: raw, NestedFields: nested}
+}
+
+// nestedFields returns the fields of a struct or map value, from the schema or the value itself
+func nestedFields(field api.PrettyField, value api.FieldValue) []api.PrettyField {
+	if len(field.Fields) > 0 {
+		return field.Fields
+	}
+
+	var names []string
+	if len(value.NestedFields) > 0 {
+		for name := range value.NestedFields {
+			names = append(names, name)
+		}
+	} else if m, ok := genericValue(value.Value).(map[string]interface{}); ok {
+		for name := range m {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fields := make([]api.PrettyField, len(names))
+	for i, name := range names {
+		fields[i] = api.PrettyField{Name: name}
+	}
+	return fields
+}
+
+// nestedValue looks up a nested field of a struct or map value
+func nestedValue(value api.FieldValue, name string) (api.FieldValue, bool) {
+	if nested, ok := value.NestedFields[name]; ok {
+		return nested, true
+	}
+	m, ok := genericValue(value.Value).(map[string]interface{})
+	if !ok {
+		return api.FieldValue{}, false
+	}
+	raw, ok := m[name]
+	if !ok {
+		return api.FieldValue{}, false
+	}
+	return api.FieldValue{Value: raw}, true
+}
+
+type fieldPathGroup struct {
+	name     string
+	children [][]string
+}
+
+// groupFieldPaths groups paths by their first segment, keeping the order of first appearance
+func groupFieldPaths(paths [][]string) []fieldPathGroup {
+	var groups []fieldPathGroup
+	index := map[string]int{}
+	for _, path := range paths {
+		i, ok := index[path[0]]
+		if !ok {
+			i = len(groups)
+			index[path[0]] = i
+			groups = append(groups, fieldPathGroup{name: path[0]})
+		}
+		if len(path) > 1 {
+			groups[i].children = append(groups[i].children, path[1:])
+		}
+	}
+	return groups
+}
+
+// findField matches a field by name, then case-insensitively by name or label
+func findField(fields []api.PrettyField, name string) (api.PrettyField, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) || (field.Label != "" && strings.EqualFold(field.Label, name)) {
+			return field, true
+		}
+	}
+	return api.PrettyField{}, false
+}
+
+func unknownFieldError(path string, fields []api.PrettyField) error {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("unknown field %q: no fields available", path)
+	}
+	return fmt.Errorf("unknown field %q, valid fields are: %s", path, strings.Join(names, ", "))
+}
+
+// isTopLevelTable reports whether data is the single table built from a slice,
+// rather than an object that happens to have a table as its only field
+func isTopLevelTable(data *api.PrettyData) bool {
+	if data.Original == nil {
+		return false
+	}
+	val, isNil := safeDerefPointer(reflect.ValueOf(data.Original))
+	if isNil || (val.Kind() != reflect.Slice && val.Kind() != reflect.Array) {
+		return false
+	}
+	fields := data.Schema.Fields
+	return len(fields) == 1 && fields[0].Format == api.FormatTable && len(data.Values) == 0
+}
+
+// rawRows converts table rows to maps of raw values, dropping group headers and aggregate rows
+func rawRows(rows []api.PrettyDataRow) []map[string]interface{} {
+	rows = api.DataRows(rows)
+	out := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		out[i] = rawRow(row)
+	}
+	return out
+}
+
+// genericValue converts structs and typed maps into the maps, slices and scalars
+// produced by encoding/json, so they can be navigated by field name
+func genericValue(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, string, bool, float64, map[string]interface{}, []interface{}:
+		return value
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return value
+	}
+	return generic
+}
+
+// querySegment is a single step of a JSONPath expression
+type querySegment struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseQuery parses the supported JSONPath subset: $, .name, ['name'], [n], [*] and .*
+func parseQuery(query string) ([]querySegment, error) {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "$")
+	// Allow a bare leading name, e.g. items[0].name
+	if query != "" && query[0] != '.' && query[0] != '[' {
+		query = "." + query
+	}
+
+	var segments []querySegment
+	for i := 0; i < len(query); {
+		switch query[i] {
+		case '.':
+			i++
+			start := i
+			for i < len(query) && query[i] != '.' && query[i] != '[' {
+				i++
+			}
+			name := query[start:i]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("invalid query %q: empty field name at position %d", query, start)
+			case "*":
+				segments = append(segments, querySegment{wildcard: true})
+			default:
+				segments = append(segments, querySegment{name: name})
+			}
+		case '[':
+			end := strings.IndexByte(query[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing ]", query)
+			}
+			selector := strings.TrimSpace(query[i+1 : i+end])
+			i += end + 1
+			switch {
+			case selector == "*":
+				segments = append(segments, querySegment{wildcard: true})
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				segments = append(segments, querySegment{name: selector[1 : len(selector)-1]})
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("invalid query %q: unsupported selector [%s]", query, selector)
+				}
+				segments = append(segments, querySegment{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("invalid query %q: unexpected %q at position %d", query, query[i], i)
+		}
+	}
+	return segments, nil
+}
+
+// queryNode is a value reached while evaluating a query, with the schema that describes it
+type queryNode struct {
+	value interface{}
+	field api.PrettyField
+	// fields are the fields of the node's children: struct fields or table columns
+	fields []api.PrettyField
+}
+
+// queryPrettyData evaluates a JSONPath query against data and builds PrettyData from the result
+func queryPrettyData(data *api.PrettyData, query string) (*api.PrettyData, error) {
+	segments, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	root := map[string]interface{}{}
+	for name, value := range data.Values {
+		root[name] = genericValue(rawFieldValue(value))
+	}
+	for name, rows := range data.Tables {
+		rows = api.DataRows(rows)
+		list := make([]interface{}, len(rows))
+		for i, row := range rows {
+			list[i] = genericValue(rawRow(row))
+		}
+		root[name] = list
+	}
+
+	nodes := []queryNode{{value: root, fields: data.Schema.Fields}}
+	wildcard := false
+	for _, segment := range segments {
+		var next []queryNode
+		for _, node := range nodes {
+			children, err := node.step(segment)
+			if err != nil {
+				return nil, fmt.Errorf("query %q: %w", query, err)
+			}
+			next = append(next, children...)
+		}
+		nodes = next
+		wildcard = wildcard || segment.wildcard
+	}
+
+	if !wildcard && len(nodes) == 1 {
+		result := nodes[0].prettyData()
+		result.Schema.Template = data.Schema.Template
+		return result, nil
+	}
+
+	// Wildcards collect their matches into a list
+	list := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		list[i] = node.value
+	}
+	matches := queryNode{value: list, field: api.PrettyField{Name: "data"}}
+	if len(nodes) > 0 {
+		matches.field = nodes[0].field
+		matches.fields = nodes[0].fields
+	}
+	result := matches.prettyData()
+	result.Schema.Template = data.Schema.Template
+	return result, nil
+}
+
+// step applies a single query segment to the node
+func (n queryNode) step(segment querySegment) ([]queryNode, error) {
+	switch value := n.value.(type) {
+	case map[string]interface{}:
+		if segment.isIndex {
+			return nil, fmt.Errorf("cannot index %s, it is an object", n.name())
+		}
+		if segment.wildcard {
+			var children []queryNode
+			for _, field := range n.childFields(value) {
+				if child, ok := value[field.Name]; ok {
+					children = append(children, n.child(child, field))
+				}
+			}
+			return children, nil
+		}
+		field, ok := findField(n.childFields(value), segment.name)
+		if !ok {
+			return nil, unknownFieldError(segment.name, n.childFields(value))
+		}
+		child, ok := value[field.Name]
+		if !ok {
+			return nil, nil
+		}
+		return []queryNode{n.child(child, field)}, nil
+
+	case []interface{}:
+		element := api.PrettyField{Name: n.field.Name, Fields: n.fields}
+		if segment.wildcard {
+			children := make([]queryNode, len(value))
+			for i, child := range value {
+				children[i] = queryNode{value: child, field: element, fields: n.fields}
+			}
+			return children, nil
+		}
+		if !segment.isIndex {
+			return nil, fmt.Errorf("cannot select %q from %s, it is a list, use [*] or [n]", segment.name, n.name())
+		}
+		index := segment.index
+		if index < 0 {
+			index += len(value)
+		}
+		if index < 0 || index >= len(value) {
+			return nil, fmt.Errorf("index %d out of range for %s with %d items", segment.index, n.name(), len(value))
+		}
+		return []queryNode{{value: value[index], field: element, fields: n.fields}}, nil
+	}
+
+	return nil, fmt.Errorf("cannot select into %s, it is a %T", n.name(), n.value)
+}
+
+func (n queryNode) name() string {
+	if n.field.Name == "" {
+		return "$"
+	}
+	return n.field.Name
+}
+
+// child creates the node for a child value described by field
+func (n queryNode) child(value interface{}, field api.PrettyField) queryNode {
+	fields := field.Fields
+	if field.Format == api.FormatTable {
+		fields = api.TableColumns(field, nil)
+	}
+	return queryNode{value: value, field: field, fields: fields}
+}
+
+// childFields returns the known fields of an object node, adding any keys without a schema field
+func (n queryNode) childFields(value map[string]interface{}) []api.PrettyField {
+	fields := append([]api.PrettyField{}, n.fields...)
+	known := map[string]bool{}
+	for _, field := range fields {
+		known[field.Name] = true
+	}
+	var extra []string
+	for name := range value {
+		if !known[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		fields = append(fields, api.PrettyField{Name: name})
+	}
+	return fields
+}
+
+// prettyData builds PrettyData from the node: objects become values and tables,
+// lists of objects become a table and anything else a single value
+func (n queryNode) prettyData() *api.PrettyData {
+	result := &api.PrettyData{
+		Schema:   &api.PrettyObject{},
+		Values:   make(map[string]api.FieldValue),
+		Tables:   make(map[string][]api.PrettyDataRow),
+		Original: n.value,
+	}
+
+	name := n.field.Name
+	if name == "" {
+		name = "data"
+	}
+
+	switch value := n.value.(type) {
+	case map[string]interface{}:
+		for _, field := range n.childFields(value) {
+			child, ok := value[field.Name]
+			if !ok {
+				continue
+			}
+			if rows, ok := queryRows(child, n.child(child, field).fields); ok {
+				field.Format = api.FormatTable
+				result.Tables[field.Name] = rows
+			} else {
+				result.Values[field.Name] = api.FieldValue{Value: child, Field: field}
+			}
+			result.Schema.Fields = append(result.Schema.Fields, field)
+		}
+
+	default:
+		field := n.field
+		field.Name = name
+		if rows, ok := queryRows(n.value, n.fields); ok {
+			field.Format = api.FormatTable
+			result.Tables[name] = rows
+		} else {
+			if _, isList := n.value.([]interface{}); isList {
+				field.Format = ""
+				field.Type = api.FieldTypeArray
+			}
+			result.Values[name] = api.FieldValue{Value: n.value, Field: field}
+		}
+		result.Schema.Fields = []api.PrettyField{field}
+	}
+
+	return result
+}
+
+// queryRows converts a list of objects into table rows
+func queryRows(value interface{}, columns []api.PrettyField) ([]api.PrettyDataRow, bool) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	rows := make([]api.PrettyDataRow, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		row := make(api.PrettyDataRow, len(m))
+		for key, cell := range m {
+			column, found := findField(columns, key)
+			if !found || column.Name != key {
+				column = api.PrettyField{Name: key}
+			}
+			row[key] = api.FieldValue{Value: cell, Field: column}
+		}
+		rows = append(rows, row)
+	}
+	return rows, true
+}
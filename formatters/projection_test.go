@@ -0,0 +1,132 @@
+package formatters
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestProjection(t *testing.T) {
+	type item struct {
+		Name  string  `json:"name"`
+		Price float64 `json:"price" pretty:"currency"`
+	}
+	type order struct {
+		ID     string  `json:"id"`
+		Status string  `json:"status"`
+		Total  float64 `json:"total" pretty:"currency"`
+		Items  []item  `json:"items" pretty:"table"`
+	}
+	sample := order{
+		ID:     "ORD-1",
+		Status: "shipped",
+		Total:  12.5,
+		Items:  []item{{Name: "a", Price: 1.5}, {Name: "b", Price: 2}},
+	}
+
+	formatJSON := func(t *testing.T, options FormatOptions, data interface{}) interface{} {
+		t.Helper()
+		options.Format = "json"
+		output, err := NewFormatManager().FormatWithOptions(options, data)
+		if err != nil {
+			t.Fatalf("projection failed: %v", err)
+		}
+		var result interface{}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("invalid JSON %q: %v", output, err)
+		}
+		return result
+	}
+
+	t.Run("Fields", func(t *testing.T) {
+		result := formatJSON(t, FormatOptions{Fields: "id,items.name"}, sample)
+		expected := map[string]interface{}{
+			"id":    "ORD-1",
+			"items": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{Format: "yaml", Fields: "id"}, sample)
+		if err != nil {
+			t.Fatalf("projection failed: %v", err)
+		}
+		if strings.TrimSpace(output) != "id: ORD-1" {
+			t.Errorf("expected the JSON field name as the YAML key, got %q", output)
+		}
+	})
+
+	t.Run("SliceColumns", func(t *testing.T) {
+		result := formatJSON(t, FormatOptions{Fields: "price"}, sample.Items)
+		expected := []interface{}{map[string]interface{}{"price": 1.5}, map[string]interface{}{"price": 2.0}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("expected %v, got %v", expected, result)
+		}
+
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{CSV: true, Fields: "name"}, sample.Items)
+		if err != nil {
+			t.Fatalf("CSV projection failed: %v", err)
+		}
+		if strings.Contains(strings.ToLower(output), "price") {
+			t.Errorf("expected price column to be dropped from CSV, got %q", output)
+		}
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		result := formatJSON(t, FormatOptions{Query: "$.items[*]", Fields: "name"}, sample)
+		expected := []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("expected %v, got %v", expected, result)
+		}
+
+		result = formatJSON(t, FormatOptions{Query: "items[1].price"}, sample)
+		if result != 2.0 {
+			t.Errorf("expected 2, got %v", result)
+		}
+	})
+
+	t.Run("NestedAndLabels", func(t *testing.T) {
+		data := &api.PrettyData{
+			Schema: &api.PrettyObject{Fields: []api.PrettyField{
+				{Name: "id", Label: "Order ID"},
+				{Name: "customer", Type: api.FieldTypeStruct, Fields: []api.PrettyField{{Name: "name"}, {Name: "email"}}},
+			}},
+			Values: map[string]api.FieldValue{
+				"id":       {Value: "ORD-1"},
+				"customer": {Value: map[string]interface{}{"name": "Jane", "email": "jane@example.com"}},
+			},
+		}
+		projected, err := Project(data, "order id,customer.name", "")
+		if err != nil {
+			t.Fatalf("projection failed: %v", err)
+		}
+		if len(projected.Schema.Fields) != 2 || projected.Schema.Fields[0].Name != "id" {
+			t.Fatalf("expected id selected by its label, got %+v", projected.Schema.Fields)
+		}
+		customer := projected.Values["customer"]
+		if _, ok := customer.NestedFields["email"]; ok {
+			t.Errorf("expected email to be dropped, got %v", customer.NestedFields)
+		}
+		if name := customer.NestedFields["name"].Value; name != "Jane" {
+			t.Errorf("expected customer name Jane, got %v", name)
+		}
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := NewFormatManager().FormatWithOptions(FormatOptions{Fields: "id,items.sku"}, sample)
+		if err == nil || !strings.Contains(err.Error(), "items.sku") || !strings.Contains(err.Error(), "name, price") {
+			t.Errorf("expected an error listing the valid columns, got %v", err)
+		}
+
+		_, err = NewFormatManager().FormatWithOptions(FormatOptions{Query: "$.missing"}, sample)
+		if err == nil || !strings.Contains(err.Error(), "id, status, total, items") {
+			t.Errorf("expected an error listing the valid fields, got %v", err)
+		}
+	})
+}
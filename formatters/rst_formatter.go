@@ -0,0 +1,212 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// RSTFormatter handles reStructuredText formatting, e.g. for Sphinx sites. Styled text
+// uses custom roles such as :red:, which the output declares and a stylesheet can
+// style by their class.
+type RSTFormatter struct{}
+
+// NewRSTFormatter creates a new reStructuredText formatter
+func NewRSTFormatter() *RSTFormatter {
+	return &RSTFormatter{}
+}
+
+// Format formats data as reStructuredText
+func (f *RSTFormatter) Format(data interface{}) (string, error) {
+	switch value := data.(type) {
+	case api.Text:
+		return withRSTRoles(value.RST()), nil
+	case api.Pretty:
+		return withRSTRoles(value.Pretty().RST()), nil
+	}
+
+	prettyData, err := ToPrettyData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+
+	if prettyData == nil || prettyData.Schema == nil {
+		return "", nil
+	}
+
+	return f.FormatPrettyData(prettyData)
+}
+
+// FormatPrettyData formats PrettyData as reStructuredText: summary fields as a field
+// list, followed by tables and trees with their titles
+func (f *RSTFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return "", err
+	}
+
+	var sections []string
+	var summaryFields []api.PrettyField
+	for _, field := range data.Schema.Fields {
+		if field.Format != api.FormatTable && field.Format != api.FormatTree {
+			summaryFields = append(summaryFields, field)
+		}
+	}
+	if summary := f.formatFields(summaryFields, data.Values, ""); summary != "" {
+		sections = append(sections, summary)
+	}
+
+	for _, field := range data.Schema.Fields {
+		switch field.Format {
+		case api.FormatTable:
+			if rows := data.Tables[field.Name]; len(rows) > 0 {
+				sections = append(sections, f.formatTable(rows, field))
+			}
+		case api.FormatTree:
+			if fieldValue, exists := data.Values[field.Name]; exists {
+				sections = append(sections, f.formatTree(field, fieldValue))
+			}
+		}
+	}
+
+	return withRSTRoles(strings.Join(sections, "\n")), nil
+}
+
+// formatFields formats fields as a field list, nesting the fields of maps in the body
+// of their field
+func (f *RSTFormatter) formatFields(fields []api.PrettyField, values map[string]api.FieldValue, indent string) string {
+	var result strings.Builder
+	for _, field := range fields {
+		fieldValue, exists := values[field.Name]
+		if !exists {
+			continue
+		}
+		marker := fmt.Sprintf("%s:%s:", indent, fieldLabel(field))
+		if fieldValue.HasNestedFields() {
+			result.WriteString(marker + "\n")
+			result.WriteString(f.formatFields(nestedFields(field, fieldValue), fieldValue.NestedFields, indent+"    "))
+			continue
+		}
+		value := f.formatValue(fieldValue, field)
+		if strings.Contains(value, "\n") {
+			result.WriteString(marker + "\n" + rstIndent(value, indent+"    ", true) + "\n")
+		} else {
+			result.WriteString(marker + " " + value + "\n")
+		}
+	}
+	return result.String()
+}
+
+// formatValue formats a value as inline reStructuredText, or as a block for images
+// and multi-line values
+func (f *RSTFormatter) formatValue(fieldValue api.FieldValue, field api.PrettyField) string {
+	if isImageField(fieldValue, field) {
+		if src, ok := fieldValue.Value.(string); ok && src != "" {
+			return fmt.Sprintf(".. image:: %s\n   :alt: %s", src, fieldLabel(field))
+		}
+	}
+	value := fieldValue.RST()
+	if !strings.Contains(value, "\n") {
+		return value
+	}
+	// A line block keeps the line breaks of multi-line values
+	return "| " + strings.ReplaceAll(value, "\n", "\n| ")
+}
+
+// formatTable formats table rows as a list-table directive with a header row
+func (f *RSTFormatter) formatTable(rows []api.PrettyDataRow, field api.PrettyField) string {
+	columns := api.TableColumns(field, rows)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf(".. list-table:: %s\n", tableTitle(field)))
+	result.WriteString("   :header-rows: 1\n\n")
+
+	var headers []string
+	for _, column := range columns {
+		headers = append(headers, fieldLabel(column))
+	}
+	result.WriteString(rstTableRow(headers))
+
+	for _, row := range rows {
+		var cells []string
+		for _, column := range columns {
+			var cell string
+			if fieldValue, exists := row[column.Name]; exists {
+				if row.Kind() != "" {
+					// Group headers and aggregate rows are bold, which cannot contain other markup
+					if text := strings.TrimSpace(fieldValue.Formatted()); text != "" {
+						cell = "**" + strings.ReplaceAll(text, "*", "\\*") + "**"
+					}
+				} else {
+					cell = f.formatValue(fieldValue, column)
+				}
+			}
+			cells = append(cells, cell)
+		}
+		result.WriteString(rstTableRow(cells))
+	}
+	return result.String()
+}
+
+// rstTableRow formats the cells of a list-table row as a nested bullet list
+func rstTableRow(cells []string) string {
+	var result strings.Builder
+	for i, cell := range cells {
+		bullet := "     - "
+		if i == 0 {
+			bullet = "   * - "
+		}
+		if cell == "" {
+			result.WriteString(strings.TrimRight(bullet, " ") + "\n")
+			continue
+		}
+		result.WriteString(bullet + rstIndent(cell, strings.Repeat(" ", len(bullet)), false) + "\n")
+	}
+	return result.String()
+}
+
+// formatTree formats a tree as a rubric followed by a nested bullet list
+func (f *RSTFormatter) formatTree(field api.PrettyField, fieldValue api.FieldValue) string {
+	node, ok := fieldValue.Value.(api.TreeNode)
+	if !ok {
+		return f.formatFields([]api.PrettyField{field}, map[string]api.FieldValue{field.Name: fieldValue}, "")
+	}
+	return fmt.Sprintf(".. rubric:: %s\n\n%s\n", fieldLabel(field), strings.TrimRight(f.formatTreeNode(node, 0), "\n"))
+}
+
+// formatTreeNode formats a tree node and its children. Nested lists must be separated
+// by blank lines, so every item is.
+func (f *RSTFormatter) formatTreeNode(node api.TreeNode, depth int) string {
+	if node == nil {
+		return ""
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("%s- %s\n\n", strings.Repeat("  ", depth), node.Pretty().RST()))
+	for _, child := range node.GetChildren() {
+		result.WriteString(f.formatTreeNode(child, depth+1))
+	}
+	return result.String()
+}
+
+// rstIndent indents the lines of a block, skipping the first line when it follows a
+// marker on the same line
+func rstIndent(block, indent string, first bool) string {
+	lines := strings.Split(block, "\n")
+	for i := range lines {
+		if i > 0 || first {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withRSTRoles declares the custom roles used by the output at its start
+func withRSTRoles(output string) string {
+	roles := api.RSTRoles(output)
+	if len(roles) == 0 {
+		return output
+	}
+	return strings.Join(roles, "\n") + "\n\n" + output
+}
@@ -0,0 +1,39 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestRSTFormatter(t *testing.T) {
+	output, err := NewRSTFormatter().FormatPrettyData(docsReport(t))
+	if err != nil {
+		t.Fatalf("rst failed: %v", err)
+	}
+
+	if !strings.HasPrefix(output, ".. role:: red\n\n") {
+		t.Errorf("expected the red role to be declared first, got:\n%s", output)
+	}
+	for _, expected := range []string{
+		":Name: web\n",
+		":Status: :red:`failed`\n",
+		":Logo:\n    .. image:: https://example.com/logo.png\n       :alt: Logo\n",
+		":Labels:\n    :Owner:\n        :Email: ops@example.com\n    :Team: platform\n",
+		".. list-table:: Recent orders\n   :header-rows: 1\n\n   * - Id\n     - Amount\n   * - a|1\n     - 1500\n",
+		".. rubric:: Files\n\n- src\n\n  - cmd\n\n    - main.go\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in RST output, got:\n%s", expected, output)
+		}
+	}
+
+	output, err = NewFormatManager().Format("rst", api.Text{Content: "ok", Style: "text-green-600"})
+	if err != nil {
+		t.Fatalf("rst failed: %v", err)
+	}
+	if output != ".. role:: green\n\n:green:`ok`" {
+		t.Errorf("expected the text as RST, got %q", output)
+	}
+}
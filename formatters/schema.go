@@ -143,6 +143,11 @@ func (sf *SchemaFormatter) convertMapToStruct(data map[string]interface{}) inter
 
 // formatWithPrettyData formats PrettyData using the specified format
 func (sf *SchemaFormatter) formatWithPrettyData(data *api.PrettyData, options FormatOptions) (string, error) {
+	data, err := options.reshape(data)
+	if err != nil {
+		return "", err
+	}
+
 	// Convert PrettyData to the appropriate format for the FormatManager
 	output := sf.formatPrettyDataToMap(data)
 
@@ -155,6 +160,8 @@ func (sf *SchemaFormatter) formatWithPrettyData(data *api.PrettyData, options Fo
 			return "", err
 		}
 		return string(b), nil
+	case "ndjson", "jsonl":
+		return NewNDJSONFormatter().FormatPrettyData(data)
 	case "yaml", "yml":
 		b, err := yaml.Marshal(output)
 		if err != nil {
@@ -165,6 +172,19 @@ func (sf *SchemaFormatter) formatWithPrettyData(data *api.PrettyData, options Fo
 		csvFormatter := NewCSVFormatter()
 		// Use the original PrettyData directly for CSV formatting
 		return csvFormatter.FormatPrettyData(data)
+	case "xlsx", "excel":
+		// Use the original PrettyData so cells keep their numeric and date types
+		return NewXLSXFormatter().FormatPrettyData(data)
+	case "pdf":
+		pdfFormatter := NewPDFFormatter()
+		if options.PDFBackend != "" {
+			pdfFormatter.Backend = options.PDFBackend
+		}
+		return pdfFormatter.Format(data)
+	case "template":
+		return NewFormatManager().FormatWithSchema(data, options)
+	case "html":
+		return NewFormatManager().html(options).Format(data)
 	default:
 		// For other formats, delegate to the format manager
 		manager := NewFormatManager()
@@ -188,6 +208,7 @@ func (sf *SchemaFormatter) formatPrettyDataToMap(data *api.PrettyData) map[strin
 
 	// Add all tables using Formatted() for consistency
 	for key, tableRows := range data.Tables {
+		tableRows = api.DataRows(tableRows)
 		tableData := make([]map[string]interface{}, len(tableRows))
 		for i, row := range tableRows {
 			rowData := make(map[string]interface{})
@@ -247,6 +268,8 @@ func (sf *SchemaFormatter) getExtensionForFormat(format string) string {
 	switch strings.ToLower(format) {
 	case "json":
 		return "json"
+	case "ndjson", "jsonl":
+		return "ndjson"
 	case "yaml":
 		return "yaml"
 	case "csv":
@@ -255,6 +278,8 @@ func (sf *SchemaFormatter) getExtensionForFormat(format string) string {
 		return "html"
 	case "pdf":
 		return "pdf"
+	case "xlsx", "excel":
+		return "xlsx"
 	case "markdown":
 		return "md"
 	default:
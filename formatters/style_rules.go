@@ -0,0 +1,158 @@
+package formatters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// ApplyStyleRules sets the Style of values and table cells matched by the style rules
+// of their fields. Rules are evaluated in order and the first matching cell rule and
+// row rule apply; value in a rule is the field being styled, and other names are the
+// columns of the same row, or the other top-level fields. Fields with a link template
+// get the URL built from the same names. The data is not modified.
+func ApplyStyleRules(data *api.PrettyData) (*api.PrettyData, error) {
+	if data == nil || data.Schema == nil {
+		return data, nil
+	}
+
+	rules := styleRuleSet{}
+	links := linkTemplates{}
+	result := *data
+	result.Values = make(map[string]api.FieldValue, len(data.Values))
+	for name, value := range data.Values {
+		result.Values[name] = value
+	}
+	result.Tables = make(map[string][]api.PrettyDataRow, len(data.Tables))
+	for name, rows := range data.Tables {
+		result.Tables[name] = rows
+	}
+
+	for _, field := range data.Schema.Fields {
+		if rows, ok := data.Tables[field.Name]; ok {
+			styled, err := rules.styleRows(field, rows)
+			if err != nil {
+				return nil, err
+			}
+			if styled, err = links.linkRows(field, styled); err != nil {
+				return nil, err
+			}
+			result.Tables[field.Name] = styled
+			continue
+		}
+
+		value, ok := data.Values[field.Name]
+		if !ok {
+			continue
+		}
+		fieldRules := field.Rules
+		if len(fieldRules) == 0 {
+			fieldRules = value.Field.Rules
+		}
+		cell, _, err := rules.match(fieldRules, data.Values, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		value.Style = cell
+		if value.Link, err = links.link(field, data.Values, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		result.Values[field.Name] = value
+	}
+	return &result, nil
+}
+
+// styleRuleSet caches the compiled expressions of style rules
+type styleRuleSet map[string]*Filter
+
+// styleRows returns the rows with the styles of matching rules, copying only the rows that match
+func (s styleRuleSet) styleRows(field api.PrettyField, rows []api.PrettyDataRow) ([]api.PrettyDataRow, error) {
+	columns := api.TableColumns(field, rows)
+	var styled []api.PrettyDataRow
+	for i, row := range rows {
+		if row.Kind() != "" {
+			continue
+		}
+
+		var rowStyle string
+		cellStyles := make(map[string]string)
+		for _, column := range columns {
+			value, ok := row[column.Name]
+			if !ok {
+				continue
+			}
+			rules := column.Rules
+			if len(rules) == 0 {
+				rules = value.Field.Rules
+			}
+			cell, whole, err := s.match(rules, row, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", field.Name, column.Name, err)
+			}
+			if cell != "" {
+				cellStyles[column.Name] = cell
+			}
+			if rowStyle == "" {
+				rowStyle = whole
+			}
+		}
+		if rowStyle == "" && len(cellStyles) == 0 {
+			continue
+		}
+
+		if styled == nil {
+			styled = append([]api.PrettyDataRow(nil), rows...)
+		}
+		styledRow := make(api.PrettyDataRow, len(row))
+		for name, value := range row {
+			// Cell rules come after row rules, so they take precedence
+			value.Style = strings.TrimSpace(rowStyle + " " + cellStyles[name])
+			styledRow[name] = value
+		}
+		styled[i] = styledRow
+	}
+	if styled == nil {
+		return rows, nil
+	}
+	return styled, nil
+}
+
+// match returns the styles of the first matching cell rule and row rule
+func (s styleRuleSet) match(rules []api.StyleRule, row api.PrettyDataRow, value api.FieldValue) (cell, whole string, err error) {
+	if len(rules) == 0 {
+		return "", "", nil
+	}
+
+	scope := make(api.PrettyDataRow, len(row)+1)
+	for name, v := range row {
+		scope[name] = v
+	}
+	scope["value"] = value
+
+	for _, rule := range rules {
+		if (rule.Row && whole != "") || (!rule.Row && cell != "") {
+			continue
+		}
+		filter, ok := s[rule.When]
+		if !ok {
+			if filter, err = ParseFilter(rule.When); err != nil {
+				return "", "", err
+			}
+			s[rule.When] = filter
+		}
+		matched, err := filter.Match(scope)
+		if err != nil {
+			return "", "", err
+		}
+		if !matched {
+			continue
+		}
+		if rule.Row {
+			whole = rule.Style
+		} else {
+			cell = rule.Style
+		}
+	}
+	return cell, whole, nil
+}
@@ -0,0 +1,115 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+	"gopkg.in/yaml.v3"
+)
+
+type ruledCheck struct {
+	Name   string `json:"name" pretty:"when=name =~ '^web-' -> italic"`
+	Score  int    `json:"score" pretty:"when=value > 90 -> text-red-600 font-bold,when=value between 50 and 90 -> text-yellow-600"`
+	Owner  string `json:"owner" pretty:"when=score < 10 -> text-gray-400"`
+	Status string `json:"status" pretty:"row_when=status == 'failed' -> bg-red-100"`
+}
+
+func TestApplyStyleRules(t *testing.T) {
+	checks := []ruledCheck{
+		{Name: "web-1", Score: 95, Owner: "ops", Status: "ok"},
+		{Name: "db-1", Score: 60, Owner: "dba", Status: "failed"},
+		{Name: "db-2", Score: 5, Owner: "dba", Status: "ok"},
+	}
+
+	t.Run("Cells", func(t *testing.T) {
+		data, err := ToPrettyData(checks)
+		if err != nil {
+			t.Fatalf("failed to convert: %v", err)
+		}
+		styled, err := ApplyStyleRules(data)
+		if err != nil {
+			t.Fatalf("failed to apply rules: %v", err)
+		}
+		rows := styled.Tables["data"]
+
+		tests := []struct {
+			row      int
+			column   string
+			expected string
+		}{
+			{0, "name", "italic"},
+			{0, "score", "text-red-600 font-bold"},
+			{1, "score", "bg-red-100 text-yellow-600"},
+			{1, "owner", "bg-red-100"},
+			{2, "score", ""},
+			{2, "owner", "text-gray-400"},
+		}
+		for _, test := range tests {
+			if style := rows[test.row][test.column].Style; style != test.expected {
+				t.Errorf("row %d %s: expected style %q, got %q", test.row, test.column, test.expected, style)
+			}
+		}
+		if data.Tables["data"][0]["score"].Style != "" {
+			t.Errorf("expected the original data to be unchanged")
+		}
+	})
+
+	t.Run("Formatters", func(t *testing.T) {
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{Format: "html"}, checks)
+		if err != nil {
+			t.Fatalf("html failed: %v", err)
+		}
+		if !strings.Contains(output, `class="text-red-600 font-bold"`) {
+			t.Errorf("expected the matched classes in HTML, got:\n%s", output)
+		}
+
+		output, err = NewFormatManager().FormatWithOptions(FormatOptions{Format: "pretty", NoColor: true}, checks)
+		if err != nil || !strings.Contains(output, "web-1") {
+			t.Errorf("expected plain pretty output, got %q (%v)", output, err)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		var schema api.PrettyObject
+		err := yaml.Unmarshal([]byte(`
+fields:
+  - name: usage
+    type: float
+    rules:
+      - "value >= 0.9 → text-red-600"
+      - when: "value >= 0.7"
+        style: text-yellow-600
+      - when: "limit == 0"
+        style: text-gray-400
+        row: true
+  - name: limit
+    type: int
+`), &schema)
+		if err != nil {
+			t.Fatalf("failed to parse schema: %v", err)
+		}
+		if rules := schema.Fields[0].Rules; len(rules) != 3 || rules[0].When != "value >= 0.9" || rules[0].Style != "text-red-600" {
+			t.Fatalf("unexpected rules %+v", rules)
+		}
+
+		data, err := api.NewStructParser().ParseDataWithSchema(map[string]interface{}{"usage": 0.75, "limit": 0}, &schema)
+		if err != nil {
+			t.Fatalf("failed to parse data: %v", err)
+		}
+		styled, err := ApplyStyleRules(data)
+		if err != nil {
+			t.Fatalf("failed to apply rules: %v", err)
+		}
+		if style := styled.Values["usage"].Style; style != "text-yellow-600" {
+			t.Errorf("expected the second rule to match, got %q", style)
+		}
+
+		if _, err := ApplyStyleRules(&api.PrettyData{
+			Schema: &api.PrettyObject{Fields: []api.PrettyField{{Name: "x", Rules: []api.StyleRule{{When: "value >", Style: "italic"}}}}},
+			Values: map[string]api.FieldValue{"x": {Value: 1}},
+		}); err == nil {
+			t.Errorf("expected an error for an invalid rule")
+		}
+	})
+}
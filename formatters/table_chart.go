@@ -0,0 +1,420 @@
+package formatters
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/flanksource/clicky/api"
+)
+
+const (
+	chartWidth      = 480.0
+	chartPlotHeight = 180.0
+	// chartLabelRunes is the longest x label drawn before truncating
+	chartLabelRunes = 12
+)
+
+var (
+	// chartFills tell series apart in bar and pie charts drawn without color
+	chartFills = []rune("█▓▒░")
+	// chartMarkers tell series apart in line charts
+	chartMarkers = []rune("●◆■▲")
+)
+
+// tableChart is a bar, line or pie chart of the data rows of a table, drawn next to it
+type tableChart struct {
+	spec   api.TableChart
+	labels []string
+	series []chartSeries
+	// colors are the hex colors of each series, or of each slice of a pie chart
+	colors []string
+	locale api.Locale
+}
+
+// chartSeries is one y column of a table chart
+type chartSeries struct {
+	name   string
+	values []float64
+}
+
+// newTableChart reads the series of a table's chart from its rows, skipping group
+// headers and aggregate rows, returning false for tables without a chart or rows
+func newTableChart(field api.PrettyField, rows []api.PrettyDataRow, theme api.Theme) (tableChart, bool) {
+	spec := field.TableOptions.Chart
+	if spec == nil || len(spec.Y) == 0 {
+		return tableChart{}, false
+	}
+	chart := tableChart{spec: *spec, locale: field.Locale()}
+	if chart.spec.Type == "" {
+		chart.spec.Type = api.ChartBar
+	}
+	columns := chart.spec.Y
+	if chart.spec.Type == api.ChartPie {
+		columns = columns[:1]
+	}
+
+	names := make(map[string]string)
+	for _, column := range api.TableColumns(field, rows) {
+		names[column.Name] = fieldLabel(column)
+	}
+	for _, column := range columns {
+		name := names[column]
+		if name == "" {
+			name = api.PrettifyFieldName(column)
+		}
+		chart.series = append(chart.series, chartSeries{name: name})
+	}
+
+	for _, row := range rows {
+		if row.Kind() != "" {
+			continue
+		}
+		label := ""
+		if value, exists := row[chart.spec.X]; exists && value.Value != nil {
+			label = stripAnsi(value.Formatted())
+		}
+		chart.labels = append(chart.labels, label)
+		for i, column := range columns {
+			var n float64
+			if values := miniChartValues(reflect.ValueOf(row[column].Value)); len(values) > 0 {
+				n = values[0]
+			}
+			chart.series[i].values = append(chart.series[i].values, n)
+		}
+	}
+	if len(chart.labels) == 0 {
+		return tableChart{}, false
+	}
+
+	if chart.spec.Type == api.ChartPie {
+		chart.colors = theme.ChartColors(chart.spec, len(chart.labels))
+	} else {
+		chart.colors = theme.ChartColors(chart.spec, len(chart.series))
+	}
+	return chart, true
+}
+
+// bounds returns the y axis range, which always includes zero
+func (c tableChart) bounds() (float64, float64) {
+	var low, high float64
+	for _, series := range c.series {
+		for _, v := range series.values {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+	if high == low {
+		high = low + 1
+	}
+	return low, high
+}
+
+// slices returns the share of each row in a pie chart, ignoring negative values
+func (c tableChart) slices() []float64 {
+	shares := make([]float64, len(c.labels))
+	var total float64
+	for _, v := range c.series[0].values {
+		total += math.Max(v, 0)
+	}
+	if total == 0 {
+		return shares
+	}
+	for i, v := range c.series[0].values {
+		shares[i] = math.Max(v, 0) / total
+	}
+	return shares
+}
+
+// axisNumber formats a y axis tick or a value label in the locale of the table
+func (c tableChart) axisNumber(v float64) string {
+	return c.locale.FormatSI(v, 1)
+}
+
+// shortLabel truncates x labels that would overlap their neighbours
+func shortLabel(label string) string {
+	if runes := []rune(label); len(runes) > chartLabelRunes {
+		return string(runes[:chartLabelRunes-1]) + "…"
+	}
+	return label
+}
+
+// SVG draws the chart as an inline SVG with a title, axes and legend
+func (c tableChart) SVG() string {
+	var shapes strings.Builder
+	top := 12.0
+	if c.spec.Title != "" {
+		top = 36
+		fmt.Fprintf(&shapes, `<text x="%.0f" y="22" text-anchor="middle" font-size="14" font-weight="bold" fill="#111827">%s</text>`,
+			chartWidth/2, html.EscapeString(c.spec.Title))
+	}
+
+	var height float64
+	if c.spec.Type == api.ChartPie {
+		height = c.svgPie(&shapes, top)
+	} else {
+		height = c.svgAxes(&shapes, top)
+	}
+
+	return fmt.Sprintf(`<svg class="table-chart" xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" role="img" aria-label="%s" font-family="sans-serif">%s</svg>`,
+		chartWidth, height, chartWidth, height, html.EscapeString(c.describe()), shapes.String())
+}
+
+// describe is the accessible name of the chart
+func (c tableChart) describe() string {
+	if c.spec.Title != "" {
+		return c.spec.Title
+	}
+	names := make([]string, len(c.series))
+	for i, series := range c.series {
+		names[i] = series.name
+	}
+	return c.spec.Type + " chart of " + strings.Join(names, ", ")
+}
+
+// svgAxes draws a bar or line chart with y ticks and x labels, returning the height used
+func (c tableChart) svgAxes(shapes *strings.Builder, top float64) float64 {
+	const left, right = 48.0, 16.0
+	width := chartWidth - left - right
+	low, high := c.bounds()
+	y := func(v float64) float64 {
+		return top + chartPlotHeight*(1-(v-low)/(high-low))
+	}
+
+	for i := 0; i <= 4; i++ {
+		v := low + (high-low)*float64(i)/4
+		fmt.Fprintf(shapes, `<line x1="%.0f" y1="%.1f" x2="%.0f" y2="%.1f" stroke="#e5e7eb"/>`, left, y(v), left+width, y(v))
+		fmt.Fprintf(shapes, `<text x="%.0f" y="%.1f" text-anchor="end" font-size="10" fill="#6b7280">%s</text>`, left-6, y(v)+3, html.EscapeString(c.axisNumber(v)))
+	}
+	fmt.Fprintf(shapes, `<line x1="%.0f" y1="%.1f" x2="%.0f" y2="%.1f" stroke="#9ca3af"/>`, left, y(0), left+width, y(0))
+
+	step := width / float64(len(c.labels))
+	for i, label := range c.labels {
+		fmt.Fprintf(shapes, `<text x="%.1f" y="%.1f" text-anchor="middle" font-size="10" fill="#374151">%s</text>`,
+			left+step*(float64(i)+0.5), top+chartPlotHeight+16, html.EscapeString(shortLabel(label)))
+	}
+
+	for s, series := range c.series {
+		color := c.colors[s]
+		if c.spec.Type == api.ChartLine {
+			points := make([]string, len(series.values))
+			for i, v := range series.values {
+				points[i] = fmt.Sprintf("%.1f,%.1f", left+step*(float64(i)+0.5), y(v))
+			}
+			fmt.Fprintf(shapes, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2" stroke-linejoin="round"/>`, strings.Join(points, " "), color)
+			for i, v := range series.values {
+				fmt.Fprintf(shapes, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s</title></circle>`,
+					left+step*(float64(i)+0.5), y(v), color, html.EscapeString(c.labels[i]+": "+c.axisNumber(v)))
+			}
+			continue
+		}
+
+		bar := step * 0.8 / float64(len(c.series))
+		for i, v := range series.values {
+			fmt.Fprintf(shapes, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s</title></rect>`,
+				left+step*(float64(i)+0.1)+bar*float64(s), math.Min(y(v), y(0)), bar, math.Abs(y(v)-y(0)), color,
+				html.EscapeString(c.labels[i]+": "+c.axisNumber(v)))
+		}
+	}
+
+	bottom := top + chartPlotHeight + 28
+	if len(c.series) > 1 {
+		x := left
+		for s, series := range c.series {
+			fmt.Fprintf(shapes, `<rect x="%.0f" y="%.0f" width="10" height="10" fill="%s"/>`, x, bottom, c.colors[s])
+			fmt.Fprintf(shapes, `<text x="%.0f" y="%.0f" font-size="11" fill="#374151">%s</text>`, x+14, bottom+9, html.EscapeString(series.name))
+			x += 24 + 6*float64(len([]rune(series.name)))
+		}
+		bottom += 18
+	}
+	return bottom
+}
+
+// svgPie draws a pie chart with a legend of each slice's value and share, returning the height used
+func (c tableChart) svgPie(shapes *strings.Builder, top float64) float64 {
+	const radius = 80.0
+	cx, cy := 24+radius, top+radius+8
+	shares := c.slices()
+
+	angle := -math.Pi / 2
+	for i, share := range shares {
+		if share == 0 {
+			continue
+		}
+		title := html.EscapeString(fmt.Sprintf("%s: %s (%.0f%%)", c.labels[i], c.axisNumber(c.series[0].values[i]), share*100))
+		if share >= 1 {
+			fmt.Fprintf(shapes, `<circle cx="%.1f" cy="%.1f" r="%.0f" fill="%s"><title>%s</title></circle>`, cx, cy, radius, c.colors[i], title)
+			continue
+		}
+		end := angle + share*2*math.Pi
+		large := 0
+		if share > 0.5 {
+			large = 1
+		}
+		fmt.Fprintf(shapes, `<path d="M%.1f,%.1f L%.1f,%.1f A%.0f,%.0f 0 %d 1 %.1f,%.1f Z" fill="%s" stroke="#ffffff"><title>%s</title></path>`,
+			cx, cy, cx+radius*math.Cos(angle), cy+radius*math.Sin(angle), radius, radius, large,
+			cx+radius*math.Cos(end), cy+radius*math.Sin(end), c.colors[i], title)
+		angle = end
+	}
+
+	x, y := cx+radius+32, top+8
+	for i, label := range c.labels {
+		fmt.Fprintf(shapes, `<rect x="%.0f" y="%.0f" width="10" height="10" fill="%s"/>`, x, y, c.colors[i])
+		fmt.Fprintf(shapes, `<text x="%.0f" y="%.0f" font-size="11" fill="#374151">%s</text>`, x+14, y+9,
+			html.EscapeString(fmt.Sprintf("%s %s (%.0f%%)", label, c.axisNumber(c.series[0].values[i]), shares[i]*100)))
+		y += 16
+	}
+	return math.Max(cy+radius+8, y)
+}
+
+// ASCII draws the chart for the terminal: horizontal bars, a plot of markers, or a
+// stacked bar with a legend for pie charts
+func (c tableChart) ASCII(color bool) string {
+	paint := func(s string, i int) string {
+		if !color {
+			return s
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c.colors[i])).Render(s)
+	}
+
+	var lines []string
+	if c.spec.Title != "" {
+		title := c.spec.Title
+		if color {
+			title = lipgloss.NewStyle().Bold(true).Render(title)
+		}
+		lines = append(lines, title)
+	}
+
+	switch c.spec.Type {
+	case api.ChartPie:
+		return strings.Join(append(lines, c.asciiPie(paint, color)...), "\n")
+	case api.ChartLine:
+		lines = append(lines, c.asciiLine(paint)...)
+	default:
+		lines = append(lines, c.asciiBars(paint, color)...)
+	}
+
+	if len(c.series) > 1 {
+		var legend []string
+		for s, series := range c.series {
+			marker := chartMarkers[s%len(chartMarkers)]
+			if c.spec.Type != api.ChartLine {
+				marker = c.fill(s, color)
+			}
+			legend = append(legend, paint(string(marker), s)+" "+series.name)
+		}
+		lines = append(lines, strings.Join(legend, "  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fill is the block character of a series or slice, which varies without color
+func (c tableChart) fill(i int, color bool) rune {
+	if color {
+		return chartFills[0]
+	}
+	return chartFills[i%len(chartFills)]
+}
+
+// asciiBars draws one horizontal bar per row and series, scaled to the largest value
+func (c tableChart) asciiBars(paint func(string, int) string, color bool) []string {
+	const width = 40
+	labelWidth := 0
+	for _, label := range c.labels {
+		labelWidth = max(labelWidth, lipgloss.Width(shortLabel(label)))
+	}
+	_, high := c.bounds()
+
+	var lines []string
+	for i, label := range c.labels {
+		for s, series := range c.series {
+			v := series.values[i]
+			var bar string
+			if fill := c.fill(s, color); fill == chartFills[0] {
+				eighths := int(math.Round(math.Max(v, 0) / high * width * 8))
+				bar = strings.Repeat(string(fill), eighths/8)
+				if eighths%8 > 0 {
+					bar += string(barEighths[eighths%8])
+				}
+			} else {
+				bar = strings.Repeat(string(fill), int(math.Round(math.Max(v, 0)/high*width)))
+			}
+			if s > 0 {
+				label = ""
+			}
+			lines = append(lines, fmt.Sprintf("%-*s │%s %s", labelWidth, shortLabel(label), paint(bar, s), c.axisNumber(v)))
+		}
+	}
+	return lines
+}
+
+// asciiLine plots each value as a marker on a grid, with the y range on the left and
+// the first and last x labels below
+func (c tableChart) asciiLine(paint func(string, int) string) []string {
+	const height = 10
+	step := max(1, min(6, 60/len(c.labels)))
+	low, high := c.bounds()
+
+	grid := make([][]string, height)
+	for row := range grid {
+		grid[row] = make([]string, step*len(c.labels))
+		for col := range grid[row] {
+			grid[row][col] = " "
+		}
+	}
+	for s, series := range c.series {
+		for i, v := range series.values {
+			row := height - 1 - int(math.Round((v-low)/(high-low)*(height-1)))
+			grid[row][step*i+step/2] = paint(string(chartMarkers[s%len(chartMarkers)]), s)
+		}
+	}
+
+	top, bottom := c.axisNumber(high), c.axisNumber(low)
+	axisWidth := max(len(top), len(bottom))
+	var lines []string
+	for row, cells := range grid {
+		label, axis := "", "│"
+		switch row {
+		case 0:
+			label, axis = top, "┤"
+		case height - 1:
+			label, axis = bottom, "┤"
+		}
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("%*s %s%s", axisWidth, label, axis, strings.Join(cells, "")), " "))
+	}
+	lines = append(lines, strings.Repeat(" ", axisWidth+1)+"└"+strings.Repeat("─", step*len(c.labels)))
+
+	first, last := shortLabel(c.labels[0]), shortLabel(c.labels[len(c.labels)-1])
+	axis := strings.Repeat(" ", axisWidth+2) + first
+	if len(c.labels) > 1 {
+		if gap := step*len(c.labels) - lipgloss.Width(first) - lipgloss.Width(last); gap > 0 {
+			axis += strings.Repeat(" ", gap) + last
+		}
+	}
+	return append(lines, axis)
+}
+
+// asciiPie draws the slices as one stacked bar followed by a legend with their shares
+func (c tableChart) asciiPie(paint func(string, int) string, color bool) []string {
+	const width = 40
+	shares := c.slices()
+	var bar strings.Builder
+	var cumulative float64
+	drawn := 0
+	for i, share := range shares {
+		cumulative += share
+		n := int(math.Round(cumulative*width)) - drawn
+		drawn += n
+		bar.WriteString(paint(strings.Repeat(string(c.fill(i, color)), n), i))
+	}
+
+	lines := []string{bar.String()}
+	for i, label := range c.labels {
+		lines = append(lines, fmt.Sprintf("%s %s %s (%.0f%%)", paint(string(c.fill(i, color)), i), label, c.axisNumber(c.series[0].values[i]), shares[i]*100))
+	}
+	return lines
+}
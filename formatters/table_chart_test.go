@@ -0,0 +1,146 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestTableChart(t *testing.T) {
+	var schema api.PrettyObject
+	err := yaml.Unmarshal([]byte(`
+fields:
+  - name: sales
+    format: table
+    table_options:
+      chart:
+        type: bar
+        x: region
+        y: [revenue, cost]
+        title: Revenue by region
+      fields:
+        - name: region
+        - name: revenue
+        - name: cost
+`), &schema)
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if chart := schema.Fields[0].TableOptions.Chart; chart == nil || chart.X != "region" || len(chart.Y) != 2 {
+		t.Fatalf("unexpected chart %+v", chart)
+	}
+
+	data, err := api.NewStructParser().ParseDataWithSchema(map[string]interface{}{
+		"sales": []map[string]interface{}{
+			{"region": "north", "revenue": 400, "cost": 100},
+			{"region": "south", "revenue": 200, "cost": 50},
+		},
+	}, &schema)
+	if err != nil {
+		t.Fatalf("failed to parse data: %v", err)
+	}
+	field, rows := schema.Fields[0], data.Tables["sales"]
+
+	t.Run("Series", func(t *testing.T) {
+		chart, ok := newTableChart(field, rows, api.DefaultTheme())
+		if !ok {
+			t.Fatalf("expected a chart")
+		}
+		if strings.Join(chart.labels, ",") != "north,south" || chart.series[1].name != "Cost" || chart.series[0].values[1] != 200 {
+			t.Errorf("unexpected series %+v", chart)
+		}
+		if chart.colors[0] != string(api.DefaultTheme().Primary) {
+			t.Errorf("expected the first series in the theme's primary color, got %s", chart.colors[0])
+		}
+
+		field.TableOptions.Chart.Colors = []string{"emerald-600"}
+		chart, _ = newTableChart(field, rows, api.DefaultTheme())
+		if chart.colors[0] != "#059669" {
+			t.Errorf("expected the Tailwind color of the chart, got %s", chart.colors[0])
+		}
+		field.TableOptions.Chart.Colors = nil
+
+		if _, ok := newTableChart(api.PrettyField{Name: "sales"}, rows, api.DefaultTheme()); ok {
+			t.Errorf("expected no chart for a table without one")
+		}
+	})
+
+	t.Run("SVG", func(t *testing.T) {
+		chart, _ := newTableChart(field, rows, api.DefaultTheme())
+		svg := chart.SVG()
+		if !strings.HasPrefix(svg, `<svg class="table-chart"`) || !strings.Contains(svg, "Revenue by region") {
+			t.Errorf("expected a titled SVG, got %s", svg)
+		}
+		if bars := strings.Count(svg, "<rect"); bars != 6 {
+			t.Errorf("expected 4 bars and 2 legend keys, got %d rects", bars)
+		}
+
+		chart.spec.Type = api.ChartLine
+		if svg := chart.SVG(); strings.Count(svg, "<polyline") != 2 {
+			t.Errorf("expected a line per series, got %s", svg)
+		}
+	})
+
+	t.Run("ASCII", func(t *testing.T) {
+		chart, _ := newTableChart(field, rows, api.DefaultTheme())
+		expected := strings.Join([]string{
+			"Revenue by region",
+			"north │" + strings.Repeat("█", 40) + " 400",
+			"      │" + strings.Repeat("▓", 10) + " 100",
+			"south │" + strings.Repeat("█", 20) + " 200",
+			"      │" + strings.Repeat("▓", 5) + " 50",
+			"█ Revenue  ▓ Cost",
+		}, "\n")
+		if got := chart.ASCII(false); got != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+		}
+
+		chart.spec.Type = api.ChartLine
+		lines := strings.Split(chart.ASCII(false), "\n")
+		if len(lines) != 14 || !strings.Contains(lines[1], "400 ┤") || !strings.Contains(lines[12], "north") {
+			t.Errorf("unexpected line chart:\n%s", strings.Join(lines, "\n"))
+		}
+	})
+
+	t.Run("Pie", func(t *testing.T) {
+		field := field
+		pie := *field.TableOptions.Chart
+		pie.Type, pie.Title = api.ChartPie, ""
+		field.TableOptions.Chart = &pie
+
+		chart, _ := newTableChart(field, rows, api.DefaultTheme())
+		if len(chart.series) != 1 || len(chart.colors) != 2 {
+			t.Fatalf("expected one series with a color per slice, got %+v", chart)
+		}
+		ascii := chart.ASCII(false)
+		if !strings.Contains(ascii, "north 400 (67%)") || !strings.HasPrefix(ascii, strings.Repeat("█", 27)+strings.Repeat("▓", 13)) {
+			t.Errorf("unexpected pie chart:\n%s", ascii)
+		}
+		if svg := chart.SVG(); strings.Count(svg, "<path") != 2 {
+			t.Errorf("expected a path per slice, got %s", svg)
+		}
+	})
+
+	t.Run("Formatters", func(t *testing.T) {
+		output, err := NewHTMLFormatter().Format(data)
+		if err != nil {
+			t.Fatalf("html failed: %v", err)
+		}
+		if !strings.Contains(output, `<svg class="table-chart"`) {
+			t.Errorf("expected an inline SVG chart in HTML output")
+		}
+
+		pretty := NewPrettyFormatter()
+		pretty.NoColor = true
+		output, err = pretty.FormatPrettyData(data)
+		if err != nil {
+			t.Fatalf("pretty failed: %v", err)
+		}
+		if !strings.Contains(output, "█ Revenue  ▓ Cost") {
+			t.Errorf("expected an ASCII chart after the table, got:\n%s", output)
+		}
+	})
+}
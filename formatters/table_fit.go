@@ -0,0 +1,156 @@
+package formatters
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/term"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// minColumnWidth is the narrowest a column is shrunk to when fitting a table
+const minColumnWidth = 6
+
+// ellipsis ends truncated cells
+const ellipsis = "…"
+
+// tableWidth returns the width tables are fitted to, or 0 when they are not fitted
+func (p *PrettyFormatter) tableWidth() int {
+	if p.Width != 0 {
+		return max(p.Width, 0)
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return 0
+	}
+	return api.GetTerminalWidth()
+}
+
+// displayWidth returns the widest line of a cell in terminal cells, ignoring ANSI
+// sequences and counting wide runes, emoji and combining characters correctly
+func displayWidth(cell string) int {
+	width := 0
+	for _, line := range strings.Split(cell, "\n") {
+		width = max(width, ansi.StringWidth(line))
+	}
+	return width
+}
+
+// fitColumns returns the widths of columns with the natural widths, capped by the
+// truncate option of their field, with the widest columns shrunk first until the
+// table and the overhead of its borders fit within width
+func fitColumns(widths []int, columns []api.PrettyField, width, overhead int) []int {
+	fitted := make([]int, len(widths))
+	total := overhead
+	for i, w := range widths {
+		if i < len(columns) && columns[i].Truncate > 0 {
+			w = min(w, columns[i].Truncate)
+		}
+		fitted[i] = w
+		total += w
+	}
+	if width <= 0 {
+		return fitted
+	}
+
+	for excess := total - width; excess > 0; excess-- {
+		widest := -1
+		for i, w := range fitted {
+			if w > minColumnWidth && (widest < 0 || w > fitted[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		fitted[widest]--
+	}
+	return fitted
+}
+
+// cellLines splits a cell into lines of at most width cells, wrapping them when the
+// column wraps and truncating them with an ellipsis otherwise. Colours and hyperlinks
+// open on one line are closed at its end and reopened on the next.
+func cellLines(cell string, width int, wrap bool) []string {
+	var lines []string
+	for _, line := range strings.Split(cell, "\n") {
+		if ansi.StringWidth(line) <= width {
+			lines = append(lines, line)
+		} else if wrap {
+			lines = append(lines, strings.Split(ansi.Wrap(line, width, ""), "\n")...)
+		} else {
+			lines = append(lines, ansi.Truncate(line, width, ellipsis))
+		}
+	}
+	if len(lines) > 1 {
+		lines = carrySequences(lines)
+	}
+	return lines
+}
+
+// carrySequences closes the SGR styles and OSC 8 hyperlink still open at the end of each
+// line, and reopens them at the start of the next, so each line renders on its own
+func carrySequences(lines []string) []string {
+	var styles []string
+	var link string
+	for i, line := range lines {
+		prefix := strings.Join(styles, "") + link
+
+		for rest := line; rest != ""; {
+			start := strings.IndexByte(rest, '\x1b')
+			if start < 0 || start+1 >= len(rest) {
+				break
+			}
+			rest = rest[start:]
+			switch rest[1] {
+			case '[':
+				end := strings.IndexFunc(rest[2:], func(r rune) bool { return r >= '@' && r <= '~' })
+				if end < 0 {
+					rest = ""
+					continue
+				}
+				sequence := rest[:end+3]
+				if sequence[len(sequence)-1] == 'm' {
+					if params := sequence[2 : len(sequence)-1]; params == "" || params == "0" {
+						styles = nil
+					} else {
+						styles = append(styles, sequence)
+					}
+				}
+				rest = rest[len(sequence):]
+			case ']':
+				end := strings.Index(rest, "\x1b\\")
+				terminator := 2
+				if bell := strings.IndexByte(rest, '\a'); bell >= 0 && (end < 0 || bell < end) {
+					end, terminator = bell, 1
+				}
+				if end < 0 {
+					rest = ""
+					continue
+				}
+				sequence := rest[:end+terminator]
+				if strings.HasPrefix(sequence, "\x1b]8;") {
+					if strings.HasPrefix(sequence, "\x1b]8;;\x1b\\") || strings.HasPrefix(sequence, "\x1b]8;;\a") {
+						link = ""
+					} else {
+						link = sequence
+					}
+				}
+				rest = rest[len(sequence):]
+			default:
+				rest = rest[2:]
+			}
+		}
+
+		suffix := ""
+		if link != "" {
+			suffix += "\x1b]8;;\x1b\\"
+		}
+		if len(styles) > 0 {
+			suffix += "\x1b[0m"
+		}
+		lines[i] = prefix + line + suffix
+	}
+	return lines
+}
@@ -0,0 +1,121 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	for cell, expected := range map[string]int{
+		"plain":                5,
+		"\x1b[31mred\x1b[0m":   3,
+		"日本語":                  6,
+		"🚀 ok":                 5,
+		"cafe\u0301":           4,
+		"short\na longer line": 13,
+	} {
+		if got := displayWidth(cell); got != expected {
+			t.Errorf("displayWidth(%q): expected %d, got %d", cell, expected, got)
+		}
+	}
+}
+
+func TestFitColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		widths   []int
+		columns  []api.PrettyField
+		width    int
+		expected []int
+	}{
+		{"fits", []int{4, 10}, nil, 80, []int{4, 10}},
+		{"not fitted", []int{40, 60}, nil, 0, []int{40, 60}},
+		{"widest first", []int{4, 30, 20}, nil, 40, []int{4, 13, 13}},
+		{"minimum width", []int{20, 20}, nil, 10, []int{6, 6}},
+		{"truncate option", []int{40, 8}, []api.PrettyField{{Truncate: 12}}, 80, []int{12, 8}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fitColumns(test.widths, test.columns, test.width, 1+3*len(test.widths))
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Fatalf("expected %v, got %v", test.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCellLines(t *testing.T) {
+	t.Run("truncate", func(t *testing.T) {
+		lines := cellLines("日本語のテキスト", 7, false)
+		if len(lines) != 1 || lines[0] != "日本語…" {
+			t.Errorf("expected a truncated line, got %q", lines)
+		}
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		lines := cellLines("the quick brown fox", 10, true)
+		if strings.Join(lines, "|") != "the quick|brown fox" {
+			t.Errorf("expected wrapped lines, got %q", lines)
+		}
+	})
+
+	t.Run("colours carry across lines", func(t *testing.T) {
+		lines := cellLines("\x1b[31mthe quick brown\x1b[0m fox", 10, true)
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %q", lines)
+		}
+		if !strings.HasSuffix(lines[0], "\x1b[0m") {
+			t.Errorf("expected the first line to reset its colour, got %q", lines[0])
+		}
+		if !strings.HasPrefix(lines[1], "\x1b[31m") {
+			t.Errorf("expected the second line to reopen the colour, got %q", lines[1])
+		}
+		for _, line := range lines {
+			if width := ansi.StringWidth(line); width > 10 {
+				t.Errorf("expected at most 10 cells, got %d in %q", width, line)
+			}
+		}
+	})
+
+	t.Run("hyperlinks carry across lines", func(t *testing.T) {
+		link := "\x1b]8;;https://example.com\x1b\\"
+		lines := cellLines(link+"aaaa bbbb\x1b]8;;\x1b\\", 4, true)
+		if len(lines) != 2 || !strings.HasPrefix(lines[1], link) || !strings.HasSuffix(lines[0], "\x1b]8;;\x1b\\") {
+			t.Errorf("expected the link on both lines, got %q", lines)
+		}
+	})
+}
+
+func TestFormatTableRowsFits(t *testing.T) {
+	p := &PrettyFormatter{NoColor: true, Width: 30}
+	rows := [][]string{
+		{"name", "description"},
+		{"日本", "a description far too long for the table"},
+	}
+
+	truncated := p.formatTableRows(rows, nil, "")
+	wrapped := p.formatTableRows(rows, []api.PrettyField{{Name: "name"}, {Name: "description", Wrap: true}}, "")
+
+	for name, table := range map[string]string{"truncated": truncated, "wrapped": wrapped} {
+		lines := strings.Split(table, "\n")
+		for _, line := range lines {
+			if width := ansi.StringWidth(line); width != 30 {
+				t.Errorf("%s: expected every line to be 30 cells, got %d:\n%s", name, width, table)
+				break
+			}
+		}
+	}
+	if !strings.Contains(truncated, "…") || strings.Count(truncated, "\n") != 4 {
+		t.Errorf("expected one line per row with an ellipsis:\n%s", truncated)
+	}
+	if strings.Contains(wrapped, "…") || !strings.Contains(wrapped, "│ table") {
+		t.Errorf("expected the description to wrap:\n%s", wrapped)
+	}
+}
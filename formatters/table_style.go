@@ -0,0 +1,135 @@
+package formatters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// tableBorder draws the borders of a terminal table
+type tableBorder struct {
+	lipgloss.Border
+	// outer draws the top and bottom borders
+	outer bool
+	// groups draws a separator before group headers, subtotals and totals
+	groups bool
+	// gap is the number of spaces between the columns of tables without borders
+	gap int
+	// uppercase headers, as kubectl does
+	uppercase bool
+}
+
+var asciiBorder = lipgloss.Border{
+	Top: "-", Bottom: "-", Left: "|", Right: "|",
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	MiddleLeft: "+", MiddleRight: "+", Middle: "+", MiddleTop: "+", MiddleBottom: "+",
+}
+
+var markdownBorder = lipgloss.Border{
+	Top: "-", Left: "|", Right: "|",
+	MiddleLeft: "|", MiddleRight: "|", Middle: "|",
+}
+
+// tableBorders are the table styles by name
+var tableBorders = map[string]tableBorder{
+	api.TableStyleSingle:   {Border: lipgloss.NormalBorder(), outer: true, groups: true},
+	api.TableStyleRounded:  {Border: lipgloss.RoundedBorder(), outer: true, groups: true},
+	api.TableStyleDouble:   {Border: lipgloss.DoubleBorder(), outer: true, groups: true},
+	api.TableStyleHeavy:    {Border: lipgloss.ThickBorder(), outer: true, groups: true},
+	api.TableStyleASCII:    {Border: asciiBorder, outer: true, groups: true},
+	api.TableStyleMarkdown: {Border: markdownBorder},
+	api.TableStyleMinimal:  {gap: 2},
+	api.TableStyleCompact:  {gap: 3, uppercase: true},
+	"none":                 {gap: 2},
+	"no-borders":           {gap: 2},
+}
+
+// ValidateTableStyle returns an error for an unknown table style
+func ValidateTableStyle(style string) error {
+	if _, ok := tableBorders[style]; ok || style == "" {
+		return nil
+	}
+	names := make([]string, 0, len(tableBorders))
+	for name := range tableBorders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown table style %q, expected one of %s", style, strings.Join(names, ", "))
+}
+
+// tableBorder returns the border of a table, from the formatter's TableStyle, then the
+// table_style of the table, then the theme, defaulting to single lines
+func (p *PrettyFormatter) tableBorder(style string) tableBorder {
+	for _, name := range []string{p.TableStyle, style, p.Theme.TableStyle} {
+		if border, ok := tableBorders[name]; ok {
+			return border
+		}
+	}
+	return tableBorders[api.TableStyleSingle]
+}
+
+// bordered reports whether the table has vertical borders
+func (b tableBorder) bordered() bool {
+	return b.Left != ""
+}
+
+// overhead returns the cells taken by the borders and padding of count columns
+func (b tableBorder) overhead(count int) int {
+	if !b.bordered() {
+		return b.gap * (count - 1)
+	}
+	return ansi.StringWidth(b.Left)*count + ansi.StringWidth(b.Right) + 2*count
+}
+
+// header returns the header row, uppercased outside its escape sequences when the
+// style asks for it
+func (b tableBorder) header(row []string) []string {
+	if !b.uppercase {
+		return row
+	}
+	header := make([]string, len(row))
+	for i, cell := range row {
+		header[i] = upperText(cell)
+	}
+	return header
+}
+
+// upperText uppercases text, leaving its ANSI escape sequences intact
+func upperText(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\x1b' || i+1 >= len(text) {
+			end := strings.IndexByte(text[i:], '\x1b')
+			if end <= 0 {
+				end = len(text) - i
+			}
+			b.WriteString(strings.ToUpper(text[i : i+end]))
+			i += end - 1
+			continue
+		}
+
+		end := i + 2
+		switch text[i+1] {
+		case '[':
+			for end < len(text) && (text[end] < '@' || text[end] > '~') {
+				end++
+			}
+		case ']':
+			for end < len(text) && text[end] != '\a' && !(text[end] == '\x1b' && end+1 < len(text) && text[end+1] == '\\') {
+				end++
+			}
+			if end < len(text) && text[end] == '\x1b' {
+				end++
+			}
+		}
+		end = min(end, len(text)-1)
+		b.WriteString(text[i : end+1])
+		i = end
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package formatters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestTableStyles(t *testing.T) {
+	rows := [][]string{
+		{"name", "status"},
+		{"web", "ok"},
+		{"Total", "1"},
+	}
+	kinds := []string{"", "", api.RowKindTotal}
+
+	tests := map[string]string{
+		api.TableStyleSingle: `
+┌───────┬────────┐
+│ name  │ status │
+├───────┼────────┤
+│ web   │ ok     │
+├───────┼────────┤
+│ Total │ 1      │
+└───────┴────────┘`,
+		api.TableStyleRounded: `
+╭───────┬────────╮
+│ name  │ status │
+├───────┼────────┤
+│ web   │ ok     │
+├───────┼────────┤
+│ Total │ 1      │
+╰───────┴────────╯`,
+		api.TableStyleASCII: `
++-------+--------+
+| name  | status |
++-------+--------+
+| web   | ok     |
++-------+--------+
+| Total | 1      |
++-------+--------+`,
+		api.TableStyleMarkdown: `
+| name  | status |
+|-------|--------|
+| web   | ok     |
+| Total | 1      |`,
+		api.TableStyleMinimal: `
+name   status
+web    ok
+Total  1`,
+		api.TableStyleCompact: `
+NAME    STATUS
+web     ok
+Total   1`,
+	}
+
+	for style, expected := range tests {
+		t.Run(style, func(t *testing.T) {
+			p := &PrettyFormatter{NoColor: true, Width: -1}
+			if got := p.formatTableRows(rows, nil, style, kinds...); got != strings.TrimPrefix(expected, "\n") {
+				t.Errorf("expected:\n%s\ngot:\n%s", strings.TrimPrefix(expected, "\n"), got)
+			}
+		})
+	}
+}
+
+func TestTableStylePrecedence(t *testing.T) {
+	p := &PrettyFormatter{NoColor: true, Width: -1}
+	p.Theme.TableStyle = api.TableStyleASCII
+	rows := [][]string{{"a"}, {"b"}}
+
+	if got := p.formatTableRows(rows, nil, ""); !strings.HasPrefix(got, "+---+") {
+		t.Errorf("expected the theme's ascii style, got:\n%s", got)
+	}
+	if got := p.formatTableRows(rows, nil, api.TableStyleDouble); !strings.HasPrefix(got, "╔═══╗") {
+		t.Errorf("expected the table's double style over the theme, got:\n%s", got)
+	}
+	p.TableStyle = api.TableStyleMinimal
+	if got := p.formatTableRows(rows, nil, api.TableStyleDouble); got != "a\nb" {
+		t.Errorf("expected the formatter's minimal style over the table, got:\n%s", got)
+	}
+}
+
+func TestUpperText(t *testing.T) {
+	header := "\x1b[1;38;2;0;0;255mname\x1b[0m \x1b]8;;https://a/b\x1b\\link\x1b]8;;\x1b\\"
+	if got, expected := upperText(header), "\x1b[1;38;2;0;0;255mNAME\x1b[0m \x1b]8;;https://a/b\x1b\\LINK\x1b]8;;\x1b\\"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if err := ValidateTableStyle("fancy"); err == nil {
+		t.Errorf("expected an error for an unknown table style")
+	}
+}
@@ -0,0 +1,231 @@
+package formatters
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/flanksource/clicky/api"
+)
+
+// TemplateFormatter renders PrettyData through a user supplied Go template.
+// Templates ending in .html, .htm or .gohtml are executed with html/template,
+// everything else with text/template. The template dot is the *api.PrettyData.
+type TemplateFormatter struct {
+	// Template is the path of the template file
+	Template string
+	// HTML executes the template with html/template, escaping values and rendering styles as HTML
+	HTML bool
+	// NoColor renders styled values as plain text instead of ANSI in text templates
+	NoColor bool
+}
+
+// NewTemplateFormatter creates a template formatter for the template at path,
+// selecting html/template from the file extension
+func NewTemplateFormatter(path string) *TemplateFormatter {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm", ".gohtml":
+		return &TemplateFormatter{Template: path, HTML: true}
+	}
+	return &TemplateFormatter{Template: path}
+}
+
+// Format renders data through the template
+func (f *TemplateFormatter) Format(data interface{}) (string, error) {
+	prettyData, err := ToPrettyData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+	return f.FormatPrettyData(prettyData)
+}
+
+// FormatPrettyData renders PrettyData through the template
+func (f *TemplateFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Write renders PrettyData through the template to w
+func (f *TemplateFormatter) Write(w io.Writer, data *api.PrettyData) error {
+	if f.Template == "" {
+		return fmt.Errorf("no template specified")
+	}
+	content, err := os.ReadFile(f.Template)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+	if data == nil {
+		data = &api.PrettyData{Schema: &api.PrettyObject{}}
+	}
+	if data, err = ApplyStyleRules(data); err != nil {
+		return err
+	}
+
+	name := filepath.Base(f.Template)
+	if f.HTML {
+		tmpl, err := htmltemplate.New(name).Funcs(f.funcs()).Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		if err := tmpl.Execute(w, data); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", name, err)
+		}
+		return nil
+	}
+
+	tmpl, err := texttemplate.New(name).Funcs(f.funcs()).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return nil
+}
+
+// funcs returns the helper functions available to templates.
+//
+// Values can be FieldValues, api.Text, api.Pretty implementations or any other value:
+//
+//	formatted, ansi, markdown, htmlText  render a value with the matching FieldValue renderer
+//	render                               renders a value for the template type (ANSI or HTML)
+//	style "classes" value                applies Tailwind classes, then renders like render
+//	color value                          returns the color_options match of a FieldValue
+//	value . "name", table . "name"       look up a value or table rows by JSON name, Go name or label
+//	columns . "name"                     returns the column fields of a table
+//	cell row column                      returns the value of a column in a table row
+//	label field                          returns the display label of a field
+func (f *TemplateFormatter) funcs() map[string]interface{} {
+	return map[string]interface{}{
+		"formatted": func(v interface{}) string { return templateText(v).String() },
+		"ansi":      func(v interface{}) string { return templateText(v).ANSI() },
+		"markdown":  func(v interface{}) string { return templateText(v).Markdown() },
+		"htmlText":  func(v interface{}) htmltemplate.HTML { return htmltemplate.HTML(escapeText(templateText(v)).HTML()) },
+		"render":    func(v interface{}) interface{} { return f.render(templateText(v)) },
+		"style": func(classes string, v interface{}) interface{} {
+			return f.render(templateText(v).Styles(classes))
+		},
+		"color": func(v api.FieldValue) string { return parseFieldValue(v, v.Field).Color() },
+		"value": func(data *api.PrettyData, name string) api.FieldValue {
+			value, _ := data.GetValue(templateField(data, name).Name)
+			return value
+		},
+		"table": func(data *api.PrettyData, name string) []api.PrettyDataRow {
+			rows, _ := data.GetTable(templateField(data, name).Name)
+			return rows
+		},
+		"columns": func(data *api.PrettyData, name string) []api.PrettyField {
+			field := templateField(data, name)
+			rows, _ := data.GetTable(field.Name)
+			return api.TableColumns(field, rows)
+		},
+		"cell": func(row api.PrettyDataRow, column api.PrettyField) api.FieldValue {
+			return parseFieldValue(row[column.Name], column)
+		},
+		"label": fieldLabel,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"join":  strings.Join,
+		"repeat": func(count int, s string) string {
+			if count < 0 {
+				return ""
+			}
+			return strings.Repeat(s, count)
+		},
+	}
+}
+
+// templateField finds a field of data by its JSON name, Go name or label,
+// falling back to a field with the given name
+func templateField(data *api.PrettyData, name string) api.PrettyField {
+	if data.Schema != nil {
+		if field, ok := findField(data.Schema.Fields, name); ok {
+			return field
+		}
+	}
+	return api.PrettyField{Name: name}
+}
+
+// render returns styled text as escaped HTML for html templates, or as ANSI
+// (plain text when NoColor is set) for text templates
+func (f *TemplateFormatter) render(text api.Text) interface{} {
+	if f.HTML {
+		return htmltemplate.HTML(escapeText(text).HTML())
+	}
+	if f.NoColor {
+		return text.String()
+	}
+	return text.ANSI()
+}
+
+// templateText converts a template argument into styled text
+func templateText(v interface{}) api.Text {
+	switch value := v.(type) {
+	case nil:
+		return api.Text{}
+	case api.FieldValue:
+		if value.Text == nil && value.Value == nil && len(value.NestedFields) == 0 {
+			return api.Text{}
+		}
+		return parseFieldValue(value, value.Field).Pretty()
+	case *api.FieldValue:
+		if value == nil {
+			return api.Text{}
+		}
+		return templateText(*value)
+	case api.Text:
+		return value
+	case api.Pretty:
+		return value.Pretty()
+	case string:
+		return api.Text{Content: value}
+	}
+	return api.Text{Content: fmt.Sprintf("%v", v)}
+}
+
+// escapeText HTML escapes the content of text and its children, since
+// Text.HTML only wraps content in styled spans
+func escapeText(text api.Text) api.Text {
+	text.Content = htmltemplate.HTMLEscapeString(text.Content)
+	if len(text.Children) > 0 {
+		children := make([]api.Text, len(text.Children))
+		for i, child := range text.Children {
+			children[i] = escapeText(child)
+		}
+		text.Children = children
+	}
+	return text
+}
+
+// parseFieldValue applies the field format to a value that has not been parsed yet,
+// values built from structs carry the raw value and field without any Text
+func parseFieldValue(value api.FieldValue, field api.PrettyField) api.FieldValue {
+	if value.Text != nil {
+		return value
+	}
+	if pretty, ok := value.Value.(api.Pretty); ok {
+		text := pretty.Pretty()
+		value.Text = &text
+		return value
+	}
+	if value.Value == nil {
+		return value
+	}
+	if field.Format == "" && field.Type == "" {
+		field = value.Field
+	}
+	parsed, err := field.Parse(value.Value)
+	if err != nil {
+		return value
+	}
+	return parsed
+}
@@ -0,0 +1,120 @@
+package formatters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+type templateItem struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price" pretty:"currency"`
+}
+
+type templateOrder struct {
+	ID     string         `json:"id"`
+	Status string         `json:"status"`
+	Total  float64        `json:"total" pretty:"currency"`
+	Items  []templateItem `json:"items" pretty:"table"`
+}
+
+func writeTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	order := templateOrder{
+		ID:     "ORD-1",
+		Status: "<shipped>",
+		Total:  12.5,
+		Items:  []templateItem{{Name: "a", Price: 1.5}, {Name: "b", Price: 2}},
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		path := writeTemplate(t, "order.tmpl",
+			`{{ formatted (value . "id") }} {{ formatted .Values.total }} {{ formatted (value . "ID") }}
+{{ range $row := table . "items" }}{{ range columns $ "items" }}{{ label . }}={{ formatted (cell $row .) }} {{ end }}
+{{ end }}`)
+
+		output, err := NewFormatManager().FormatWithOptions(FormatOptions{Template: path, NoColor: true}, order)
+		if err != nil {
+			t.Fatalf("template format failed: %v", err)
+		}
+		if !strings.HasPrefix(output, "ORD-1 $12.50 ORD-1") {
+			t.Errorf("expected currency formatted total and values found by JSON and Go name, got %q", output)
+		}
+		if !strings.Contains(output, "price=$1.50") {
+			t.Errorf("expected table cells formatted with the column format, got %q", output)
+		}
+	})
+
+	t.Run("StyleANSI", func(t *testing.T) {
+		path := writeTemplate(t, "status.tmpl", `{{ style "text-red-600 font-bold" (value . "status") }}`)
+
+		output, err := NewTemplateFormatter(path).Format(order)
+		if err != nil {
+			t.Fatalf("template format failed: %v", err)
+		}
+		if !strings.Contains(output, "\x1b[") || !strings.Contains(output, "<shipped>") {
+			t.Errorf("expected ANSI styled status, got %q", output)
+		}
+
+		noColor := NewTemplateFormatter(path)
+		noColor.NoColor = true
+		output, err = noColor.Format(order)
+		if err != nil {
+			t.Fatalf("template format failed: %v", err)
+		}
+		if output != "<shipped>" {
+			t.Errorf("expected plain status with NoColor, got %q", output)
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		path := writeTemplate(t, "order.html", `<p>{{ formatted (value . "status") }}</p>{{ style "font-bold" (value . "status") }}`)
+
+		formatter := NewTemplateFormatter(path)
+		if !formatter.HTML {
+			t.Fatalf("expected .html templates to use html/template")
+		}
+		output, err := formatter.Format(order)
+		if err != nil {
+			t.Fatalf("template format failed: %v", err)
+		}
+		if strings.Contains(output, "<shipped>") {
+			t.Errorf("expected values to be escaped, got %q", output)
+		}
+		if !strings.Contains(output, "<strong>&lt;shipped&gt;</strong>") {
+			t.Errorf("expected styled values rendered as HTML, got %q", output)
+		}
+	})
+
+	t.Run("SchemaTemplate", func(t *testing.T) {
+		path := writeTemplate(t, "schema.tmpl", `{{ range .Schema.Fields }}{{ .Name }};{{ end }}`)
+		data := &api.PrettyData{
+			Schema: &api.PrettyObject{Fields: []api.PrettyField{{Name: "id"}}, Template: path},
+		}
+
+		output, err := NewFormatManager().FormatWithSchema(data, FormatOptions{Format: "template"})
+		if err != nil {
+			t.Fatalf("template format failed: %v", err)
+		}
+		if output != "id;" {
+			t.Errorf("expected the schema template to be used, got %q", output)
+		}
+	})
+
+	t.Run("MissingTemplate", func(t *testing.T) {
+		if _, err := NewFormatManager().FormatWithOptions(FormatOptions{Format: "template"}, order); err == nil {
+			t.Errorf("expected an error without a template")
+		}
+	})
+}
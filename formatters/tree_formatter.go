@@ -16,10 +16,14 @@ type TreeFormatter struct {
 	Options *api.TreeOptions
 }
 
-// NewTreeFormatter creates a new tree formatter
+// NewTreeFormatter creates a new tree formatter, drawn with the glyphs of the theme
+// unless options are given
 func NewTreeFormatter(theme api.Theme, noColor bool, options *api.TreeOptions) *TreeFormatter {
 	if options == nil {
 		options = api.DefaultTreeOptions()
+		if theme.Tree != nil {
+			theme.Tree.Apply(options)
+		}
 	}
 	return &TreeFormatter{
 		Theme:   theme,
@@ -0,0 +1,750 @@
+package formatters
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flanksource/clicky/api"
+	"github.com/flanksource/clicky/api/tailwind"
+)
+
+// XLSXFormatter handles Excel (Office Open XML spreadsheet) formatting.
+// It writes one sheet per table in PrettyData.Tables plus a summary sheet
+// for the regular field values, using typed cells and frozen header rows.
+type XLSXFormatter struct {
+	// SummarySheet is the name of the sheet holding non-table values
+	SummarySheet string
+	// MaxColumnWidth caps the auto-fitted column width (in characters)
+	MaxColumnWidth int
+}
+
+// NewXLSXFormatter creates a new XLSX formatter
+func NewXLSXFormatter() *XLSXFormatter {
+	return &XLSXFormatter{
+		SummarySheet:   "Summary",
+		MaxColumnWidth: 60,
+	}
+}
+
+// Format formats data as an XLSX workbook, returned as a binary string
+func (f *XLSXFormatter) Format(data interface{}) (string, error) {
+	prettyData, err := ToPrettyData(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert to PrettyData: %w", err)
+	}
+	return f.FormatPrettyData(prettyData)
+}
+
+// FormatPrettyData formats PrettyData as an XLSX workbook, returned as a binary string
+func (f *XLSXFormatter) FormatPrettyData(data *api.PrettyData) (string, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Write writes PrettyData as an XLSX workbook to w
+func (f *XLSXFormatter) Write(w io.Writer, data *api.PrettyData) error {
+	if data == nil || data.Schema == nil {
+		data = &api.PrettyData{Schema: &api.PrettyObject{}}
+	}
+	data, err := ApplyStyleRules(data)
+	if err != nil {
+		return err
+	}
+
+	styles := newXLSXStyles()
+	var sheets []xlsxSheet
+	names := map[string]bool{}
+
+	if summary := f.summarySheet(data, styles); summary != nil {
+		summary.name = uniqueSheetName(summary.name, names)
+		sheets = append(sheets, *summary)
+	}
+
+	for _, field := range data.Schema.Fields {
+		if field.Format != api.FormatTable {
+			continue
+		}
+		rows, ok := data.Tables[field.Name]
+		if !ok {
+			continue
+		}
+		sheet := f.tableSheet(field, rows, styles)
+		sheet.name = uniqueSheetName(sheet.name, names)
+		sheets = append(sheets, sheet)
+	}
+
+	// Excel refuses to open a workbook without any sheets
+	if len(sheets) == 0 {
+		sheets = append(sheets, xlsxSheet{name: f.sheetName()})
+	}
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", styles.xml()},
+	}
+	for i, sheet := range sheets {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml(f.MaxColumnWidth)})
+	}
+
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", file.name, err)
+		}
+		if _, err := io.WriteString(fw, file.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+func (f *XLSXFormatter) sheetName() string {
+	if f.SummarySheet == "" {
+		return "Summary"
+	}
+	return f.SummarySheet
+}
+
+// summarySheet builds a two column Field/Value sheet from the non-table values
+func (f *XLSXFormatter) summarySheet(data *api.PrettyData, styles *xlsxStyles) *xlsxSheet {
+	header := styles.add(xlsxStyle{bold: true})
+	sheet := &xlsxSheet{
+		name: f.sheetName(),
+		rows: [][]xlsxCell{{
+			{kind: xlsxString, text: "Field", style: header},
+			{kind: xlsxString, text: "Value", style: header},
+		}},
+	}
+
+	for _, field := range data.Schema.Fields {
+		if field.Format == api.FormatTable || field.Format == api.FormatTree || field.Format == api.FormatHide {
+			continue
+		}
+		value, ok := data.Values[field.Name]
+		if !ok {
+			continue
+		}
+		sheet.rows = append(sheet.rows, []xlsxCell{
+			{kind: xlsxString, text: fieldLabel(field), style: header},
+			f.cell(value, field, styles, xlsxStyle{}),
+		})
+	}
+
+	if len(sheet.rows) == 1 {
+		return nil
+	}
+	return sheet
+}
+
+// tableSheet builds a sheet from table rows, using the field definitions for column order
+func (f *XLSXFormatter) tableSheet(field api.PrettyField, rows []api.PrettyDataRow, styles *xlsxStyles) xlsxSheet {
+	name := field.TableOptions.Title
+	if name == "" {
+		name = fieldLabel(field)
+	}
+
+	columns := api.TableColumns(field, rows)
+
+	headerStyle := xlsxStyleFromTailwind(field.TableOptions.HeaderStyle)
+	headerStyle.bold = true
+	header := styles.add(headerStyle)
+	rowStyle := xlsxStyleFromTailwind(field.TableOptions.RowStyle)
+
+	headerRow := make([]xlsxCell, len(columns))
+	for i, column := range columns {
+		label := column.Label
+		if label == "" {
+			label = column.Name
+		}
+		headerRow[i] = xlsxCell{kind: xlsxString, text: label, style: header}
+	}
+
+	sheet := xlsxSheet{name: name, rows: [][]xlsxCell{headerRow}, frozen: true}
+	for _, row := range rows {
+		style := rowStyle
+		if row.Kind() != "" {
+			// Group headers and aggregate rows
+			style.bold = true
+		}
+		cells := make([]xlsxCell, len(columns))
+		for i, column := range columns {
+			if value, ok := row[column.Name]; ok {
+				cells[i] = f.cell(value, column, styles, style)
+			} else {
+				cells[i] = xlsxCell{kind: xlsxBlank, style: styles.add(style)}
+			}
+		}
+		sheet.rows = append(sheet.rows, cells)
+	}
+	return sheet
+}
+
+// cell converts a FieldValue into a typed spreadsheet cell
+func (f *XLSXFormatter) cell(value api.FieldValue, field api.PrettyField, styles *xlsxStyles, base xlsxStyle) xlsxCell {
+	if field.Format == "" {
+		field.Format = value.Field.Format
+	}
+	if field.FormatOptions == nil {
+		field.FormatOptions = value.Field.FormatOptions
+	}
+
+	style := base
+	// Classes of matching style rules override the field style
+	for _, classes := range []string{field.Style, value.Style} {
+		if classes == "" {
+			continue
+		}
+		override := xlsxStyleFromTailwind(classes)
+		if override.fill != "" {
+			style.fill = override.fill
+		}
+		if override.color != "" {
+			style.color = override.color
+		}
+		style.bold = style.bold || override.bold
+		style.italic = style.italic || override.italic
+	}
+
+	raw := value.Value
+	if rv := reflect.ValueOf(raw); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			raw = nil
+		} else {
+			raw = rv.Elem().Interface()
+		}
+		value.Value = raw
+	}
+	if raw == nil {
+		return xlsxCell{kind: xlsxBlank, style: styles.add(style)}
+	}
+
+	if pretty, ok := raw.(api.Pretty); ok {
+		return xlsxCell{kind: xlsxString, text: pretty.Pretty().String(), style: styles.add(style)}
+	}
+
+	if t, ok := xlsxTime(value, field); ok {
+		style.numFmt = xlsxDateTimeFormat
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+			style.numFmt = xlsxDateFormat
+		}
+		return xlsxCell{kind: xlsxNumber, number: xlsxSerialDate(t), style: styles.add(style)}
+	}
+
+	if b, ok := raw.(bool); ok {
+		return xlsxCell{kind: xlsxBool, boolean: b, style: styles.add(style)}
+	}
+
+	if n, ok := xlsxNumeric(raw); ok {
+		switch field.Format {
+		case api.FormatCurrency:
+			// Excel applies the reader's separators, the symbol and its placement follow the locale
+			locale := field.Locale()
+			symbol, decimals := locale.CurrencySymbol(field.FormatOptions["currency"])
+			if sym, ok := field.FormatOptions["symbol"]; ok {
+				symbol = sym
+			}
+			number := "#,##0"
+			if decimals > 0 {
+				number += "." + strings.Repeat("0", decimals)
+			}
+			symbol = strings.ReplaceAll(symbol, `"`, "")
+			switch {
+			case locale.SymbolAfter:
+				style.numFmt = fmt.Sprintf(`%s "%s"`, number, symbol)
+			case locale.SymbolSpace:
+				style.numFmt = fmt.Sprintf(`"%s "%s`, symbol, number)
+			default:
+				style.numFmt = fmt.Sprintf(`"%s"%s`, symbol, number)
+			}
+		case api.FormatPercent:
+			digits := 1
+			if d, err := strconv.Atoi(field.FormatOptions["digits"]); err == nil {
+				digits = d
+			}
+			number := "0"
+			if digits > 0 {
+				number += "." + strings.Repeat("0", digits)
+			}
+			// Excel's % multiplies by 100, so only ratios use it
+			style.numFmt = number + `"%"`
+			if field.FormatOptions["ratio"] == "true" {
+				style.numFmt = number + "%"
+			}
+		case api.FormatFloat:
+			digits := 2
+			if d, err := strconv.Atoi(field.FormatOptions["digits"]); err == nil {
+				digits = d
+			}
+			style.numFmt = "0"
+			if digits > 0 {
+				style.numFmt = "0." + strings.Repeat("0", digits)
+			}
+		}
+		return xlsxCell{kind: xlsxNumber, number: n, style: styles.add(style)}
+	}
+
+	text := value.Plain()
+	if value.Text == nil {
+		if parsed, err := field.Parse(raw); err == nil {
+			text = parsed.Plain()
+		}
+	}
+	return xlsxCell{kind: xlsxString, text: text, style: styles.add(style)}
+}
+
+// xlsxTime returns the time for date fields and time.Time values
+func xlsxTime(value api.FieldValue, field api.PrettyField) (time.Time, bool) {
+	if t, ok := value.Value.(time.Time); ok {
+		return t, !t.IsZero()
+	}
+	if value.TimeValue != nil {
+		return *value.TimeValue, true
+	}
+	if field.Format != api.FormatDate && field.Type != api.FieldTypeDate {
+		return time.Time{}, false
+	}
+	value.Field = field
+	if t := value.Time(); t != nil {
+		return *t, true
+	}
+	return time.Time{}, false
+}
+
+// xlsxNumeric extracts a float64 from any Go numeric kind
+func xlsxNumeric(v interface{}) (float64, bool) {
+	if _, ok := v.(time.Duration); ok {
+		return 0, false
+	}
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return 0, false
+}
+
+// xlsxSerialDate converts a time into an Excel serial date (days since 1899-12-30)
+func xlsxSerialDate(t time.Time) float64 {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}
+
+// uniqueSheetName sanitizes a sheet name and makes it unique within the workbook
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '[', ']', ':', '*', '?', '/', '\\':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "Sheet"
+	}
+	if utf8.RuneCountInString(name) > 31 {
+		name = string([]rune(name)[:31])
+	}
+
+	candidate := name
+	for i := 2; used[strings.ToLower(candidate)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		base := []rune(name)
+		if len(base)+len(suffix) > 31 {
+			base = base[:31-len(suffix)]
+		}
+		candidate = string(base) + suffix
+	}
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+type xlsxCellKind int
+
+const (
+	xlsxBlank xlsxCellKind = iota
+	xlsxString
+	xlsxNumber
+	xlsxBool
+)
+
+const (
+	xlsxDateFormat     = "yyyy-mm-dd"
+	xlsxDateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+)
+
+type xlsxCell struct {
+	kind    xlsxCellKind
+	text    string
+	number  float64
+	boolean bool
+	style   int
+}
+
+// width returns the approximate display width of the cell in characters
+func (c xlsxCell) width() int {
+	switch c.kind {
+	case xlsxString:
+		longest := 0
+		for _, line := range strings.Split(c.text, "\n") {
+			if n := utf8.RuneCountInString(line); n > longest {
+				longest = n
+			}
+		}
+		return longest
+	case xlsxNumber:
+		return len(strconv.FormatFloat(c.number, 'f', 2, 64)) + 2
+	case xlsxBool:
+		return 5
+	}
+	return 0
+}
+
+type xlsxSheet struct {
+	name   string
+	rows   [][]xlsxCell
+	frozen bool
+}
+
+func (s xlsxSheet) xml(maxWidth int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	if s.frozen || len(s.rows) > 1 {
+		b.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	}
+	b.WriteString(`</sheetView></sheetViews>`)
+
+	// Column widths are fitted to the longest value in each column
+	var widths []int
+	for _, row := range s.rows {
+		for i, cell := range row {
+			for len(widths) <= i {
+				widths = append(widths, 0)
+			}
+			if w := cell.width(); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	if len(widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, w := range widths {
+			w += 2
+			if maxWidth > 0 && w > maxWidth {
+				w = maxWidth
+			}
+			if w < 8 {
+				w = 8
+			}
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, w)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxColumnName(c) + strconv.Itoa(r+1)
+			switch cell.kind {
+			case xlsxString:
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, xlsxEscape(cell.text))
+			case xlsxNumber:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, strconv.FormatFloat(cell.number, 'f', -1, 64))
+			case xlsxBool:
+				v := 0
+				if cell.boolean {
+					v = 1
+				}
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="b"><v>%d</v></c>`, ref, cell.style, v)
+			default:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, ref, cell.style)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// xlsxColumnName converts a zero-based column index into a spreadsheet column name (A, B, ..., AA)
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// xlsxEscape escapes text for XML, dropping control characters that are invalid in XML 1.0
+func xlsxEscape(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// xlsxStyle describes a cell format, deduplicated into the workbook stylesheet
+type xlsxStyle struct {
+	numFmt string
+	bold   bool
+	italic bool
+	color  string // ARGB font color
+	fill   string // ARGB fill color
+}
+
+// xlsxStyleFromTailwind maps Tailwind text/bg colours and font classes to a cell style
+func xlsxStyleFromTailwind(classes string) xlsxStyle {
+	if classes == "" {
+		return xlsxStyle{}
+	}
+	parsed := tailwind.ParseStyle(classes)
+	return xlsxStyle{
+		bold:   parsed.Bold,
+		italic: parsed.Italic,
+		color:  xlsxARGB(parsed.Foreground),
+		fill:   xlsxARGB(parsed.Background),
+	}
+}
+
+// xlsxARGB converts a #RRGGBB colour to the ARGB form used by SpreadsheetML
+func xlsxARGB(hex string) string {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return ""
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return ""
+	}
+	return "FF" + strings.ToUpper(hex)
+}
+
+type xlsxFont struct {
+	bold   bool
+	italic bool
+	color  string
+}
+
+type xlsxStyles struct {
+	numFmts []string
+	fonts   []xlsxFont
+	fills   []string
+	xfs     []xlsxStyle
+	index   map[xlsxStyle]int
+}
+
+func newXLSXStyles() *xlsxStyles {
+	s := &xlsxStyles{
+		fonts: []xlsxFont{{}},
+		// The first two fills are reserved by the spec (none and gray125)
+		fills: []string{"", ""},
+		index: map[xlsxStyle]int{},
+	}
+	s.add(xlsxStyle{})
+	return s
+}
+
+// add registers a style and returns its cellXfs index
+func (s *xlsxStyles) add(style xlsxStyle) int {
+	if i, ok := s.index[style]; ok {
+		return i
+	}
+	s.xfs = append(s.xfs, style)
+	s.index[style] = len(s.xfs) - 1
+	return len(s.xfs) - 1
+}
+
+func (s *xlsxStyles) numFmtID(format string) int {
+	if format == "" {
+		return 0
+	}
+	for i, f := range s.numFmts {
+		if f == format {
+			return 164 + i
+		}
+	}
+	s.numFmts = append(s.numFmts, format)
+	return 164 + len(s.numFmts) - 1
+}
+
+func (s *xlsxStyles) fontID(font xlsxFont) int {
+	for i, f := range s.fonts {
+		if f == font {
+			return i
+		}
+	}
+	s.fonts = append(s.fonts, font)
+	return len(s.fonts) - 1
+}
+
+func (s *xlsxStyles) fillID(fill string) int {
+	if fill == "" {
+		return 0
+	}
+	for i, f := range s.fills {
+		if i > 1 && f == fill {
+			return i
+		}
+	}
+	s.fills = append(s.fills, fill)
+	return len(s.fills) - 1
+}
+
+func (s *xlsxStyles) xml() string {
+	type xf struct{ numFmt, font, fill int }
+	xfs := make([]xf, len(s.xfs))
+	for i, style := range s.xfs {
+		xfs[i] = xf{
+			numFmt: s.numFmtID(style.numFmt),
+			font:   s.fontID(xlsxFont{bold: style.bold, italic: style.italic, color: style.color}),
+			fill:   s.fillID(style.fill),
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(s.numFmts) > 0 {
+		fmt.Fprintf(&b, `<numFmts count="%d">`, len(s.numFmts))
+		for i, f := range s.numFmts {
+			fmt.Fprintf(&b, `<numFmt numFmtId="%d" formatCode="%s"/>`, 164+i, xlsxEscapeAttr(f))
+		}
+		b.WriteString(`</numFmts>`)
+	}
+
+	fmt.Fprintf(&b, `<fonts count="%d">`, len(s.fonts))
+	for _, font := range s.fonts {
+		b.WriteString(`<font>`)
+		if font.bold {
+			b.WriteString(`<b/>`)
+		}
+		if font.italic {
+			b.WriteString(`<i/>`)
+		}
+		b.WriteString(`<sz val="11"/>`)
+		if font.color != "" {
+			fmt.Fprintf(&b, `<color rgb="%s"/>`, font.color)
+		}
+		b.WriteString(`<name val="Calibri"/></font>`)
+	}
+	b.WriteString(`</fonts>`)
+
+	fmt.Fprintf(&b, `<fills count="%d">`, len(s.fills))
+	b.WriteString(`<fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>`)
+	for _, fill := range s.fills[2:] {
+		fmt.Fprintf(&b, `<fill><patternFill patternType="solid"><fgColor rgb="%s"/><bgColor indexed="64"/></patternFill></fill>`, fill)
+	}
+	b.WriteString(`</fills>`)
+
+	b.WriteString(`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>`)
+	b.WriteString(`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+
+	fmt.Fprintf(&b, `<cellXfs count="%d">`, len(xfs))
+	for _, x := range xfs {
+		fmt.Fprintf(&b, `<xf numFmtId="%d" fontId="%d" fillId="%d" borderId="0" xfId="0"`, x.numFmt, x.font, x.fill)
+		if x.numFmt != 0 {
+			b.WriteString(` applyNumberFormat="1"`)
+		}
+		if x.font != 0 {
+			b.WriteString(` applyFont="1"`)
+		}
+		if x.fill != 0 {
+			b.WriteString(` applyFill="1"`)
+		}
+		b.WriteString(`/>`)
+	}
+	b.WriteString(`</cellXfs>`)
+
+	b.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>`)
+	b.WriteString(`</styleSheet>`)
+	return b.String()
+}
+
+func xlsxEscapeAttr(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func xlsxContentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func xlsxWorkbook(sheets []xlsxSheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscapeAttr(sheet.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func xlsxWorkbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
@@ -0,0 +1,180 @@
+package formatters
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func readXLSXParts(t *testing.T, output string) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader([]byte(output)), int64(len(output)))
+	if err != nil {
+		t.Fatalf("output is not a valid zip archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file.Name, err)
+		}
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+func TestXLSXFormatter(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := &api.PrettyData{
+		Schema: &api.PrettyObject{
+			Fields: []api.PrettyField{
+				{Name: "id", Label: "Order ID"},
+				{
+					Name:   "items",
+					Format: api.FormatTable,
+					TableOptions: api.PrettyTable{
+						HeaderStyle: "bg-blue-500 text-white",
+						Fields: []api.PrettyField{
+							{Name: "name", Label: "Name"},
+							{Name: "price", Label: "Price", Format: api.FormatCurrency},
+							{Name: "created", Label: "Created", Format: api.FormatDate},
+							{Name: "active", Label: "Active"},
+						},
+					},
+				},
+			},
+		},
+		Values: map[string]api.FieldValue{
+			"id": {Value: "ORD-1"},
+		},
+		Tables: map[string][]api.PrettyDataRow{
+			"items": {
+				{
+					"name":    {Value: "Widget & Co"},
+					"price":   {Value: 12.5},
+					"created": {Value: created},
+					"active":  {Value: true},
+				},
+			},
+		},
+	}
+
+	output, err := NewXLSXFormatter().FormatPrettyData(data)
+	if err != nil {
+		t.Fatalf("XLSX format failed: %v", err)
+	}
+	parts := readXLSXParts(t, output)
+
+	t.Run("Parts", func(t *testing.T) {
+		for _, name := range []string{
+			"[Content_Types].xml",
+			"_rels/.rels",
+			"xl/workbook.xml",
+			"xl/_rels/workbook.xml.rels",
+			"xl/styles.xml",
+			"xl/worksheets/sheet1.xml",
+			"xl/worksheets/sheet2.xml",
+		} {
+			if _, ok := parts[name]; !ok {
+				t.Errorf("workbook should contain %s", name)
+			}
+		}
+	})
+
+	t.Run("Sheets", func(t *testing.T) {
+		workbook := parts["xl/workbook.xml"]
+		if !strings.Contains(workbook, `name="Summary"`) {
+			t.Error("workbook should contain a Summary sheet")
+		}
+		if !strings.Contains(workbook, `name="Items"`) {
+			t.Error("workbook should contain an Items sheet")
+		}
+		if !strings.Contains(parts["xl/worksheets/sheet1.xml"], "Order ID") {
+			t.Error("summary sheet should use field labels")
+		}
+	})
+
+	t.Run("TypedCells", func(t *testing.T) {
+		sheet := parts["xl/worksheets/sheet2.xml"]
+		if !strings.Contains(sheet, "Widget &amp; Co") {
+			t.Error("text cells should be XML escaped")
+		}
+		if !strings.Contains(sheet, "<v>12.5</v>") {
+			t.Error("currency should be written as a numeric cell")
+		}
+		if !strings.Contains(sheet, "<v>45352.5</v>") {
+			t.Error("dates should be written as Excel serial numbers")
+		}
+		if !strings.Contains(sheet, `t="b"><v>1</v>`) {
+			t.Error("booleans should be written as boolean cells")
+		}
+		if !strings.Contains(sheet, `state="frozen"`) {
+			t.Error("header row should be frozen")
+		}
+		if !strings.Contains(sheet, "<cols>") {
+			t.Error("sheet should define column widths")
+		}
+	})
+
+	t.Run("Styles", func(t *testing.T) {
+		styles := parts["xl/styles.xml"]
+		if !strings.Contains(styles, `formatCode="&#34;$&#34;#,##0.00"`) {
+			t.Error("styles should contain a currency number format")
+		}
+		if !strings.Contains(styles, "yyyy-mm-dd hh:mm:ss") {
+			t.Error("styles should contain a date number format")
+		}
+		if !strings.Contains(styles, `patternType="solid"`) {
+			t.Error("header_style background should map to a cell fill")
+		}
+	})
+}
+
+func TestXLSXFormatterSlice(t *testing.T) {
+	output, err := NewFormatManager().Format("xlsx", []TestStruct{{Name: "John Doe", Age: 30}})
+	if err != nil {
+		t.Fatalf("XLSX format failed: %v", err)
+	}
+	parts := readXLSXParts(t, output)
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, "John Doe") {
+		t.Error("sheet should contain John Doe")
+	}
+	if !strings.Contains(sheet, "<v>30</v>") {
+		t.Error("age should be written as a numeric cell")
+	}
+	if strings.Contains(sheet, "this should not appear") {
+		t.Error("sheet should not contain hidden field value")
+	}
+}
+
+func TestUniqueSheetName(t *testing.T) {
+	used := map[string]bool{}
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Orders", "Orders"},
+		{"orders", "orders (2)"},
+		{"a/b:c", "a_b_c"},
+		{strings.Repeat("x", 40), strings.Repeat("x", 31)},
+		{"", "Sheet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := uniqueSheetName(tt.name, used); got != tt.expected {
+				t.Errorf("uniqueSheetName(%q) = %q, want %q", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/flanksource/clicky/api"
 	"github.com/flanksource/commons/collections"
 	flanksourceContext "github.com/flanksource/commons/context"
 	"github.com/flanksource/commons/logger"
@@ -40,8 +41,9 @@ type Manager struct {
 	onInterrupt      func() // optional cleanup callback
 	signalMu         sync.Mutex
 	shutdownOnce     sync.Once
-	noColor          bool // Disable colored output
-	noProgress       bool // Disable progress display
+	noColor          bool       // Disable colored output
+	noProgress       bool       // Disable progress display
+	theme            *api.Theme // Theme of the task statuses, nil for the theme selected with api.SetTheme
 
 	// Priority queue for task scheduling
 	taskQueue     *collections.Queue[*Task]
@@ -202,6 +204,11 @@ func SetNoColor(noColor bool) {
 	global.noColor = noColor
 }
 
+// SetTheme sets the theme of the task status icons and colours
+func SetTheme(theme api.Theme) {
+	global.theme = &theme
+}
+
 // SetNoProgress enables or disables progress display
 func SetNoProgress(noProgress bool) {
 	global.noProgress = noProgress
@@ -48,24 +48,35 @@ func (s Status) String() string {
 	return string(s)
 }
 
-// Icon returns the emoji icon representation of the status
+// Icon returns the emoji icon representation of the status, or the icon the selected
+// theme sets for it
 func (s Status) Icon() string {
+	return s.ThemeIcon(selectedTheme())
+}
+
+// ThemeIcon returns the icon theme sets for the status, or its emoji icon when theme is nil
+func (s Status) ThemeIcon(theme *api.Theme) string {
+	var name, icon string
 	switch s {
 	case StatusPending:
-		return "⏳"
+		name, icon = "pending", "⏳"
 	case StatusRunning:
-		return "⟳"
+		name, icon = "running", "⟳"
 	case StatusSuccess, StatusPASS:
-		return "✓"
+		name, icon = "success", "✓"
 	case StatusFailed, StatusFAIL:
-		return "✗"
+		name, icon = "failed", "✗"
 	case StatusWarning, StatusERR:
-		return "⚠"
+		name, icon = "warning", "⚠"
 	case StatusCancelled, StatusSKIP:
-		return "⊘"
+		name, icon = "canceled", "⊘"
 	default:
 		return ""
 	}
+	if theme != nil {
+		return theme.Icon(name, icon)
+	}
+	return icon
 }
 
 // Style returns the CSS style class for the status
@@ -76,18 +87,63 @@ func (s Status) Style() string {
 	return s.Health().Style()
 }
 
+// Color returns the colour of the status in the selected theme, or nil when no theme
+// is selected
+func (s Status) Color() *api.Color {
+	return s.ThemeColor(selectedTheme())
+}
+
+// ThemeColor returns the colour of the status in theme, or nil when theme is nil
+func (s Status) ThemeColor(theme *api.Theme) *api.Color {
+	if theme == nil {
+		return nil
+	}
+	color := theme.Muted
+	switch {
+	case s == StatusRunning:
+		color = theme.Info
+	case s.Health() == HealthOK:
+		color = theme.Success
+	case s.Health() == HealthWarning:
+		color = theme.Warning
+	case s.Health() == HealthError:
+		color = theme.Error
+	}
+	if hex := api.ColorHex(color); hex != "" {
+		return &api.Color{Hex: hex}
+	}
+	return nil
+}
+
 // Apply applies the status icon and style to the given text
 func (s Status) Apply(t api.Text) api.Text {
-	t.Content = fmt.Sprintf("%s %s", s.Icon(), t.Content)
+	return s.ApplyTheme(selectedTheme(), t)
+}
+
+// ApplyTheme applies the status icon and style of theme to the given text
+func (s Status) ApplyTheme(theme *api.Theme, t api.Text) api.Text {
+	t.Content = fmt.Sprintf("%s %s", s.ThemeIcon(theme), t.Content)
 	t.Style = s.Style()
+	if color := s.ThemeColor(theme); color != nil {
+		t.Class.Foreground = color
+	}
 	return t
 }
 
+// selectedTheme returns the theme selected with api.SetTheme, or nil
+func selectedTheme() *api.Theme {
+	if theme, ok := api.SelectedTheme(); ok {
+		return &theme
+	}
+	return nil
+}
+
 // Pretty returns a pretty formatted text representation of the status
 func (s Status) Pretty() api.Text {
 	return api.Text{
 		Content: s.Icon() + " " + s.String(),
 		Style:   s.Style(),
+		Class:   api.Class{Foreground: s.Color()},
 	}
 }
 
@@ -607,6 +663,14 @@ func (t *Task) getDuration() string {
 	return text.HumanizeDuration(end.Sub(t.startTime))
 }
 
+// theme returns the theme of the task's manager, then the theme selected with api.SetTheme
+func (t *Task) theme() *api.Theme {
+	if t.manager != nil && t.manager.theme != nil {
+		return t.manager.theme
+	}
+	return selectedTheme()
+}
+
 // Pretty returns a formatted text representation of the task
 func (t *Task) Pretty() api.Text {
 	if pretty, ok := t.result.(formatters.PrettyMixin); ok {
@@ -627,7 +691,7 @@ func (t *Task) Pretty() api.Text {
 
 	text.Content = fmt.Sprintf("%s %-10s", lo.Ellipsis(displayName, api.GetTerminalWidth()-10), duration)
 
-	text = t.Status().Apply(text)
+	text = t.Status().ApplyTheme(t.theme(), text)
 
 	level := t.ctx.Logger.GetLevel()
 	// Add logs as children if present
@@ -0,0 +1,27 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flanksource/clicky/api"
+)
+
+func TestStatusTheme(t *testing.T) {
+	if icon := StatusSuccess.ThemeIcon(nil); icon != "✓" {
+		t.Errorf("expected the default icon without a theme, got %q", icon)
+	}
+	if color := StatusFailed.ThemeColor(nil); color != nil {
+		t.Errorf("expected no colour without a theme, got %v", color)
+	}
+
+	theme := api.DarkTheme()
+	theme.Icons = map[string]string{"success": "OK"}
+	text := StatusSuccess.ApplyTheme(&theme, api.Text{Content: "build"})
+	if !strings.HasPrefix(text.Content, "OK ") {
+		t.Errorf("expected the theme icon, got %q", text.Content)
+	}
+	if text.Class.Foreground == nil {
+		t.Errorf("expected the success colour of the theme")
+	}
+}